@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -316,13 +318,12 @@ func runAcknowledgementDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runAcknowledgementList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := fetchAcknowledgements(ctx, client, did)
 	if err != nil {
@@ -383,3 +384,280 @@ func runAcknowledgementList(ctx context.Context, cmd *cli.Command) error {
 func runAcknowledgementGet(ctx context.Context, cmd *cli.Command) error {
 	return runSimpleGet(ctx, cmd, atproto.CollectionAcknowledgement, "acknowledgement")
 }
+
+// --- Acknowledgement Inbox ---
+
+// ackTarget is one of the caller's records that others may reference.
+type ackTarget struct {
+	URI   string
+	CID   string
+	Label string
+}
+
+// ackInboxItem is a record in another repo that references one of the caller's records.
+type ackInboxItem struct {
+	URI        string // the referencing record
+	CID        string
+	DID        string
+	IssuerName string
+	Collection string
+	Path       string // field that links to the target, e.g. ".subject.uri"
+	Target     ackTarget
+}
+
+// pendingAckItems drops references from the caller's own repo, references the
+// caller has already acknowledged or rejected, and repeats of the same record.
+func pendingAckItems(items []ackInboxItem, acks []acknowledgementOption, did string) []ackInboxItem {
+	done := map[string]bool{}
+	for _, a := range acks {
+		done[a.Subject] = true
+	}
+	var pending []ackInboxItem
+	for _, item := range items {
+		if item.DID == did || done[item.URI] {
+			continue
+		}
+		done[item.URI] = true
+		pending = append(pending, item)
+	}
+	return pending
+}
+
+// fetchAckTargets lists the caller's activities, contributors, and collections.
+func fetchAckTargets(ctx context.Context, client *atclient.APIClient, did string) ([]ackTarget, error) {
+	var targets []ackTarget
+	for _, coll := range []string{atproto.CollectionActivity, atproto.CollectionContributorInfo, atproto.CollectionCollection} {
+		entries, err := atproto.ListAllRecords(ctx, client, did, coll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", coll, err)
+		}
+		for _, e := range entries {
+			label := mapStr(e.Value, "title")
+			if label == "" {
+				label = mapStr(e.Value, "displayName")
+			}
+			if label == "" {
+				label = mapStr(e.Value, "identifier")
+			}
+			if label == "" {
+				label = extractRkey(e.URI)
+			}
+			targets = append(targets, ackTarget{URI: e.URI, CID: e.CID, Label: label})
+		}
+	}
+	return targets, nil
+}
+
+// fetchAckInbox finds records in other repos that reference the caller's
+// activities, contributors, or collections and have not been acknowledged yet.
+func fetchAckInbox(ctx context.Context, cmd *cli.Command, client *atclient.APIClient, did string) ([]ackInboxItem, error) {
+	targets, err := fetchAckTargets(ctx, client, did)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ackInboxItem
+	for _, t := range targets {
+		summary, err := atproto.GetAllBacklinks(ctx, t.URI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query backlinks: %w", err)
+		}
+		for coll, paths := range summary.Links {
+			if coll == atproto.CollectionAcknowledgement {
+				continue
+			}
+			for path, counts := range paths {
+				if counts.Records == 0 {
+					continue
+				}
+				links, err := atproto.GetAllBacklinkRecords(ctx, t.URI, coll, path)
+				if err != nil {
+					return nil, fmt.Errorf("failed to query backlinks: %w", err)
+				}
+				for _, lr := range links {
+					items = append(items, ackInboxItem{
+						URI:        lr.Ref().URI(),
+						DID:        lr.DID,
+						Collection: lr.Collection,
+						Path:       path,
+						Target:     t,
+					})
+				}
+			}
+		}
+	}
+
+	acks, err := fetchAcknowledgements(ctx, client, did)
+	if err != nil {
+		return nil, err
+	}
+	items = pendingAckItems(items, acks, did)
+	sort.Slice(items, func(i, j int) bool { return items[i].URI < items[j].URI })
+
+	refs := make([]atproto.RecordRef, len(items))
+	for i, item := range items {
+		refs[i] = atproto.RecordRef{DID: item.DID, Collection: item.Collection, Rkey: extractRkey(item.URI)}
+	}
+	handles := map[string]string{}
+	var live []ackInboxItem
+	for i, res := range getRecordsAnyRepo(ctx, cmd, refs) {
+		if res.Err != nil {
+			continue // deleted since it was indexed
+		}
+		item := items[i]
+		item.CID = res.CID
+		if _, ok := handles[item.DID]; !ok {
+			handles[item.DID] = ""
+			if ident, err := resolveIdent(ctx, cmd, item.DID); err == nil && !ident.Handle.IsInvalidHandle() {
+				handles[item.DID] = ident.Handle.String()
+			}
+		}
+		item.IssuerName = handles[item.DID]
+		live = append(live, item)
+	}
+	return live, nil
+}
+
+// createAck writes an acknowledgement of item, with the caller's referenced record as context.
+func createAck(ctx context.Context, client *atclient.APIClient, item ackInboxItem, acknowledged bool, comment string) (string, error) {
+	record := map[string]any{
+		"$type":        atproto.CollectionAcknowledgement,
+		"subject":      buildStrongRef(item.URI, item.CID),
+		"context":      buildStrongRef(item.Target.URI, item.Target.CID),
+		"acknowledged": acknowledged,
+		"createdAt":    time.Now().UTC().Format(time.RFC3339),
+	}
+	if comment != "" {
+		record["comment"] = comment
+	}
+	uri, _, err := atproto.CreateRecord(ctx, client, atproto.CollectionAcknowledgement, record)
+	return uri, err
+}
+
+func ackItemFrom(item ackInboxItem) string {
+	if item.IssuerName != "" {
+		return item.IssuerName
+	}
+	return item.DID
+}
+
+func runAcknowledgementInbox(ctx context.Context, cmd *cli.Command) error {
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+	did := client.AccountDID.String()
+
+	ackURI, rejectURI := cmd.String("ack"), cmd.String("reject")
+	if ackURI != "" && rejectURI != "" {
+		return fmt.Errorf("use only one of --ack or --reject")
+	}
+
+	items, err := fetchAckInbox(ctx, cmd, client, did)
+	if err != nil {
+		return err
+	}
+
+	// Non-interactive: respond to one record by AT-URI
+	if ackURI != "" || rejectURI != "" {
+		uri := ackURI + rejectURI
+		for _, item := range items {
+			if item.URI != uri {
+				continue
+			}
+			created, err := createAck(ctx, client, item, ackURI != "", cmd.String("comment"))
+			if err != nil {
+				return fmt.Errorf("failed to create acknowledgement: %w", err)
+			}
+			fmt.Fprintf(w, "\033[32m✓\033[0m Created acknowledgement: %s\n", created)
+			return nil
+		}
+		return fmt.Errorf("%s is not a pending reference to your records", uri)
+	}
+
+	if cmd.Bool("review") {
+		return reviewAckInbox(ctx, client, w, items)
+	}
+
+	if cmd.Bool("json") {
+		var out []map[string]any
+		for _, item := range items {
+			out = append(out, map[string]any{
+				"uri":        item.URI,
+				"from":       item.DID,
+				"fromHandle": item.IssuerName,
+				"collection": item.Collection,
+				"path":       item.Path,
+				"references": item.Target.URI,
+			})
+		}
+		fmt.Fprintln(w, prettyJSON(out))
+		return nil
+	}
+
+	fmt.Fprintf(w, "\033[1m%-25s %-20s %-30s %s\033[0m\n", "FROM", "TYPE", "REFERENCES", "RECORD")
+	fmt.Fprintf(w, "%-25s %-20s %-30s %s\n",
+		strings.Repeat("-", 23), strings.Repeat("-", 18), strings.Repeat("-", 28), strings.Repeat("-", 40))
+	for _, item := range items {
+		kind := item.Collection[strings.LastIndex(item.Collection, ".")+1:]
+		fmt.Fprintf(w, "%-25s %-20s %-30s %s\n",
+			truncate(ackItemFrom(item), 23), truncate(kind, 18), truncate(item.Target.Label, 28), item.URI)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(w, "\033[90m(no unacknowledged references found)\033[0m")
+	} else {
+		fmt.Fprintln(w, "\nRun 'hc ack inbox --review' to acknowledge or reject them.")
+	}
+	return nil
+}
+
+// reviewAckInbox walks each pending reference and asks whether to acknowledge,
+// reject, or skip it, with an optional comment.
+func reviewAckInbox(ctx context.Context, client *atclient.APIClient, w io.Writer, items []ackInboxItem) error {
+	if len(items) == 0 {
+		fmt.Fprintln(w, "\033[90m(no unacknowledged references found)\033[0m")
+		return nil
+	}
+	for i, item := range items {
+		action := "skip"
+		var comment string
+		kind := item.Collection[strings.LastIndex(item.Collection, ".")+1:]
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewNote().
+					Title(fmt.Sprintf("[%d/%d] %s from %s", i+1, len(items), kind, ackItemFrom(item))).
+					Description(fmt.Sprintf("References %s\n%s", item.Target.Label, item.URI)),
+				huh.NewSelect[string]().
+					Title("Response").
+					Options(
+						huh.NewOption("Acknowledge", "ack"),
+						huh.NewOption("Reject", "reject"),
+						huh.NewOption("Skip", "skip"),
+					).
+					Value(&action),
+				huh.NewInput().
+					Title("Comment").
+					Description("Optional comment (max 10000 chars)").
+					CharLimit(10000).
+					Value(&comment),
+			),
+		).WithTheme(style.Theme())
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return fmt.Errorf("cancelled")
+			}
+			return err
+		}
+		if action == "skip" {
+			continue
+		}
+		uri, err := createAck(ctx, client, item, action == "ack", comment)
+		if err != nil {
+			fmt.Fprintf(w, "  Warning: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(w, "\033[32m✓\033[0m Created acknowledgement: %s\n", uri)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPendingAckItems(t *testing.T) {
+	const me = "did:plc:me"
+	target := ackTarget{URI: "at://did:plc:me/org.hypercerts.claim.activity/a1"}
+	items := []ackInboxItem{
+		{URI: "at://did:plc:eval/org.hypercerts.context.evaluation/e1", DID: "did:plc:eval", Target: target},
+		{URI: "at://did:plc:eval/org.hypercerts.context.evaluation/e1", DID: "did:plc:eval", Target: target},
+		{URI: "at://did:plc:curator/org.hypercerts.collection/c1", DID: "did:plc:curator", Target: target},
+		{URI: "at://did:plc:me/org.hypercerts.context.measurement/m1", DID: me, Target: target},
+		{URI: "at://did:plc:old/org.hypercerts.context.evaluation/e2", DID: "did:plc:old", Target: target},
+	}
+	acks := []acknowledgementOption{
+		{Subject: "at://did:plc:old/org.hypercerts.context.evaluation/e2", Acknowledged: false},
+	}
+
+	got := pendingAckItems(items, acks, me)
+	want := []string{
+		"at://did:plc:eval/org.hypercerts.context.evaluation/e1",
+		"at://did:plc:curator/org.hypercerts.collection/c1",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d items %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i].URI != want[i] {
+			t.Errorf("item %d = %s, want %s", i, got[i].URI, want[i])
+		}
+	}
+}
@@ -147,8 +147,15 @@ func runActivityCreate(ctx context.Context, cmd *cli.Command) error {
 	}
 	w := cmd.Root().Writer
 
-	if gh := cmd.String("from-github"); gh != "" {
-		return runFromGitHub(ctx, cmd, client, gh)
+	imp, repoInput, err := sourceImporter(cmd)
+	if err != nil {
+		return err
+	}
+	if imp != nil {
+		return runFromSource(ctx, cmd, client, imp, repoInput)
+	}
+	if path := cmd.String("from-git"); path != "" {
+		return runFromGit(ctx, cmd, client, path)
 	}
 
 	record := map[string]any{
@@ -322,7 +329,7 @@ func promptContributors(ctx context.Context, client *atclient.APIClient, w io.Wr
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().Title("Role").Description("optional").Value(&role),
-				huh.NewInput().Title("Weight").Description("optional, numeric").Value(&weight),
+				huh.NewInput().Title("Weight").Description("optional, numeric").Validate(validateWeight).Value(&weight),
 				huh.NewConfirm().Title("Add another contributor?").Inline(true).Value(&addAnother),
 			).Title(fmt.Sprintf("Contributor %d", i)),
 		).WithTheme(style.Theme())
@@ -340,7 +347,7 @@ func promptContributors(ctx context.Context, client *atclient.APIClient, w io.Wr
 				"role":  role,
 			}
 		}
-		if weight != "" {
+		if weight = strings.TrimSpace(weight); weight != "" {
 			contribObj["contributionWeight"] = weight
 		}
 
@@ -634,7 +641,7 @@ func runActivityEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update activity: %w", err)
 	}
@@ -807,12 +814,11 @@ func findLinkedURIs(ctx context.Context, client *atclient.APIClient, did, collec
 }
 
 func runActivityList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	// Build measurement count map
 	measurementCounts := make(map[string]int)
@@ -928,13 +934,12 @@ func runActivityGet(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("usage: hc activity get <id|at-uri>")
 	}
 
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, arg)
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 	uri := resolveRecordURI(did, atproto.CollectionActivity, arg)
 
 	aturi, err := syntax.ParseATURI(uri)
@@ -1014,10 +1019,10 @@ func runActivityGet(ctx context.Context, cmd *cli.Command) error {
 					strings.Repeat("-", 13), strings.Repeat("-", 10),
 					strings.Repeat("-", 18), strings.Repeat("-", 8),
 					strings.Repeat("-", 8), strings.Repeat("-", 10))
-				for _, lr := range records {
-					rec, _, err := atproto.GetRecord(ctx, client, lr.DID, lr.Collection, lr.Rkey)
-					if err != nil {
-						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch)\033[0m\n", lr.Rkey, truncate(lr.DID, 10))
+				for _, res := range fetchBacklinkRecords(ctx, client, records) {
+					lr, rec := res.Ref, res.Value
+					if res.Err != nil {
+						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch: %v)\033[0m\n", lr.Rkey, truncate(lr.DID, 10), res.Err)
 						continue
 					}
 					metric := truncate(mapStr(rec, "metric"), 18)
@@ -1053,10 +1058,10 @@ func runActivityGet(ctx context.Context, cmd *cli.Command) error {
 					strings.Repeat("-", 13), strings.Repeat("-", 10),
 					strings.Repeat("-", 23), strings.Repeat("-", 10),
 					strings.Repeat("-", 10))
-				for _, lr := range records {
-					rec, _, err := atproto.GetRecord(ctx, client, lr.DID, lr.Collection, lr.Rkey)
-					if err != nil {
-						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch)\033[0m\n", lr.Rkey, truncate(lr.DID, 10))
+				for _, res := range fetchBacklinkRecords(ctx, client, records) {
+					lr, rec := res.Ref, res.Value
+					if res.Err != nil {
+						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch: %v)\033[0m\n", lr.Rkey, truncate(lr.DID, 10), res.Err)
 						continue
 					}
 					title := truncate(mapStr(rec, "title"), 23)
@@ -1089,10 +1094,10 @@ func runActivityGet(ctx context.Context, cmd *cli.Command) error {
 					strings.Repeat("-", 13), strings.Repeat("-", 10),
 					strings.Repeat("-", 33), strings.Repeat("-", 8),
 					strings.Repeat("-", 10))
-				for _, lr := range records {
-					rec, _, err := atproto.GetRecord(ctx, client, lr.DID, lr.Collection, lr.Rkey)
-					if err != nil {
-						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch)\033[0m\n", lr.Rkey, truncate(lr.DID, 10))
+				for _, res := range fetchBacklinkRecords(ctx, client, records) {
+					lr, rec := res.Ref, res.Value
+					if res.Err != nil {
+						fmt.Fprintf(w, "  %-15s %-12s \033[90m(failed to fetch: %v)\033[0m\n", lr.Rkey, truncate(lr.DID, 10), res.Err)
 						continue
 					}
 					summary := truncate(mapStr(rec, "summary"), 33)
@@ -1116,16 +1121,26 @@ func runActivityGet(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// fetchBacklinkRecords fetches the full records behind backlinks concurrently, preserving order.
+func fetchBacklinkRecords(ctx context.Context, client *atclient.APIClient, records []atproto.LinkingRecord) []atproto.FetchResult {
+	refs := make([]atproto.RecordRef, len(records))
+	for i, lr := range records {
+		refs[i] = lr.Ref()
+	}
+	return atproto.GetRecords(ctx, client, refs)
+}
+
 // printBacklinkRecordsJSON fetches and prints full records as JSON.
+// Records that fail to fetch are included with an "_error" field.
 func printBacklinkRecordsJSON(ctx context.Context, client *atclient.APIClient, w io.Writer, records []atproto.LinkingRecord) {
 	var results []map[string]any
-	for _, lr := range records {
-		rec, _, err := atproto.GetRecord(ctx, client, lr.DID, lr.Collection, lr.Rkey)
-		if err != nil {
-			continue
+	for _, res := range fetchBacklinkRecords(ctx, client, records) {
+		rec := res.Value
+		if res.Err != nil {
+			rec = map[string]any{"_error": res.Err.Error()}
 		}
-		rec["_uri"] = fmt.Sprintf("at://%s/%s/%s", lr.DID, lr.Collection, lr.Rkey)
-		rec["_did"] = lr.DID
+		rec["_uri"] = res.Ref.URI()
+		rec["_did"] = res.Ref.DID
 		results = append(results, rec)
 	}
 	fmt.Fprintln(w, prettyJSON(results))
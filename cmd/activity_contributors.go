@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// activityContributor is one entry of an activity's contributors[] with its
+// linked records resolved for display.
+type activityContributor struct {
+	Index       int      `json:"index"`
+	Name        string   `json:"name"`
+	Identity    string   `json:"identity"`
+	Weight      string   `json:"weight,omitempty"`
+	Percent     *float64 `json:"percent,omitempty"`
+	Role        string   `json:"role,omitempty"`
+	Description string   `json:"description,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Details     string   `json:"details,omitempty"`
+}
+
+// parseWeight parses a contributionWeight. Weights must be non-negative numbers.
+func parseWeight(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("weight %q is not a number", s)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("weight %q is negative", s)
+	}
+	return v, nil
+}
+
+// validateWeight is a huh validator for optional weight inputs.
+func validateWeight(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	_, err := parseWeight(s)
+	return err
+}
+
+// contributorWeights parses the weight of every contributor entry.
+// ok[i] is false when entry i has no weight or an unparseable one; the latter
+// are also reported in invalid, so callers can warn or refuse.
+func contributorWeights(contributors []any) (weights []float64, ok []bool, invalid []error) {
+	weights = make([]float64, len(contributors))
+	ok = make([]bool, len(contributors))
+	for i, c := range contributors {
+		cm, _ := c.(map[string]any)
+		s := mapStr(cm, "contributionWeight")
+		if s == "" {
+			continue
+		}
+		v, err := parseWeight(s)
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("contributor %d (%s): %w", i+1, contributorLabel(cm), err))
+			continue
+		}
+		weights[i], ok[i] = v, true
+	}
+	return weights, ok, invalid
+}
+
+// weightPercents returns each weight as a percentage of the total.
+func weightPercents(weights []float64) []float64 {
+	var total float64
+	for _, v := range weights {
+		total += v
+	}
+	out := make([]float64, len(weights))
+	if total == 0 {
+		return out
+	}
+	for i, v := range weights {
+		out[i] = v / total * 100
+	}
+	return out
+}
+
+// normalizeWeights rewrites weights as percentages with two decimals that
+// sum to exactly 100. Rounding remainders go to the largest fractions first.
+func normalizeWeights(weights []float64) ([]string, error) {
+	if len(weights) == 0 {
+		return nil, nil
+	}
+	var total float64
+	for _, v := range weights {
+		total += v
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("weights sum to zero")
+	}
+
+	// Work in hundredths of a percent so the sum is exact.
+	const scale = 10000
+	units := make([]int, len(weights))
+	rem := make([]float64, len(weights))
+	assigned := 0
+	for i, v := range weights {
+		exact := v / total * scale
+		units[i] = int(math.Floor(exact))
+		rem[i] = exact - float64(units[i])
+		assigned += units[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return rem[order[a]] > rem[order[b]] })
+	for i := 0; assigned < scale; i++ {
+		units[order[i%len(order)]]++
+		assigned++
+	}
+
+	out := make([]string, len(weights))
+	for i, u := range units {
+		out[i] = strconv.FormatFloat(float64(u)/100, 'f', -1, 64)
+	}
+	return out, nil
+}
+
+// fetchActivityContributors resolves contributor identities and contribution
+// details for each entry. Linked records are read from whichever repo holds them.
+func fetchActivityContributors(ctx context.Context, cmd *cli.Command, contributors []any) []activityContributor {
+	rows := make([]activityContributor, len(contributors))
+	var refs []atproto.RecordRef
+	type link struct {
+		row     int
+		details bool
+	}
+	var links []link
+
+	for i, c := range contributors {
+		cm, _ := c.(map[string]any)
+		row := activityContributor{Index: i + 1, Weight: mapStr(cm, "contributionWeight")}
+
+		identity := mapMap(cm, "contributorIdentity")
+		if did := mapStr(identity, "identity"); did != "" {
+			row.Identity = did
+		} else if uri := mapStr(identity, "uri"); uri != "" {
+			row.Identity = uri
+			if ref, err := atproto.ParseRecordRef(uri); err == nil {
+				refs = append(refs, ref)
+				links = append(links, link{row: i})
+			}
+		}
+
+		details := mapMap(cm, "contributionDetails")
+		if uri := mapStr(details, "uri"); uri != "" {
+			row.Details = uri
+			if ref, err := atproto.ParseRecordRef(uri); err == nil {
+				refs = append(refs, ref)
+				links = append(links, link{row: i, details: true})
+			}
+		} else {
+			row.Role = mapStr(details, "role")
+		}
+		rows[i] = row
+	}
+
+	if len(refs) > 0 {
+		for j, res := range getRecordsAnyRepo(ctx, cmd, refs) {
+			if res.Err != nil {
+				continue
+			}
+			row := &rows[links[j].row]
+			if links[j].details {
+				row.Role = mapStr(res.Value, "role")
+				row.Description = mapStr(res.Value, "contributionDescription")
+				row.StartDate = mapStr(res.Value, "startDate")
+				row.EndDate = mapStr(res.Value, "endDate")
+			} else {
+				row.Name = mapStr(res.Value, "displayName")
+				if row.Name == "" {
+					row.Name = mapStr(res.Value, "identifier")
+				}
+			}
+		}
+	}
+	for i := range rows {
+		if rows[i].Name == "" {
+			rows[i].Name = rows[i].Identity
+		}
+		if rows[i].Name == "" {
+			rows[i].Name = "unknown"
+		}
+	}
+	return rows
+}
+
+func runActivityContributors(ctx context.Context, cmd *cli.Command) error {
+	arg := cmd.Args().First()
+	if arg == "" {
+		return fmt.Errorf("usage: hc activity contributors <id|at-uri> [--normalize]")
+	}
+	normalize := cmd.Bool("normalize")
+
+	var client *atclient.APIClient
+	var did string
+	var err error
+	if normalize {
+		client, err = requireAuth(ctx, cmd)
+		if err == nil {
+			did = client.AccountDID.String()
+		}
+	} else {
+		client, did, err = requireReadClient(ctx, cmd, arg)
+	}
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+
+	uri := resolveRecordURI(did, atproto.CollectionActivity, arg)
+	aturi, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return fmt.Errorf("invalid URI: %w", err)
+	}
+	if normalize && aturi.Authority().String() != did {
+		return fmt.Errorf("--normalize only works on activities in your own repo")
+	}
+
+	activity, cid, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
+	if err != nil {
+		return fmt.Errorf("failed to get activity: %w", err)
+	}
+	contributors := mapSlice(activity, "contributors")
+
+	weights, hasWeight, invalid := contributorWeights(contributors)
+
+	if normalize {
+		if len(contributors) == 0 {
+			return fmt.Errorf("activity has no contributors")
+		}
+		if len(invalid) > 0 {
+			return fmt.Errorf("cannot normalize: %w", errors.Join(invalid...))
+		}
+		weighted := 0
+		for _, ok := range hasWeight {
+			if ok {
+				weighted++
+			}
+		}
+		if weighted == 0 {
+			// Nothing to scale: split evenly.
+			for i := range weights {
+				weights[i] = 1
+			}
+		} else if weighted < len(contributors) {
+			return fmt.Errorf("%d of %d contributors have no weight; set weights before normalizing", len(contributors)-weighted, len(contributors))
+		}
+		normalized, err := normalizeWeights(weights)
+		if err != nil {
+			return err
+		}
+		for i, c := range contributors {
+			if cm, ok := c.(map[string]any); ok {
+				cm["contributionWeight"] = normalized[i]
+			}
+		}
+		activity["contributors"] = contributors
+		if _, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), activity, &cid); err != nil {
+			return fmt.Errorf("failed to update activity: %w", err)
+		}
+		fmt.Fprintf(w, "\033[32m✓\033[0m Normalized %d contributor weight(s) on %s\n\n", len(contributors), extractRkey(uri))
+		weights, hasWeight, _ = contributorWeights(contributors)
+	}
+
+	rows := fetchActivityContributors(ctx, cmd, contributors)
+	var weighted []float64
+	var idx []int
+	for i, ok := range hasWeight {
+		if ok {
+			weighted = append(weighted, weights[i])
+			idx = append(idx, i)
+		}
+	}
+	var total float64
+	for _, v := range weighted {
+		total += v
+	}
+	if total > 0 {
+		for j, p := range weightPercents(weighted) {
+			rows[idx[j]].Percent = &p
+		}
+	}
+
+	if cmd.Bool("json") {
+		fmt.Fprintln(w, prettyJSON(rows))
+		return nil
+	}
+	printActivityContributors(w, rows)
+	if missing := len(rows) - len(idx) - len(invalid); len(idx) > 0 && missing > 0 {
+		fmt.Fprintf(w, "\nWarning: %d contributor(s) have no weight and are excluded from shares\n", missing)
+	}
+	for _, err := range invalid {
+		fmt.Fprintf(w, "Warning: %v; shown without a share\n", err)
+	}
+	return nil
+}
+
+func printActivityContributors(w io.Writer, rows []activityContributor) {
+	fmt.Fprintf(w, "\033[1m%-3s %-30s %-8s %-8s %-20s %-30s %s\033[0m\n", "#", "CONTRIBUTOR", "WEIGHT", "SHARE", "ROLE", "DESCRIPTION", "DATES")
+	fmt.Fprintf(w, "%-3s %-30s %-8s %-8s %-20s %-30s %s\n",
+		"-", strings.Repeat("-", 28), strings.Repeat("-", 6), strings.Repeat("-", 6),
+		strings.Repeat("-", 18), strings.Repeat("-", 28), strings.Repeat("-", 23))
+	for _, r := range rows {
+		weight, share := "-", "-"
+		if r.Weight != "" {
+			weight = r.Weight
+		}
+		if r.Percent != nil {
+			share = strconv.FormatFloat(*r.Percent, 'f', 1, 64) + "%"
+		}
+		role := r.Role
+		if role == "" {
+			role = "-"
+		}
+		desc := r.Description
+		if desc == "" {
+			desc = "-"
+		}
+		dates := "-"
+		if r.StartDate != "" || r.EndDate != "" {
+			dates = formatDate(r.StartDate) + ".." + formatDate(r.EndDate)
+		}
+		fmt.Fprintf(w, "%-3d %-30s %-8s %-8s %-20s %-30s %s\n",
+			r.Index, truncate(r.Name, 28), truncate(weight, 8), share, truncate(role, 18), truncate(desc, 28), dates)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "\033[90m(no contributors found)\033[0m")
+	}
+}
+
+// findContributorEntry returns the index of the contributors[] entry that
+// refers to contributorURI (a strongRef) or to one of dids (an inline identity), or -1.
+func findContributorEntry(contributors []any, contributorURI string, dids ...string) int {
+	for i, c := range contributors {
+		cm, _ := c.(map[string]any)
+		identity := mapMap(cm, "contributorIdentity")
+		if identity == nil {
+			continue
+		}
+		if uri := mapStr(identity, "uri"); uri != "" && uri == contributorURI {
+			return i
+		}
+		if inline := mapStr(identity, "identity"); inline != "" && slices.Contains(dids, inline) {
+			return i
+		}
+	}
+	return -1
+}
+
+// refreshContributorRefs points every contributorIdentity or contributionDetails
+// strongRef to uri at cid. It reports whether anything changed.
+func refreshContributorRefs(contributors []any, uri, cid string) bool {
+	changed := false
+	for _, c := range contributors {
+		cm, _ := c.(map[string]any)
+		for _, field := range []string{"contributorIdentity", "contributionDetails"} {
+			ref := mapMap(cm, field)
+			if mapStr(ref, "uri") == uri && mapStr(ref, "cid") != cid {
+				ref["cid"] = cid
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// syncActivityContributorRefs rewrites activities in did's repo whose
+// contributors[] reference uri so their strongRefs carry the record's new cid.
+func syncActivityContributorRefs(ctx context.Context, client *atclient.APIClient, w io.Writer, did, uri, cid string) {
+	activities, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionActivity)
+	if err != nil {
+		fmt.Fprintf(w, "Warning: failed to list activities to refresh references: %v\n", err)
+		return
+	}
+	for _, e := range activities {
+		contributors := mapSlice(e.Value, "contributors")
+		if !refreshContributorRefs(contributors, uri, cid) {
+			continue
+		}
+		aturi, err := syntax.ParseATURI(e.URI)
+		if err != nil {
+			continue
+		}
+		e.Value["contributors"] = contributors
+		swap := e.CID
+		if _, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), e.Value, &swap); err != nil {
+			fmt.Fprintf(w, "Warning: failed to refresh reference in activity %s: %v\n", extractRkey(e.URI), err)
+			continue
+		}
+		fmt.Fprintf(w, "\033[32m✓\033[0m Refreshed reference in activity: %s\n", extractRkey(e.URI))
+	}
+}
@@ -1,6 +1,9 @@
 package cmd
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestContributorLabel(t *testing.T) {
 	tests := []struct {
@@ -90,3 +93,144 @@ func TestContributorLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"45", 45, false},
+		{" 12.5 ", 12.5, false},
+		{"0", 0, false},
+		{"abc", 0, true},
+		{"10%", 0, true},
+		{"-3", 0, true},
+		{"NaN", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseWeight(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWeight(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseWeight(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContributorWeights(t *testing.T) {
+	contributors := []any{
+		map[string]any{"contributionWeight": "3"},
+		map[string]any{},
+		map[string]any{"contributionWeight": "1.5"},
+	}
+	weights, ok, invalid := contributorWeights(contributors)
+	if len(invalid) != 0 {
+		t.Fatal(invalid)
+	}
+	if weights[0] != 3 || weights[2] != 1.5 || !ok[0] || ok[1] || !ok[2] {
+		t.Errorf("contributorWeights() = %v, %v", weights, ok)
+	}
+
+	// A legacy non-numeric weight is reported, not fatal, so views still work.
+	contributors = append(contributors, map[string]any{"contributionWeight": "lots"})
+	weights, ok, invalid = contributorWeights(contributors)
+	if len(invalid) != 1 || ok[3] || !ok[0] || weights[2] != 1.5 {
+		t.Errorf("with non-numeric weight: %v, %v, invalid %v", weights, ok, invalid)
+	}
+}
+
+func TestNormalizeWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+		want    []string
+		wantErr bool
+	}{
+		{"simple", []float64{3, 1}, []string{"75", "25"}, false},
+		{"thirds", []float64{1, 1, 1}, []string{"33.34", "33.33", "33.33"}, false},
+		{"already_percent", []float64{50, 30, 20}, []string{"50", "30", "20"}, false},
+		{"zero_weight", []float64{2, 0}, []string{"100", "0"}, false},
+		{"sevenths", []float64{1, 2, 4}, []string{"14.29", "28.57", "57.14"}, false},
+		{"all_zero", []float64{0, 0}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeWeights(tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("normalizeWeights() = %v, want %v", got, tt.want)
+			}
+			var sum float64
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("normalizeWeights()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+				v, _ := parseWeight(got[i])
+				sum += v
+			}
+			if len(got) > 0 && math.Abs(sum-100) > 1e-9 {
+				t.Errorf("normalized weights sum to %v", sum)
+			}
+		})
+	}
+}
+
+func TestFindContributorEntry(t *testing.T) {
+	aliceURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/alice"
+	contributors := []any{
+		map[string]any{"contributorIdentity": buildStrongRef(aliceURI, "c1")},
+		map[string]any{"contributorIdentity": map[string]any{
+			"$type":    "org.hypercerts.claim.activity#contributorIdentity",
+			"identity": "did:plc:bob",
+		}},
+		map[string]any{},
+	}
+	tests := []struct {
+		name string
+		uri  string
+		dids []string
+		want int
+	}{
+		{"by_strongref", aliceURI, nil, 0},
+		{"by_inline_did", "", []string{"did:plc:bob"}, 1},
+		{"by_identifier", "at://did:plc:me/org.hypercerts.claim.contributorInformation/bob", []string{"did:plc:bob"}, 1},
+		{"missing", "at://did:plc:me/org.hypercerts.claim.contributorInformation/carol", []string{""}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findContributorEntry(contributors, tt.uri, tt.dids...); got != tt.want {
+				t.Errorf("findContributorEntry() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshContributorRefs(t *testing.T) {
+	contribURI := "at://did:plc:me/org.hypercerts.claim.contribution/c1"
+	contributors := []any{
+		map[string]any{
+			"contributorIdentity": buildStrongRef("at://did:plc:me/org.hypercerts.claim.contributorInformation/alice", "a1"),
+			"contributionDetails": buildStrongRef(contribURI, "old"),
+		},
+		map[string]any{
+			"contributionDetails": map[string]any{"role": "reviewer"},
+		},
+	}
+	if !refreshContributorRefs(contributors, contribURI, "new") {
+		t.Fatal("expected a change")
+	}
+	details := mapMap(contributors[0].(map[string]any), "contributionDetails")
+	if mapStr(details, "cid") != "new" {
+		t.Errorf("cid = %q, want new", mapStr(details, "cid"))
+	}
+	if refreshContributorRefs(contributors, contribURI, "new") {
+		t.Error("expected no change when already current")
+	}
+}
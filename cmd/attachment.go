@@ -544,7 +544,7 @@ func runAttachmentEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update attachment: %w", err)
 	}
@@ -618,13 +618,12 @@ func runAttachmentDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runAttachmentList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	// Filter by activity if specified
 	activityFilter := cmd.String("activity")
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -172,13 +174,12 @@ func runBadgeDefinitionCreate(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runBadgeDefinitionList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionBadgeDefinition)
 	if err != nil {
@@ -495,15 +496,219 @@ func runBadgeAwardCreate(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-func runBadgeAwardList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+// badgeRecipient is one row of a bulk award recipients file.
+type badgeRecipient struct {
+	Recipient string // handle or DID as written in the file
+	Note      string
+	URL       string
+}
+
+// parseRecipients reads a recipients CSV: one handle or DID per row, with
+// optional note and url columns. A header row and # comments are skipped.
+func parseRecipients(r io.Reader) ([]badgeRecipient, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var result []badgeRecipient
+	for i := 0; ; i++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recipients: %w", err)
+		}
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		first := strings.ToLower(strings.TrimSpace(row[0]))
+		if i == 0 && (first == "recipient" || first == "handle" || first == "did" || first == "subject") {
+			continue
+		}
+		rec := badgeRecipient{Recipient: strings.TrimPrefix(strings.TrimSpace(row[0]), "@")}
+		if len(row) > 1 {
+			rec.Note = strings.TrimSpace(row[1])
+		}
+		if len(row) > 2 {
+			rec.URL = strings.TrimSpace(row[2])
+		}
+		result = append(result, rec)
+	}
+	return result, nil
+}
+
+// selectBadgeDefinition shows a menu of the caller's badge definitions.
+func selectBadgeDefinition(ctx context.Context, client *atclient.APIClient, w io.Writer) (*badgeDefinitionOption, error) {
+	defs, err := fetchBadgeDefinitions(ctx, client, client.AccountDID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no badge definitions found; create one first (hc badge definition create)")
+	}
+	return menu.SingleSelect(w, defs, "badge definition",
+		func(d badgeDefinitionOption) string { return d.Title },
+		func(d badgeDefinitionOption) string { return d.BadgeType },
+	)
+}
+
+func runBadgeAwardBulk(ctx context.Context, cmd *cli.Command) error {
+	path := cmd.String("recipients")
+	if path == "" {
+		return fmt.Errorf("usage: hc badge award bulk --recipients <file.csv> [--badge <def>]")
+	}
+	batchSize := cmd.Int("batch-size")
+	if batchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open recipients file: %w", err)
+	}
+	recipients, err := parseRecipients(f)
+	f.Close()
 	if err != nil {
 		return err
 	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients in %s", path)
+	}
 
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
 	w := cmd.Root().Writer
 	did := client.AccountDID.String()
 
+	// Badge definition: flag (ID or AT-URI) or interactive selection
+	var badgeURI, badgeCID, badgeTitle string
+	if badgeArg := cmd.String("badge"); badgeArg != "" {
+		badgeURI = resolveRecordURI(did, atproto.CollectionBadgeDefinition, badgeArg)
+		badgeAturi, err := syntax.ParseATURI(badgeURI)
+		if err != nil {
+			return fmt.Errorf("invalid badge URI: %w", err)
+		}
+		def, cid, err := atproto.GetRecord(ctx, client, badgeAturi.Authority().String(), badgeAturi.Collection().String(), badgeAturi.RecordKey().String())
+		if err != nil {
+			return fmt.Errorf("failed to fetch badge: %w", err)
+		}
+		badgeCID, badgeTitle = cid, mapStr(def, "title")
+	} else {
+		def, err := selectBadgeDefinition(ctx, client, w)
+		if err != nil {
+			return err
+		}
+		badgeURI, badgeCID, badgeTitle = def.URI, def.CID, def.Title
+	}
+
+	// Recipients who already hold this badge from us
+	existing, err := fetchBadgeAwards(ctx, client, did)
+	if err != nil {
+		return err
+	}
+	awarded := map[string]bool{}
+	for _, a := range existing {
+		if a.Badge == badgeURI {
+			awarded[a.Subject] = true
+		}
+	}
+
+	fmt.Fprintf(w, "Awarding \033[1m%s\033[0m to %d recipient(s)\n", badgeTitle, len(recipients))
+
+	defaultNote, defaultURL := cmd.String("note"), cmd.String("url")
+	var pending []map[string]any
+	var pendingNames []string
+	var skipped, failed int
+	for _, r := range recipients {
+		recipientDID := r.Recipient
+		if !strings.HasPrefix(recipientDID, "did:") {
+			ident, err := resolveIdent(ctx, cmd, r.Recipient)
+			if err != nil {
+				fmt.Fprintf(w, "  Warning: could not resolve %s: %v\n", r.Recipient, err)
+				failed++
+				continue
+			}
+			recipientDID = ident.DID.String()
+		} else if _, err := syntax.ParseDID(recipientDID); err != nil {
+			fmt.Fprintf(w, "  Warning: invalid DID %s: %v\n", r.Recipient, err)
+			failed++
+			continue
+		}
+		if awarded[recipientDID] {
+			fmt.Fprintf(w, "  \033[90mskip %s (already awarded)\033[0m\n", r.Recipient)
+			skipped++
+			continue
+		}
+		awarded[recipientDID] = true
+
+		record := map[string]any{
+			"$type": atproto.CollectionBadgeAward,
+			"badge": buildStrongRef(badgeURI, badgeCID),
+			"subject": map[string]any{
+				"$type": "app.certified.defs#did",
+				"did":   recipientDID,
+			},
+			"createdAt": time.Now().UTC().Format(time.RFC3339),
+		}
+		note, url := r.Note, r.URL
+		if note == "" {
+			note = defaultNote
+		}
+		if url == "" {
+			url = defaultURL
+		}
+		if note != "" {
+			record["note"] = note
+		}
+		if url != "" {
+			record["url"] = url
+		}
+		pending = append(pending, record)
+		pendingNames = append(pendingNames, r.Recipient)
+	}
+
+	if cmd.Bool("dry-run") {
+		for _, name := range pendingNames {
+			fmt.Fprintf(w, "  would award %s\n", name)
+		}
+		fmt.Fprintf(w, "\nDry run: %d to award, %d skipped, %d failed\n", len(pending), skipped, failed)
+		return nil
+	}
+
+	created := 0
+	for start := 0; start < len(pending); start += batchSize {
+		end := min(start+batchSize, len(pending))
+		uris, err := atproto.CreateRecords(ctx, client, atproto.CollectionBadgeAward, pending[start:end])
+		if err != nil {
+			fmt.Fprintf(w, "  Warning: batch of %d failed: %v\n", end-start, err)
+			failed += end - start
+			continue
+		}
+		for i, uri := range uris {
+			fmt.Fprintf(w, "  \033[32m✓\033[0m %s: %s\n", pendingNames[start+i], uri)
+		}
+		created += len(uris)
+	}
+
+	fmt.Fprintf(w, "\nAwarded %d, skipped %d, failed %d\n", created, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d recipient(s) could not be awarded", failed)
+	}
+	return nil
+}
+
+func runBadgeAwardList(ctx context.Context, cmd *cli.Command) error {
+	client, did, err := requireReadClient(ctx, cmd, "")
+	if err != nil {
+		return err
+	}
+
+	w := cmd.Root().Writer
+
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionBadgeAward)
 	if err != nil {
 		return fmt.Errorf("failed to list badge awards: %w", err)
@@ -793,13 +998,12 @@ func runBadgeResponseCreate(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runBadgeResponseList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionBadgeResponse)
 	if err != nil {
@@ -914,3 +1118,259 @@ func runBadgeResponseDelete(ctx context.Context, cmd *cli.Command) error {
 	fmt.Fprintf(w, "Deleted badge response: %s\n", extractRkey(uri))
 	return nil
 }
+
+// --- Badge Inbox ---
+
+// badgeInboxItem is a badge award issued to the caller from another repo.
+type badgeInboxItem struct {
+	URI        string
+	CID        string
+	Issuer     string // DID of the awarding repo
+	IssuerName string // handle, if it resolves
+	BadgeURI   string
+	BadgeTitle string
+	Note       string
+	Response   string // "accepted", "rejected", or "" if not yet responded
+	Created    string
+}
+
+// buildBadgeInbox pairs fetched awards with the caller's responses and badge
+// definition titles. Awards that failed to fetch are skipped.
+func buildBadgeInbox(awards []atproto.FetchResult, responses []badgeResponseOption, titles map[string]string) []badgeInboxItem {
+	responded := map[string]string{}
+	for _, r := range responses {
+		responded[r.BadgeAward] = r.Response
+	}
+	var items []badgeInboxItem
+	for _, res := range awards {
+		if res.Err != nil {
+			continue
+		}
+		uri := res.Ref.URI()
+		badgeURI := mapStr(mapMap(res.Value, "badge"), "uri")
+		created := ""
+		if t, err := time.Parse(time.RFC3339, mapStr(res.Value, "createdAt")); err == nil {
+			created = t.Format("2006-01-02")
+		}
+		items = append(items, badgeInboxItem{
+			URI:        uri,
+			CID:        res.CID,
+			Issuer:     res.Ref.DID,
+			BadgeURI:   badgeURI,
+			BadgeTitle: titles[badgeURI],
+			Note:       mapStr(res.Value, "note"),
+			Response:   responded[uri],
+			Created:    created,
+		})
+	}
+	return items
+}
+
+// fetchBadgeInbox finds badge awards whose subject is did via the backlink index.
+func fetchBadgeInbox(ctx context.Context, cmd *cli.Command, client *atclient.APIClient, did string) ([]badgeInboxItem, int, error) {
+	links, err := atproto.GetAllBacklinkRecords(ctx, did, atproto.CollectionBadgeAward, ".subject.did")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query backlinks: %w", err)
+	}
+	refs := make([]atproto.RecordRef, len(links))
+	for i, lr := range links {
+		refs[i] = lr.Ref()
+	}
+	awards := getRecordsAnyRepo(ctx, cmd, refs)
+
+	failed := 0
+	var defRefs []atproto.RecordRef
+	seen := map[string]bool{}
+	for _, res := range awards {
+		if res.Err != nil {
+			failed++
+			continue
+		}
+		badgeURI := mapStr(mapMap(res.Value, "badge"), "uri")
+		if seen[badgeURI] {
+			continue
+		}
+		seen[badgeURI] = true
+		if ref, err := atproto.ParseRecordRef(badgeURI); err == nil {
+			defRefs = append(defRefs, ref)
+		}
+	}
+	titles := map[string]string{}
+	for _, res := range getRecordsAnyRepo(ctx, cmd, defRefs) {
+		if res.Err == nil {
+			titles[res.Ref.URI()] = mapStr(res.Value, "title")
+		}
+	}
+
+	responses, err := fetchBadgeResponses(ctx, client, did)
+	if err != nil {
+		return nil, failed, err
+	}
+	items := buildBadgeInbox(awards, responses, titles)
+
+	handles := map[string]string{}
+	for i, item := range items {
+		if _, ok := handles[item.Issuer]; !ok {
+			handles[item.Issuer] = ""
+			if ident, err := resolveIdent(ctx, cmd, item.Issuer); err == nil && !ident.Handle.IsInvalidHandle() {
+				handles[item.Issuer] = ident.Handle.String()
+			}
+		}
+		items[i].IssuerName = handles[item.Issuer]
+	}
+	return items, failed, nil
+}
+
+func runBadgeInbox(ctx context.Context, cmd *cli.Command) error {
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+	did := client.AccountDID.String()
+
+	accept, reject := cmd.Bool("accept"), cmd.Bool("reject")
+	if accept && reject {
+		return fmt.Errorf("use only one of --accept or --reject")
+	}
+	batchSize := cmd.Int("batch-size")
+	if batchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+
+	items, failed, err := fetchBadgeInbox(ctx, cmd, client, did)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		fmt.Fprintf(w, "Warning: %d badge award(s) could not be fetched\n", failed)
+	}
+	if cmd.Bool("pending") || accept || reject {
+		var pending []badgeInboxItem
+		for _, item := range items {
+			if item.Response == "" {
+				pending = append(pending, item)
+			}
+		}
+		items = pending
+	}
+
+	if !accept && !reject {
+		if cmd.Bool("json") {
+			var out []map[string]any
+			for _, item := range items {
+				out = append(out, map[string]any{
+					"uri":        item.URI,
+					"issuer":     item.Issuer,
+					"issuerName": item.IssuerName,
+					"badge":      item.BadgeURI,
+					"badgeTitle": item.BadgeTitle,
+					"note":       item.Note,
+					"response":   item.Response,
+					"createdAt":  item.Created,
+				})
+			}
+			fmt.Fprintln(w, prettyJSON(out))
+			return nil
+		}
+		printBadgeInbox(w, items)
+		return nil
+	}
+
+	response, verb := "accepted", "Accepted"
+	if reject {
+		response, verb = "rejected", "Rejected"
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(w, "\033[90m(no pending badge awards)\033[0m")
+		return nil
+	}
+
+	selected := items
+	if !cmd.Bool("all") {
+		selected, err = menu.MultiSelect(w, items, "badge award",
+			func(item badgeInboxItem) string {
+				if item.BadgeTitle != "" {
+					return item.BadgeTitle
+				}
+				return extractRkey(item.BadgeURI)
+			},
+			func(item badgeInboxItem) string { return "from " + badgeIssuerLabel(item) },
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(w, "Nothing selected.")
+		return nil
+	}
+	if len(selected) > 1 && !menu.Confirm(w, os.Stdin, fmt.Sprintf("Mark %d badge award(s) as %s?", len(selected), response)) {
+		fmt.Fprintln(w, "Aborted.")
+		return nil
+	}
+
+	records := make([]map[string]any, len(selected))
+	for i, item := range selected {
+		records[i] = map[string]any{
+			"$type":      atproto.CollectionBadgeResponse,
+			"badgeAward": buildStrongRef(item.URI, item.CID),
+			"response":   response,
+			"createdAt":  time.Now().UTC().Format(time.RFC3339),
+		}
+	}
+	// PDSes cap the writes in one applyWrites call, so respond in batches.
+	created, failedWrites := 0, 0
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		uris, err := atproto.CreateRecords(ctx, client, atproto.CollectionBadgeResponse, records[start:end])
+		if err != nil {
+			fmt.Fprintf(w, "  Warning: batch of %d failed: %v\n", end-start, err)
+			failedWrites += end - start
+			continue
+		}
+		for i, uri := range uris {
+			item := selected[start+i]
+			fmt.Fprintf(w, "\033[32m✓\033[0m %s %s from %s: %s\n", verb, item.BadgeTitle, badgeIssuerLabel(item), uri)
+		}
+		created += len(uris)
+		if len(records) > batchSize {
+			fmt.Fprintf(w, "  %d/%d responded\n", created+failedWrites, len(records))
+		}
+	}
+	if failedWrites > 0 {
+		return fmt.Errorf("%d of %d badge response(s) could not be created", failedWrites, len(records))
+	}
+	return nil
+}
+
+// badgeIssuerLabel returns the issuer's handle, or DID if it has none.
+func badgeIssuerLabel(item badgeInboxItem) string {
+	if item.IssuerName != "" {
+		return item.IssuerName
+	}
+	return item.Issuer
+}
+
+func printBadgeInbox(w io.Writer, items []badgeInboxItem) {
+	fmt.Fprintf(w, "\033[1m%-15s %-25s %-25s %-30s %-10s %s\033[0m\n", "ID", "ISSUER", "BADGE", "NOTE", "RESPONSE", "AWARDED")
+	fmt.Fprintf(w, "%-15s %-25s %-25s %-30s %-10s %s\n",
+		strings.Repeat("-", 13), strings.Repeat("-", 23), strings.Repeat("-", 23),
+		strings.Repeat("-", 28), strings.Repeat("-", 8), strings.Repeat("-", 10))
+	for _, item := range items {
+		title := item.BadgeTitle
+		if title == "" {
+			title = extractRkey(item.BadgeURI)
+		}
+		response := item.Response
+		if response == "" {
+			response = "pending"
+		}
+		fmt.Fprintf(w, "%-15s %-25s %-25s %-30s %-10s %s\n",
+			extractRkey(item.URI), truncate(badgeIssuerLabel(item), 23), truncate(title, 23),
+			truncate(item.Note, 28), response, item.Created)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(w, "\033[90m(no badge awards found)\033[0m")
+	}
+}
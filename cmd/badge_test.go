@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+func TestParseRecipients(t *testing.T) {
+	input := `recipient,note,url
+# cohort 3
+@alice.example.com,Great work
+did:plc:bob123
+carol.example.com, Mentor , https://example.com/carol
+
+`
+	got, err := parseRecipients(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseRecipients: %v", err)
+	}
+	want := []badgeRecipient{
+		{Recipient: "alice.example.com", Note: "Great work"},
+		{Recipient: "did:plc:bob123"},
+		{Recipient: "carol.example.com", Note: "Mentor", URL: "https://example.com/carol"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d recipients %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("recipient %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseRecipients_noHeader(t *testing.T) {
+	got, err := parseRecipients(strings.NewReader("did:plc:one\ndid:plc:two\n"))
+	if err != nil {
+		t.Fatalf("parseRecipients: %v", err)
+	}
+	if len(got) != 2 || got[0].Recipient != "did:plc:one" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestBuildBadgeInbox(t *testing.T) {
+	defURI := "at://did:plc:issuer/app.certified.badge.definition/d1"
+	awards := []atproto.FetchResult{
+		{
+			Ref:   atproto.RecordRef{DID: "did:plc:issuer", Collection: atproto.CollectionBadgeAward, Rkey: "a1"},
+			CID:   "cid-a1",
+			Value: map[string]any{"badge": map[string]any{"uri": defURI, "cid": "x"}, "note": "thanks", "createdAt": "2025-05-01T12:00:00Z"},
+		},
+		{
+			Ref:   atproto.RecordRef{DID: "did:plc:issuer", Collection: atproto.CollectionBadgeAward, Rkey: "a2"},
+			CID:   "cid-a2",
+			Value: map[string]any{"badge": map[string]any{"uri": defURI, "cid": "x"}},
+		},
+		{
+			Ref: atproto.RecordRef{DID: "did:plc:gone", Collection: atproto.CollectionBadgeAward, Rkey: "a3"},
+			Err: errors.New("not found"),
+		},
+	}
+	responses := []badgeResponseOption{
+		{BadgeAward: "at://did:plc:issuer/app.certified.badge.award/a2", Response: "rejected"},
+	}
+
+	items := buildBadgeInbox(awards, responses, map[string]string{defURI: "Mentor"})
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if it := items[0]; it.BadgeTitle != "Mentor" || it.Note != "thanks" || it.Response != "" || it.Created != "2025-05-01" || it.CID != "cid-a1" {
+		t.Errorf("item 0 = %+v", it)
+	}
+	if items[1].Response != "rejected" {
+		t.Errorf("item 1 response = %q, want rejected", items[1].Response)
+	}
+}
@@ -94,17 +94,23 @@ func promptCollectionItems(ctx context.Context, client *atclient.APIClient, w in
 		return nil, fmt.Errorf("at least one item is required")
 	}
 
-	// Fetch CIDs and optionally prompt for weights
-	var items []map[string]any
+	// Fetch CIDs up front, then optionally prompt for weights
+	refs := make([]atproto.RecordRef, 0, len(selected))
 	for _, a := range selected {
-		aturi, _ := syntax.ParseATURI(a.URI)
-		_, cid, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
-		if err != nil {
+		ref, _ := atproto.ParseRecordRef(a.URI)
+		refs = append(refs, ref)
+	}
+	fetched := atproto.GetRecords(ctx, client, refs)
+
+	var items []map[string]any
+	for i, a := range selected {
+		if fetched[i].Err != nil {
+			fmt.Fprintf(w, "  Warning: skipping activity %s: %v\n", a.Rkey, fetched[i].Err)
 			continue
 		}
 
 		item := map[string]any{
-			"itemIdentifier": buildStrongRef(a.URI, cid),
+			"itemIdentifier": buildStrongRef(a.URI, fetched[i].CID),
 		}
 
 		// Optionally prompt for weight via huh
@@ -507,7 +513,7 @@ func runCollectionEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update collection: %w", err)
 	}
@@ -580,13 +586,12 @@ func runCollectionDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runCollectionList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionCollection)
 	if err != nil {
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// completionCacheTTL is how long a cached record list is used before it is
+// refetched. A stale list is still used when refetching fails.
+const completionCacheTTL = 5 * time.Minute
+
+// completionFetchTimeout bounds the PDS request made while the shell waits.
+const completionFetchTimeout = 3 * time.Second
+
+// completionFlagCollections maps flags that take a record ID to the
+// collection to complete from. --id completes the command's own collection.
+var completionFlagCollections = map[string]string{
+	"activity":    atproto.CollectionActivity,
+	"for":         atproto.CollectionActivity,
+	"contributor": atproto.CollectionContributorInfo,
+	"badge":       atproto.CollectionBadgeDefinition,
+	"badge-award": atproto.CollectionBadgeAward,
+	"parent":      atproto.CollectionWorkScopeTag,
+}
+
+// completionRecordCommands take record IDs as positional arguments.
+var completionRecordCommands = []string{"edit", "delete", "get", "contributors", "sync-github", "merge"}
+
+// completion is one candidate: a value and an optional description.
+type completion struct {
+	Value       string
+	Description string
+}
+
+// commandCollection returns the collection managed by the command group at
+// path (e.g. ["badge", "award"]), from the ls commands in serveResources.
+func commandCollection(path []string) string {
+	for _, res := range serveResources {
+		if len(res.List) > 0 && slices.Equal(res.List[:len(res.List)-1], path) {
+			return res.Collection
+		}
+	}
+	return ""
+}
+
+// findCompletionFlag looks up a flag by name on the command chain, innermost first.
+func findCompletionFlag(chain []*cli.Command, name string) cli.Flag {
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, f := range chain[i].Flags {
+			if slices.Contains(f.Names(), name) {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// takesValue reports whether f consumes the next argument.
+func takesValue(f cli.Flag) bool {
+	_, isBool := f.(*cli.BoolFlag)
+	return !isBool
+}
+
+// completeArgs returns candidates for the last of words, the arguments after
+// "hc" up to and including the word being completed.
+func completeArgs(ctx context.Context, root *cli.Command, words []string) []completion {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	cur := words[len(words)-1]
+	chain := []*cli.Command{root}
+	var path []string
+	var valueFlag cli.Flag // flag awaiting a value in cur
+	for _, w := range words[:len(words)-1] {
+		if w == "=" {
+			continue // bash splits --flag=value at "="
+		}
+		if valueFlag != nil {
+			valueFlag = nil
+			continue
+		}
+		if strings.HasPrefix(w, "-") {
+			name := strings.TrimLeft(w, "-")
+			if strings.Contains(name, "=") {
+				continue
+			}
+			if f := findCompletionFlag(chain, name); f != nil && takesValue(f) {
+				valueFlag = f
+			}
+			continue
+		}
+		c := chain[len(chain)-1]
+		if sub := c.Command(w); sub != nil {
+			chain = append(chain, sub)
+			path = append(path, sub.Name)
+		}
+	}
+	c := chain[len(chain)-1]
+
+	var out []completion
+	switch {
+	case valueFlag != nil:
+		name := valueFlag.Names()[0]
+		coll := completionFlagCollections[name]
+		if name == "id" && len(path) > 0 {
+			coll = commandCollection(path[:len(path)-1])
+		}
+		if coll != "" {
+			out = completionRecords(ctx, root, coll)
+		}
+	case strings.HasPrefix(cur, "-"):
+		for _, f := range c.Flags {
+			usage := ""
+			if df, ok := f.(cli.DocGenerationFlag); ok {
+				usage = df.GetUsage()
+			}
+			out = append(out, completion{"--" + f.Names()[0], usage})
+		}
+	case len(c.Commands) > 0:
+		for _, sub := range c.Commands {
+			if !sub.Hidden {
+				out = append(out, completion{sub.Name, sub.Usage})
+			}
+		}
+	case len(path) > 1 && slices.Contains(completionRecordCommands, c.Name):
+		if coll := commandCollection(path[:len(path)-1]); coll != "" {
+			out = completionRecords(ctx, root, coll)
+		}
+	}
+
+	var matches []completion
+	for _, m := range out {
+		if strings.HasPrefix(m.Value, cur) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// completionCache is the cached record list for one collection.
+type completionCache struct {
+	Fetched time.Time    `json:"fetched"`
+	Records []completion `json:"records"`
+}
+
+func completionCachePath(did, collection string) string {
+	return "hc/records/" + strings.ReplaceAll(did, ":", "_") + "/" + collection + ".json"
+}
+
+func loadCompletionCache(did, collection string) (*completionCache, bool) {
+	path, err := xdg.SearchCacheFile(completionCachePath(did, collection))
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache completionCache
+	if json.Unmarshal(data, &cache) != nil {
+		return nil, false
+	}
+	return &cache, true
+}
+
+func saveCompletionCache(did, collection string, cache *completionCache) error {
+	path, err := xdg.CacheFile(completionCachePath(did, collection))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// completionRecords returns record keys in the logged-in account's collection,
+// labelled with their titles. It uses the cached list when it is fresh and
+// falls back to it when the PDS cannot be reached.
+func completionRecords(ctx context.Context, root *cli.Command, collection string) []completion {
+	sess, err := atproto.LoadAuthSessionFile()
+	if err != nil {
+		return nil
+	}
+	did := sess.DID.String()
+	cache, ok := loadCompletionCache(did, collection)
+	if ok && time.Since(cache.Fetched) < completionCacheTTL {
+		return cache.Records
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionFetchTimeout)
+	defer cancel()
+	client, err := requireAuth(ctx, root)
+	if err == nil {
+		var entries []atproto.RecordEntry
+		if entries, err = atproto.ListAllRecords(ctx, client, did, collection); err == nil {
+			fresh := &completionCache{Fetched: time.Now(), Records: []completion{}}
+			for _, e := range entries {
+				fresh.Records = append(fresh.Records, completion{extractRkey(e.URI), recordLabel(e.Value)})
+			}
+			_ = saveCompletionCache(did, collection, fresh)
+			return fresh.Records
+		}
+	}
+	if ok {
+		return cache.Records
+	}
+	return nil
+}
+
+func runComplete(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	for _, c := range completeArgs(ctx, cmd.Root(), cmd.Args().Slice()) {
+		desc := strings.Join(strings.Fields(c.Description), " ")
+		fmt.Fprintf(w, "%s\t%s\n", c.Value, truncate(desc, 60))
+	}
+	return nil
+}
+
+// Completion scripts call `hc __complete <words>`, which prints one
+// "value<TAB>description" line per candidate.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for hc
+_hc_complete() {
+    local IFS=$'\n' line
+    local -a lines
+    lines=($(hc __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+    COMPREPLY=()
+    for line in "${lines[@]}"; do
+        COMPREPLY+=("${line%%$'\t'*}")
+    done
+    if [[ ${#COMPREPLY[@]} -eq 0 ]]; then
+        compopt -o default 2>/dev/null
+    fi
+}
+complete -F _hc_complete hc
+`,
+	"zsh": `#compdef hc
+# zsh completion for hc
+_hc() {
+    local line
+    local -a opts
+    for line in "${(@f)$(hc __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z $line ]] && continue
+        opts+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+    done
+    if (( ${#opts} )); then
+        _describe 'hc' opts
+    else
+        _files
+    fi
+}
+if [[ "$funcstack[1]" = "_hc" ]]; then
+    _hc "$@"
+else
+    compdef _hc hc
+fi
+`,
+	"fish": `# fish completion for hc
+function __hc_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    hc __complete $tokens[2..-1] 2>/dev/null
+end
+complete -c hc -f -a '(__hc_complete)'
+`,
+}
+
+func runCompletion(ctx context.Context, cmd *cli.Command) error {
+	shell := cmd.Args().First()
+	script, ok := completionScripts[shell]
+	if !ok {
+		return fmt.Errorf("usage: hc completion bash|zsh|fish")
+	}
+	fmt.Fprint(cmd.Root().Writer, script)
+	return nil
+}
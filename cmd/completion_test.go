@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+func TestCompleteArgs(t *testing.T) {
+	setupConfigTest(t, "")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	did := "did:plc:completiontest"
+	if err := atproto.PersistAuthSession(&atproto.AuthSession{DID: "did:plc:completiontest", Handle: "alice.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	caches := map[string][]completion{
+		atproto.CollectionActivity:        {{"3kactivity1", "Mangrove restoration"}, {"3kactivity2", "Reef survey"}},
+		atproto.CollectionBadgeDefinition: {{"3kbadge1", "Verifier"}},
+	}
+	for coll, records := range caches {
+		if err := saveCompletionCache(did, coll, &completionCache{Fetched: time.Now(), Records: records}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		line string
+		want []string // candidates that must be offered
+		not  []string // candidates that must not be offered
+	}{
+		{"", []string{"activity", "badge", "completion"}, []string{"__complete"}},
+		{"act", []string{"activity"}, []string{"badge"}},
+		{"activity ed", []string{"edit"}, []string{"ls"}},
+		{"activity edit ", []string{"3kactivity1\tMangrove restoration", "3kactivity2"}, nil},
+		{"activity edit 3kactivity2", []string{"3kactivity2"}, []string{"3kactivity1"}},
+		{"badge award create --badge ", []string{"3kbadge1\tVerifier"}, []string{"3kactivity1"}},
+		{"badge award create --badge=", []string{"3kbadge1"}, nil},
+		{"--username alice.example.com measurement create --activity ", []string{"3kactivity1"}, nil},
+		{"activity delete --id ", []string{"3kactivity1"}, nil},
+		{"measurement create --", []string{"--activity", "--measurer"}, nil},
+		{"activity ls ", nil, []string{"3kactivity1"}},
+		{"funding create --currency ", nil, []string{"3kactivity1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			words := strings.Split(tt.line, " ")
+			if strings.HasSuffix(tt.line, "=") {
+				// bash passes "--badge=" as "--badge", "=", ""
+				words = append(strings.Split(strings.TrimSuffix(tt.line, "="), " "), "=", "")
+			}
+			var lines []string
+			for _, c := range completeArgs(context.Background(), BuildApp(&bytes.Buffer{}), words) {
+				lines = append(lines, c.Value+"\t"+c.Description)
+			}
+			got := strings.Join(lines, "\n")
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("missing %q in:\n%s", w, got)
+				}
+			}
+			for _, n := range tt.not {
+				if strings.Contains(got, n) {
+					t.Errorf("unexpected %q in:\n%s", n, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandCollection(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"activity", atproto.CollectionActivity},
+		{"badge definition", atproto.CollectionBadgeDefinition},
+		{"workscope", atproto.CollectionWorkScopeTag},
+		{"badge", ""},
+		{"config", ""},
+	}
+	for _, tt := range tests {
+		if got := commandCollection(strings.Fields(tt.path)); got != tt.want {
+			t.Errorf("commandCollection(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var buf bytes.Buffer
+		if err := BuildApp(&buf).Run(context.Background(), []string{"hc", "completion", shell}); err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "hc __complete") {
+			t.Errorf("%s script does not call hc __complete:\n%s", shell, buf.String())
+		}
+	}
+	if err := BuildApp(&bytes.Buffer{}).Run(context.Background(), []string{"hc", "completion", "powershell"}); err == nil {
+		t.Error("unknown shell should fail")
+	}
+
+	var buf bytes.Buffer
+	if err := BuildApp(&buf).Run(context.Background(), []string{"hc", "__complete", "badge", "aw"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "award\t") {
+		t.Errorf("__complete badge aw = %q", buf.String())
+	}
+}
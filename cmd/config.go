@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/config"
+)
+
+// configBinding makes a config key the default for one command's flag.
+type configBinding struct {
+	Key     string
+	Command string // space-separated command path below hc; "" for the root
+	Flag    string
+}
+
+// configBindings lists the flags config keys apply to. The output key also
+// applies to every --json and --format flag.
+var configBindings = []configBinding{
+	{"plc-host", "", "plc-host"},
+	{"backlink-url", "", "backlink-url"},
+	{"pds-host", "account login", "pds-host"},
+	{"activity", "measurement create", "activity"},
+	{"activity", "attachment create", "activity"},
+	{"activity", "funding create", "for"},
+	{"currency", "funding create", "currency"},
+	{"measurer", "measurement create", "measurer"},
+}
+
+// configLoader reads the config file once per app and resolves which account
+// sections apply.
+type configLoader struct {
+	root *cli.Command
+	once sync.Once
+	cfg  *config.Config
+	err  error
+}
+
+func (l *configLoader) load() (*config.Config, error) {
+	l.once.Do(func() { l.cfg, l.err = config.Load() })
+	return l.cfg, l.err
+}
+
+// configAccounts returns the identifiers of the active account whose config
+// section applies: --username or its env vars, else the saved session's
+// handle and DID.
+func configAccounts(root *cli.Command) []string {
+	if u := root.String("username"); u != "" {
+		return []string{u}
+	}
+	// Root flags are still being resolved when root-level bindings are
+	// looked up, so read the username env vars directly too.
+	for _, env := range []string{"HYPER_USERNAME", "ATP_USERNAME"} {
+		if u := os.Getenv(env); u != "" {
+			return []string{u}
+		}
+	}
+	if sess, err := atproto.LoadAuthSessionFile(); err == nil {
+		return []string{sess.Handle, sess.DID.String()}
+	}
+	return nil
+}
+
+// configSource is a flag value source backed by a config key. It comes after
+// a flag's env vars in its source chain, so the precedence is: flag, env var,
+// account section, global setting, built-in default.
+type configSource struct {
+	key    string
+	loader *configLoader
+	// value maps the config value to the flag value; ok=false leaves the flag unset.
+	value func(string) (string, bool)
+}
+
+func (s *configSource) Lookup() (string, bool) {
+	cfg, err := s.loader.load()
+	if err != nil {
+		return "", false // reported by the root Before hook
+	}
+	var accounts []string
+	if len(cfg.Accounts) > 0 {
+		accounts = configAccounts(s.loader.root)
+	}
+	v, _, ok := cfg.Get(s.key, accounts...)
+	if !ok {
+		return "", false
+	}
+	if s.value != nil {
+		return s.value(v)
+	}
+	return v, true
+}
+
+func (s *configSource) String() string   { return fmt.Sprintf("config key %q", s.key) }
+func (s *configSource) GoString() string { return fmt.Sprintf("&configSource{key:%q}", s.key) }
+
+// bindConfig appends config sources to the flags in configBindings and to
+// every --json and --format flag.
+func bindConfig(root *cli.Command, l *configLoader) {
+	jsonOutput := func(v string) (string, bool) {
+		if v == "json" {
+			return "true", true
+		}
+		return "", false
+	}
+	var walk func(path string, c *cli.Command)
+	walk = func(path string, c *cli.Command) {
+		for _, f := range c.Flags {
+			switch f := f.(type) {
+			case *cli.BoolFlag:
+				if f.Name == "json" {
+					f.Sources.Append(cli.NewValueSourceChain(&configSource{key: "output", loader: l, value: jsonOutput}))
+				}
+			case *cli.StringFlag:
+				if f.Name == "format" {
+					f.Sources.Append(cli.NewValueSourceChain(&configSource{key: "output", loader: l}))
+				}
+				for _, b := range configBindings {
+					if b.Command == path && b.Flag == f.Name {
+						f.Sources.Append(cli.NewValueSourceChain(&configSource{key: b.Key, loader: l}))
+					}
+				}
+			}
+		}
+		for _, sub := range c.Commands {
+			walk(strings.TrimSpace(path+" "+sub.Name), sub)
+		}
+	}
+	walk("", root)
+}
+
+// configEnvVars returns the env vars that override key, from its bound flags.
+func configEnvVars(root *cli.Command, key string) []string {
+	var envs []string
+	for _, b := range configBindings {
+		if b.Key != key {
+			continue
+		}
+		c := root
+		for _, name := range strings.Fields(b.Command) {
+			if c = c.Command(name); c == nil {
+				break
+			}
+		}
+		if c == nil {
+			continue
+		}
+		for _, f := range c.Flags {
+			if sf, ok := f.(*cli.StringFlag); ok && sf.Name == b.Flag {
+				for _, env := range sf.Sources.EnvKeys() {
+					if !slices.Contains(envs, env) {
+						envs = append(envs, env)
+					}
+				}
+			}
+		}
+	}
+	return envs
+}
+
+// configEntry is the effective value of a key and where it came from.
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // "env NAME", "account NAME", "global", or "default"
+	Usage  string `json:"usage"`
+}
+
+func resolveConfigEntry(root *cli.Command, cfg *config.Config, k config.Key, accounts []string) configEntry {
+	e := configEntry{Key: k.Name, Source: "default", Usage: k.Usage}
+	for _, env := range configEnvVars(root, k.Name) {
+		if v := os.Getenv(env); v != "" {
+			e.Value, e.Source = v, "env "+env
+			return e
+		}
+	}
+	if v, src, ok := cfg.Get(k.Name, accounts...); ok {
+		e.Value, e.Source = v, src
+	}
+	return e
+}
+
+// configTarget returns the accounts whose sections apply to get and list.
+func configTarget(cmd *cli.Command) []string {
+	if a := cmd.String("account"); a != "" {
+		return []string{a}
+	}
+	return configAccounts(cmd.Root())
+}
+
+func runConfigGet(ctx context.Context, cmd *cli.Command) error {
+	key := cmd.Args().First()
+	k, ok := config.LookupKey(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q (see: hc config list)", key)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	e := resolveConfigEntry(cmd.Root(), cfg, k, configTarget(cmd))
+	if e.Source == "default" {
+		return fmt.Errorf("%s is not set", key)
+	}
+	fmt.Fprintln(cmd.Root().Writer, e.Value)
+	return nil
+}
+
+func runConfigSet(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if cmd.Args().Len() != 2 {
+		return fmt.Errorf("usage: hc config set <key> <value> [--account <handle|did>]")
+	}
+	key, value := cmd.Args().Get(0), cmd.Args().Get(1)
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	account := cmd.String("account")
+	if err := cfg.Set(key, value, account); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	scope := "globally"
+	if account != "" {
+		scope = "for " + account
+	}
+	fmt.Fprintf(w, "\033[32m✓\033[0m Set %s = %s %s\n", key, value, scope)
+	for _, env := range configEnvVars(cmd.Root(), key) {
+		if os.Getenv(env) != "" {
+			fmt.Fprintf(w, "Warning: %s is set and takes precedence over the config file\n", env)
+		}
+	}
+	return nil
+}
+
+func runConfigUnset(ctx context.Context, cmd *cli.Command) error {
+	key := cmd.Args().First()
+	if _, ok := config.LookupKey(key); !ok {
+		return fmt.Errorf("unknown config key %q (see: hc config list)", key)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if !cfg.Unset(key, cmd.String("account")) {
+		return fmt.Errorf("%s is not set", key)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Fprintf(cmd.Root().Writer, "Unset %s\n", key)
+	return nil
+}
+
+func runConfigList(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	accounts := configTarget(cmd)
+	var entries []configEntry
+	for _, k := range config.Keys {
+		entries = append(entries, resolveConfigEntry(cmd.Root(), cfg, k, accounts))
+	}
+	if cmd.Bool("json") {
+		fmt.Fprintln(w, prettyJSON(entries))
+		return nil
+	}
+
+	path, _ := config.Path()
+	fmt.Fprintf(w, "Config file: %s\n\n", path)
+	fmt.Fprintf(w, "\033[1m%-14s %-42s %-28s %s\033[0m\n", "KEY", "VALUE", "SOURCE", "DESCRIPTION")
+	fmt.Fprintf(w, "%-14s %-42s %-28s %s\n",
+		strings.Repeat("-", 12), strings.Repeat("-", 40), strings.Repeat("-", 26), strings.Repeat("-", 20))
+	for _, e := range entries {
+		value := e.Value
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(w, "%-14s %-42s %-28s %s\n", e.Key, truncate(value, 40), truncate(e.Source, 26), e.Usage)
+	}
+	fmt.Fprintln(w, "\nPrecedence: command-line flag, then environment variable, then account section, then global setting.")
+	return nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/config"
+)
+
+func setupConfigTest(t *testing.T, data string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	for _, env := range []string{"HYPER_USERNAME", "ATP_USERNAME", "ATP_PLC_HOST", "ATP_PDS_HOST", "HYPER_BACKLINK_URL"} {
+		t.Setenv(env, "") // restored after the test
+		_ = os.Unsetenv(env)
+	}
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	if data == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "hc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "hc", "config.toml"), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigBindingsExist(t *testing.T) {
+	app := BuildApp(&bytes.Buffer{})
+	for _, b := range configBindings {
+		if _, ok := config.LookupKey(b.Key); !ok {
+			t.Errorf("binding for unknown key %q", b.Key)
+		}
+		c := app
+		for _, name := range strings.Fields(b.Command) {
+			if c = c.Command(name); c == nil {
+				break
+			}
+		}
+		if c == nil {
+			t.Errorf("binding %q: no command %q", b.Key, b.Command)
+			continue
+		}
+		found := false
+		for _, f := range c.Flags {
+			if _, ok := f.(*cli.StringFlag); ok && f.Names()[0] == b.Flag {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("binding %q: command %q has no string flag --%s", b.Key, b.Command, b.Flag)
+		}
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	setupConfigTest(t, `
+output = "json"
+currency = "USD"
+plc-host = "https://plc.example.com"
+
+[accounts."alice.example.com"]
+currency = "EUR"
+`)
+
+	// probe runs hc with the action of the command at path replaced, and
+	// returns that command so tests can read the flag values it saw.
+	probe := func(path string, args ...string) *cli.Command {
+		t.Helper()
+		app := BuildApp(&bytes.Buffer{})
+		c := app
+		for _, name := range strings.Fields(path) {
+			c = c.Command(name)
+		}
+		c.Action = func(context.Context, *cli.Command) error { return nil }
+		if err := app.Run(context.Background(), append([]string{"hc"}, args...)); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	c := probe("funding create", "funding", "create")
+	if c.String("currency") != "USD" || c.Root().String("plc-host") != "https://plc.example.com" {
+		t.Errorf("global: currency=%q plc-host=%q", c.String("currency"), c.Root().String("plc-host"))
+	}
+	if c := probe("funding create", "--username", "alice.example.com", "funding", "create"); c.String("currency") != "EUR" {
+		t.Errorf("account section: currency=%q, want EUR", c.String("currency"))
+	}
+	if c := probe("funding create", "funding", "create", "--currency", "GBP"); c.String("currency") != "GBP" {
+		t.Errorf("flag: currency=%q, want GBP", c.String("currency"))
+	}
+	if c := probe("funding ls", "funding", "ls"); !c.Bool("json") {
+		t.Error("output = json should default --json to true")
+	}
+	if c := probe("funding ls", "funding", "ls", "--json=false"); c.Bool("json") {
+		t.Error("--json=false should override the config")
+	}
+	if c := probe("measurement stats", "measurement", "stats"); c.String("format") != "json" {
+		t.Errorf("--format = %q, want json from output", c.String("format"))
+	}
+	t.Setenv("ATP_PLC_HOST", "https://plc.env.example.com")
+	if c := probe("funding create", "funding", "create"); c.Root().String("plc-host") != "https://plc.env.example.com" {
+		t.Errorf("env: plc-host=%q, want the env value", c.Root().String("plc-host"))
+	}
+	if c := probe("funding create", "--plc-host", "https://plc.flag.example.com", "funding", "create"); c.Root().String("plc-host") != "https://plc.flag.example.com" {
+		t.Errorf("flag: plc-host=%q, want the flag value", c.Root().String("plc-host"))
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	setupConfigTest(t, "")
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		err := BuildApp(&buf).Run(context.Background(), append([]string{"hc", "config"}, args...))
+		return buf.String(), err
+	}
+
+	if _, err := run("get", "currency"); err == nil {
+		t.Error("get of an unset key should fail")
+	}
+	if _, err := run("set", "output", "yaml"); err == nil {
+		t.Error("invalid value should fail")
+	}
+	if _, err := run("set", "colour", "red"); err == nil {
+		t.Error("unknown key should fail")
+	}
+	if _, err := run("set", "currency", "USD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("set", "currency", "EUR", "--account", "alice.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := run("get", "currency"); strings.TrimSpace(out) != "USD" {
+		t.Errorf("get currency = %q, want USD", out)
+	}
+	if out, _ := run("get", "currency", "--account", "alice.example.com"); strings.TrimSpace(out) != "EUR" {
+		t.Errorf("get currency for alice = %q, want EUR", out)
+	}
+
+	t.Setenv("ATP_PLC_HOST", "https://plc.env.example.com")
+	out, err := run("list", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []configEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("list --json: %v\n%s", err, out)
+	}
+	sources := map[string]string{}
+	for _, e := range entries {
+		sources[e.Key] = e.Source
+	}
+	if sources["currency"] != "global" || sources["plc-host"] != "env ATP_PLC_HOST" || sources["activity"] != "default" {
+		t.Errorf("sources = %v", sources)
+	}
+
+	if _, err := run("unset", "currency", "--account", "alice.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("unset", "currency", "--account", "alice.example.com"); err == nil {
+		t.Error("second unset should fail")
+	}
+}
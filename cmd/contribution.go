@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -78,6 +79,19 @@ func runContributionCreate(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 	w := cmd.Root().Writer
+	did := client.AccountDID.String()
+
+	// Resolve the activity entry up front so a bad --activity/--contributor
+	// doesn't leave an unreferenced contribution behind.
+	var link *contributionLink
+	if activity := cmd.String("activity"); activity != "" {
+		link, err = resolveContributionLink(ctx, client, w, did, activity, cmd.String("contributor"))
+		if err != nil {
+			return err
+		}
+	} else if cmd.String("contributor") != "" {
+		return fmt.Errorf("--contributor requires --activity")
+	}
 
 	record := map[string]any{
 		"$type":     atproto.CollectionContribution,
@@ -141,15 +155,184 @@ func runContributionCreate(ctx context.Context, cmd *cli.Command) error {
 		record["endDate"] = normalizeDate(endDate)
 	}
 
-	uri, _, err := atproto.CreateRecord(ctx, client, atproto.CollectionContribution, record)
+	// The form may have taken a while: check the activity is unchanged so the
+	// swap-CID update in attach is expected to succeed.
+	if link != nil {
+		if err := link.checkUnchanged(ctx, client, did); err != nil {
+			return err
+		}
+	}
+
+	uri, err := createLinkedContribution(ctx, client, did, record, link)
 	if err != nil {
-		return fmt.Errorf("failed to create contribution: %w", err)
+		return err
 	}
 
 	fmt.Fprintf(w, "\n\033[32m✓\033[0m Created contribution: %s\n", uri)
+	if link != nil {
+		fmt.Fprintf(w, "\033[32m✓\033[0m Linked to %s in activity: %s\n", link.label, extractRkey(link.activityURI))
+	}
+	return nil
+}
+
+// createLinkedContribution creates a contribution record and attaches it to
+// link's activity entry, if any. When attaching fails the new record is
+// deleted again, so the activity never misses a contribution that exists.
+func createLinkedContribution(ctx context.Context, client *atclient.APIClient, did string, record map[string]any, link *contributionLink) (string, error) {
+	uri, cid, err := atproto.CreateRecord(ctx, client, atproto.CollectionContribution, record)
+	if err != nil {
+		return "", fmt.Errorf("failed to create contribution: %w", err)
+	}
+	if link == nil {
+		return uri, nil
+	}
+	if err := link.attach(ctx, client, did, uri, cid); err != nil {
+		if derr := atproto.DeleteRecord(ctx, client, did, atproto.CollectionContribution, extractRkey(uri)); derr != nil {
+			return "", fmt.Errorf("failed to link contribution to activity: %w\ncontribution %s was created but is not linked and could not be removed (%v); delete it with: hc contribution delete %s", err, uri, derr, extractRkey(uri))
+		}
+		return "", fmt.Errorf("failed to link contribution to activity (the new contribution was removed): %w", err)
+	}
+	return uri, nil
+}
+
+// contributionLink is the activity contributors[] entry a new contribution attaches to.
+type contributionLink struct {
+	activityURI string
+	activityCID string
+	activity    map[string]any
+	index       int            // entry in contributors[], or -1 to append entry
+	entry       map[string]any // new entry when index is -1
+	label       string
+}
+
+// resolveContributionLink finds the activity entry for contributorArg, which may be
+// a contributor record ID/AT-URI or a DID. With no contributor, the user picks an entry.
+func resolveContributionLink(ctx context.Context, client *atclient.APIClient, w io.Writer, did, activityArg, contributorArg string) (*contributionLink, error) {
+	activityURI := resolveRecordURI(did, atproto.CollectionActivity, activityArg)
+	aturi, err := syntax.ParseATURI(activityURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URI: %w", err)
+	}
+	if aturi.Authority().String() != did {
+		return nil, fmt.Errorf("can only link contributions to activities in your own repo")
+	}
+	activity, activityCID, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
+	if err != nil {
+		return nil, fmt.Errorf("activity not found: %s", activityArg)
+	}
+	contributors := mapSlice(activity, "contributors")
+	link := &contributionLink{activityURI: activityURI, activityCID: activityCID, activity: activity, index: -1}
+
+	switch {
+	case contributorArg == "":
+		if len(contributors) == 0 {
+			return nil, fmt.Errorf("activity has no contributors; pass --contributor to add one")
+		}
+		if len(contributors) == 1 {
+			link.index = 0
+			break
+		}
+		type contribChoice struct {
+			Index int
+			Label string
+		}
+		var choices []contribChoice
+		for i, c := range contributors {
+			if cm, ok := c.(map[string]any); ok {
+				choices = append(choices, contribChoice{Index: i, Label: contributorLabel(cm)})
+			}
+		}
+		selected, err := menu.SingleSelect(w, choices, "contributor",
+			func(c contribChoice) string { return c.Label },
+			func(c contribChoice) string { return "" },
+		)
+		if err != nil {
+			return nil, err
+		}
+		link.index = selected.Index
+
+	case strings.HasPrefix(contributorArg, "did:"):
+		if _, err := syntax.ParseDID(contributorArg); err != nil {
+			return nil, fmt.Errorf("invalid DID: %w", err)
+		}
+		link.index = findContributorEntry(contributors, "", contributorArg)
+		link.entry = map[string]any{
+			"contributorIdentity": map[string]any{
+				"$type":    atproto.CollectionActivity + "#contributorIdentity",
+				"identity": contributorArg,
+			},
+		}
+		link.label = contributorArg
+
+	default:
+		contributorURI := resolveRecordURI(did, atproto.CollectionContributorInfo, contributorArg)
+		ref, err := atproto.ParseRecordRef(contributorURI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI: %w", err)
+		}
+		info, contributorCID, err := atproto.GetRecord(ctx, client, ref.DID, ref.Collection, ref.Rkey)
+		if err != nil {
+			return nil, fmt.Errorf("contributor not found: %s", contributorArg)
+		}
+		link.index = findContributorEntry(contributors, contributorURI, mapStr(info, "identifier"))
+		link.entry = map[string]any{"contributorIdentity": buildStrongRef(contributorURI, contributorCID)}
+		link.label = mapStr(info, "displayName")
+		if link.label == "" {
+			link.label = extractRkey(contributorURI)
+		}
+	}
+
+	if link.index >= 0 {
+		cm, _ := contributors[link.index].(map[string]any)
+		if cm == nil {
+			return nil, fmt.Errorf("invalid contributor entry at index %d", link.index)
+		}
+		if link.label == "" {
+			link.label = contributorLabel(cm)
+		}
+		if prev := mapStr(mapMap(cm, "contributionDetails"), "uri"); prev != "" {
+			fmt.Fprintf(w, "Note: replacing existing contribution %s on this entry\n", extractRkey(prev))
+		}
+	}
+	return link, nil
+}
+
+// attach points the entry's contributionDetails at the contribution and writes the activity.
+// checkUnchanged fails if the activity was modified since the link was resolved.
+func (l *contributionLink) checkUnchanged(ctx context.Context, client *atclient.APIClient, did string) error {
+	aturi, err := syntax.ParseATURI(l.activityURI)
+	if err != nil {
+		return err
+	}
+	_, cid, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
+	if err != nil {
+		return fmt.Errorf("failed to re-read activity: %w", err)
+	}
+	if cid != l.activityCID {
+		return fmt.Errorf("activity %s changed while the contribution was being entered; run the command again", extractRkey(l.activityURI))
+	}
 	return nil
 }
 
+func (l *contributionLink) attach(ctx context.Context, client *atclient.APIClient, did, uri, cid string) error {
+	contributors := mapSlice(l.activity, "contributors")
+	details := buildStrongRef(uri, cid)
+	if l.index >= 0 {
+		contributors[l.index].(map[string]any)["contributionDetails"] = details
+	} else {
+		l.entry["contributionDetails"] = details
+		contributors = append(contributors, l.entry)
+	}
+	l.activity["contributors"] = contributors
+
+	aturi, err := syntax.ParseATURI(l.activityURI)
+	if err != nil {
+		return err
+	}
+	_, _, err = atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), l.activity, &l.activityCID)
+	return err
+}
+
 func runContributionEdit(ctx context.Context, cmd *cli.Command) error {
 	client, err := requireAuth(ctx, cmd)
 	if err != nil {
@@ -318,12 +501,13 @@ func runContributionEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, newCID, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update contribution: %w", err)
 	}
 
 	fmt.Fprintf(w, "\033[32m✓\033[0m Updated contribution: %s\n", resultURI)
+	syncActivityContributorRefs(ctx, client, w, did, resultURI, newCID)
 	return nil
 }
 
@@ -399,13 +583,16 @@ func runContributionDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runContributionList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	if activity := cmd.String("activity"); activity != "" {
+		return runContributionListForActivity(ctx, cmd, activity)
+	}
+
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionContribution)
 	if err != nil {
@@ -473,6 +660,55 @@ func runContributionList(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// runContributionListForActivity shows who did what on one activity, following
+// each contributors[] entry to its contributor and contribution records.
+func runContributionListForActivity(ctx context.Context, cmd *cli.Command, arg string) error {
+	client, did, err := requireReadClient(ctx, cmd, arg)
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+
+	uri := resolveRecordURI(did, atproto.CollectionActivity, arg)
+	aturi, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return fmt.Errorf("invalid URI: %w", err)
+	}
+	activity, _, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
+	if err != nil {
+		return fmt.Errorf("failed to get activity: %w", err)
+	}
+
+	rows := fetchActivityContributors(ctx, cmd, mapSlice(activity, "contributors"))
+	if cmd.Bool("json") {
+		fmt.Fprintln(w, prettyJSON(rows))
+		return nil
+	}
+
+	fmt.Fprintf(w, "\033[1m%-25s %-15s %-20s %-30s %-12s %s\033[0m\n", "CONTRIBUTOR", "CONTRIBUTION", "ROLE", "DESCRIPTION", "START", "END")
+	fmt.Fprintf(w, "%-25s %-15s %-20s %-30s %-12s %s\n",
+		strings.Repeat("-", 23), strings.Repeat("-", 13), strings.Repeat("-", 18),
+		strings.Repeat("-", 28), strings.Repeat("-", 10), strings.Repeat("-", 10))
+	for _, r := range rows {
+		id, role, desc := "-", "-", "-"
+		if r.Details != "" {
+			id = extractRkey(r.Details)
+		}
+		if r.Role != "" {
+			role = r.Role
+		}
+		if r.Description != "" {
+			desc = r.Description
+		}
+		fmt.Fprintf(w, "%-25s %-15s %-20s %-30s %-12s %s\n",
+			truncate(r.Name, 23), id, truncate(role, 18), truncate(desc, 28), formatDate(r.StartDate), formatDate(r.EndDate))
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "\033[90m(no contributors found)\033[0m")
+	}
+	return nil
+}
+
 func runContributionGet(ctx context.Context, cmd *cli.Command) error {
 	return runSimpleGet(ctx, cmd, atproto.CollectionContribution, "contribution")
 }
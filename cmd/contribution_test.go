@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+func TestCreateLinkedContributionRemovesOrphan(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteFail bool
+		wantErr    string
+	}{
+		{"removed", false, "the new contribution was removed"},
+		{"not removed", true, "hc contribution delete c1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			pds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/xrpc/com.atproto.repo.createRecord":
+					_ = json.NewEncoder(w).Encode(map[string]any{"uri": "at://did:plc:test/" + atproto.CollectionContribution + "/c1", "cid": "bafyc1"})
+				case "/xrpc/com.atproto.repo.putRecord":
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(map[string]any{"error": "InvalidSwap", "message": "record was modified"})
+				case "/xrpc/com.atproto.repo.deleteRecord":
+					if tt.deleteFail {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					var in struct {
+						Rkey string `json:"rkey"`
+					}
+					_ = json.NewDecoder(r.Body).Decode(&in)
+					deleted = append(deleted, in.Rkey)
+					_ = json.NewEncoder(w).Encode(map[string]any{})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer pds.Close()
+			client := atclient.NewAPIClient(pds.URL)
+			did := syntax.DID("did:plc:test")
+			client.AccountDID = &did
+
+			link := &contributionLink{
+				activityURI: "at://did:plc:test/" + atproto.CollectionActivity + "/a1",
+				activityCID: "bafya1",
+				activity:    map[string]any{"title": "Reforestation"},
+				index:       -1,
+				entry:       map[string]any{"contributorIdentity": map[string]any{"identity": "did:plc:alice"}},
+			}
+			_, err := createLinkedContribution(context.Background(), client, "did:plc:test", map[string]any{"role": "Planter"}, link)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if !tt.deleteFail && (len(deleted) != 1 || deleted[0] != "c1") {
+				t.Errorf("deleted = %v, want [c1]", deleted)
+			}
+		})
+	}
+}
@@ -44,13 +44,12 @@ func fetchContributors(ctx context.Context, client *atclient.APIClient) ([]contr
 }
 
 func runContributorList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionContributorInfo)
 	if err != nil {
@@ -304,12 +303,13 @@ func runContributorEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, newCID, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update contributor: %w", err)
 	}
 
 	fmt.Fprintf(w, "\033[32m\u2713\033[0m Updated contributor: %s\n", resultURI)
+	syncActivityContributorRefs(ctx, client, w, did, resultURI, newCID)
 	return nil
 }
 
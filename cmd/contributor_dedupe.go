@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/menu"
+)
+
+var (
+	didPattern         = regexp.MustCompile(`did:[a-z]+:[a-zA-Z0-9._:%-]+`)
+	githubLoginPattern = regexp.MustCompile(`(?i)^(?:https?://)?(?:www\.)?github\.com/([a-z0-9](?:[a-z0-9-]*[a-z0-9])?)/?$`)
+	nonAlnum           = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// dedupeCandidate is a contributor record with the keys used to match duplicates.
+type dedupeCandidate struct {
+	URI         string
+	Rkey        string
+	Identifier  string
+	DisplayName string
+	Image       string
+
+	did   string
+	login string // lowercased GitHub login
+	name  string // lowercased alphanumerics of the display name
+}
+
+// contributorCluster is a group of contributor records that look like the same person.
+type contributorCluster struct {
+	Members []dedupeCandidate
+	Reasons []string
+}
+
+func newDedupeCandidate(uri string, value map[string]any) dedupeCandidate {
+	c := dedupeCandidate{
+		URI:         uri,
+		Rkey:        extractRkey(uri),
+		Identifier:  strings.TrimSpace(mapStr(value, "identifier")),
+		DisplayName: strings.TrimSpace(mapStr(value, "displayName")),
+		Image:       mapStr(mapMap(value, "image"), "uri"),
+	}
+	c.did = didPattern.FindString(c.Identifier)
+	if m := githubLoginPattern.FindStringSubmatch(c.Identifier); m != nil {
+		c.login = strings.ToLower(m[1])
+	}
+	c.name = nonAlnum.ReplaceAllString(strings.ToLower(c.DisplayName), "")
+	return c
+}
+
+// similarNames reports whether two normalized names are equal or one edit apart.
+// Short names must match exactly to avoid false positives.
+func similarNames(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if len(a) < 5 || len(b) < 5 {
+		return false
+	}
+	return levenshtein(a, b) <= 1
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// matchReason returns why two contributors look like duplicates, or "" if they don't.
+func matchReason(a, b dedupeCandidate) string {
+	switch {
+	case a.did != "" && a.did == b.did:
+		return "same DID"
+	case a.login != "" && a.login == b.login:
+		return "same GitHub login"
+	case a.login != "" && a.login == b.name, b.login != "" && b.login == a.name:
+		return "name matches GitHub login"
+	case similarNames(a.name, b.name):
+		return "similar names"
+	case a.Image != "" && a.Image == b.Image:
+		return "same image"
+	}
+	return ""
+}
+
+// clusterContributors groups likely duplicates. Matching is transitive, so a
+// GitHub record and a DID record sharing a name end up in one cluster.
+func clusterContributors(candidates []dedupeCandidate) []contributorCluster {
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	reasons := map[int]map[string]bool{}
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			reason := matchReason(candidates[i], candidates[j])
+			if reason == "" {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			if ri != rj {
+				parent[rj] = ri
+				for r := range reasons[rj] {
+					if reasons[ri] == nil {
+						reasons[ri] = map[string]bool{}
+					}
+					reasons[ri][r] = true
+				}
+				delete(reasons, rj)
+			}
+			if reasons[ri] == nil {
+				reasons[ri] = map[string]bool{}
+			}
+			reasons[ri][reason] = true
+		}
+	}
+
+	groups := map[int][]dedupeCandidate{}
+	var roots []int
+	for i, c := range candidates {
+		r := find(i)
+		if _, ok := groups[r]; !ok {
+			roots = append(roots, r)
+		}
+		groups[r] = append(groups[r], c)
+	}
+
+	var clusters []contributorCluster
+	for _, r := range roots {
+		if len(groups[r]) < 2 {
+			continue
+		}
+		var rs []string
+		for reason := range reasons[r] {
+			rs = append(rs, reason)
+		}
+		sort.Strings(rs)
+		members := groups[r]
+		sort.SliceStable(members, func(i, j int) bool { return keepScore(members[i]) > keepScore(members[j]) })
+		clusters = append(clusters, contributorCluster{Members: members, Reasons: rs})
+	}
+	return clusters
+}
+
+// keepScore ranks which record of a cluster to keep: DIDs are the most stable
+// identity, then records with more fields filled in.
+func keepScore(c dedupeCandidate) int {
+	score := 0
+	if c.did != "" {
+		score += 4
+	}
+	if c.Identifier != "" {
+		score += 2
+	}
+	if c.DisplayName != "" {
+		score++
+	}
+	if c.Image != "" {
+		score++
+	}
+	return score
+}
+
+func runContributorDedupe(ctx context.Context, cmd *cli.Command) error {
+	client, did, err := requireReadClient(ctx, cmd, "")
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+
+	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionContributorInfo)
+	if err != nil {
+		return fmt.Errorf("failed to list contributors: %w", err)
+	}
+	candidates := make([]dedupeCandidate, len(entries))
+	for i, e := range entries {
+		candidates[i] = newDedupeCandidate(e.URI, e.Value)
+	}
+	clusters := clusterContributors(candidates)
+
+	if cmd.Bool("json") {
+		var out []map[string]any
+		for _, c := range clusters {
+			var members []map[string]any
+			for _, m := range c.Members {
+				members = append(members, map[string]any{"uri": m.URI, "identifier": m.Identifier, "displayName": m.DisplayName})
+			}
+			out = append(out, map[string]any{"reasons": c.Reasons, "members": members})
+		}
+		fmt.Fprintln(w, prettyJSON(out))
+		return nil
+	}
+
+	if len(clusters) == 0 {
+		fmt.Fprintf(w, "\033[32m✓\033[0m No likely duplicates among %d contributors\n", len(entries))
+		return nil
+	}
+	for i, c := range clusters {
+		fmt.Fprintf(w, "\033[1mCluster %d\033[0m (%s)\n", i+1, strings.Join(c.Reasons, ", "))
+		for j, m := range c.Members {
+			marker := " "
+			if j == 0 {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "  %s %-15s %-40s %s\n", marker, m.Rkey, truncate(m.Identifier, 38), m.DisplayName)
+		}
+		drops := make([]string, 0, len(c.Members)-1)
+		for _, m := range c.Members[1:] {
+			drops = append(drops, m.Rkey)
+		}
+		fmt.Fprintf(w, "  \033[90mhc contributor merge %s %s\033[0m\n\n", c.Members[0].Rkey, strings.Join(drops, " "))
+	}
+	fmt.Fprintf(w, "Found %d cluster(s). * marks the suggested record to keep.\n", len(clusters))
+	return nil
+}
+
+// mergeContributorRefs points contributors[] entries at any of drop to keep.
+// If keep is then listed more than once, the entries are combined: numeric
+// weights are summed and contribution details are merged by
+// mergeContributionDetails. Reports whether the list changed; details that
+// cannot be merged are returned as an error and the list is left as is.
+func mergeContributorRefs(contributors []any, keep map[string]any, drop map[string]bool) ([]any, bool, error) {
+	keepURI := mapStr(keep, "uri")
+	changed := false
+	var result []any
+	keepIdx := -1
+	for _, c := range contributors {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			result = append(result, c)
+			continue
+		}
+		uri := mapStr(mapMap(cm, "contributorIdentity"), "uri")
+		if drop[uri] {
+			uri = keepURI
+			changed = true
+		}
+		if uri != keepURI {
+			result = append(result, cm)
+			continue
+		}
+		entry := maps.Clone(cm)
+		entry["contributorIdentity"] = buildStrongRef(keepURI, mapStr(keep, "cid"))
+		if keepIdx < 0 {
+			keepIdx = len(result)
+			result = append(result, entry)
+			continue
+		}
+		// Second entry for the kept contributor: fold it into the first.
+		changed = true
+		first := result[keepIdx].(map[string]any)
+		details, err := mergeContributionDetails(mapMap(first, "contributionDetails"), mapMap(entry, "contributionDetails"))
+		if err != nil {
+			return contributors, false, err
+		}
+		if details != nil {
+			first["contributionDetails"] = details
+		}
+		a, errA := strconv.ParseFloat(mapStr(first, "contributionWeight"), 64)
+		b, errB := strconv.ParseFloat(mapStr(entry, "contributionWeight"), 64)
+		if errA == nil && errB == nil {
+			first["contributionWeight"] = strconv.FormatFloat(a+b, 'f', -1, 64)
+		}
+	}
+	return result, changed, nil
+}
+
+// mergeContributionDetails combines the contributionDetails of two entries for
+// the same contributor. Roles are joined; a contribution record reference can
+// only be kept when the other entry has none or the same one.
+func mergeContributionDetails(a, b map[string]any) (map[string]any, error) {
+	switch {
+	case b == nil:
+		return a, nil
+	case a == nil:
+		return b, nil
+	}
+	aURI, bURI := mapStr(a, "uri"), mapStr(b, "uri")
+	if aURI == "" && bURI == "" {
+		aRole, bRole := mapStr(a, "role"), mapStr(b, "role")
+		if bRole == "" || strings.EqualFold(aRole, bRole) {
+			return a, nil
+		}
+		if aRole == "" {
+			return b, nil
+		}
+		merged := maps.Clone(a)
+		merged["role"] = aRole + ", " + bRole
+		return merged, nil
+	}
+	if aURI == bURI {
+		return a, nil
+	}
+	return nil, fmt.Errorf("contribution details %s and %s cannot be combined", detailsLabel(a), detailsLabel(b))
+}
+
+// detailsLabel describes contributionDetails as its role or contribution rkey.
+func detailsLabel(details map[string]any) string {
+	if uri := mapStr(details, "uri"); uri != "" {
+		return "contribution " + extractRkey(uri)
+	}
+	return fmt.Sprintf("role %q", mapStr(details, "role"))
+}
+
+func runContributorMerge(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) < 2 {
+		return fmt.Errorf("usage: hc contributor merge <keep> <drop...>")
+	}
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+	did := client.AccountDID.String()
+
+	keepURI := resolveRecordURI(did, atproto.CollectionContributorInfo, args[0])
+	keepRef, err := atproto.ParseRecordRef(keepURI)
+	if err != nil {
+		return fmt.Errorf("invalid URI: %w", err)
+	}
+	if keepRef.DID != did || keepRef.Collection != atproto.CollectionContributorInfo {
+		return fmt.Errorf("%s is not a contributor in your repo", args[0])
+	}
+	_, keepCID, err := atproto.GetRecord(ctx, client, did, keepRef.Collection, keepRef.Rkey)
+	if err != nil {
+		return fmt.Errorf("contributor not found: %s", args[0])
+	}
+	keep := buildStrongRef(keepURI, keepCID)
+
+	drop := map[string]bool{}
+	var dropRefs []atproto.RecordRef
+	for _, arg := range args[1:] {
+		uri := resolveRecordURI(did, atproto.CollectionContributorInfo, arg)
+		if uri == keepURI {
+			return fmt.Errorf("cannot merge %s into itself", arg)
+		}
+		ref, err := atproto.ParseRecordRef(uri)
+		if err != nil {
+			return fmt.Errorf("invalid URI: %w", err)
+		}
+		if ref.DID != did || ref.Collection != atproto.CollectionContributorInfo {
+			return fmt.Errorf("%s is not a contributor in your repo", arg)
+		}
+		if _, _, err := atproto.GetRecord(ctx, client, did, ref.Collection, ref.Rkey); err != nil {
+			return fmt.Errorf("contributor not found: %s", arg)
+		}
+		drop[uri] = true
+		dropRefs = append(dropRefs, ref)
+	}
+
+	activities, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionActivity)
+	if err != nil {
+		return fmt.Errorf("failed to list activities: %w", err)
+	}
+	type activityUpdate struct {
+		entry        atproto.RecordEntry
+		contributors []any
+	}
+	var updates []activityUpdate
+	var conflicts []string
+	for _, e := range activities {
+		merged, changed, err := mergeContributorRefs(mapSlice(e.Value, "contributors"), keep, drop)
+		if err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("  %s (%s): %v", extractRkey(e.URI), mapStr(e.Value, "title"), err))
+			continue
+		}
+		if changed {
+			updates = append(updates, activityUpdate{entry: e, contributors: merged})
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("cannot merge: these activities list both contributors with different contributions; remove one entry first:\n%s", strings.Join(conflicts, "\n"))
+	}
+
+	fmt.Fprintf(w, "Merging %d contributor(s) into %s: %d activit(ies) to update\n", len(dropRefs), extractRkey(keepURI), len(updates))
+	if cmd.Bool("dry-run") {
+		for _, u := range updates {
+			fmt.Fprintf(w, "  would update %s (%s)\n", extractRkey(u.entry.URI), mapStr(u.entry.Value, "title"))
+		}
+		return nil
+	}
+	if !cmd.Bool("force") && !menu.Confirm(w, os.Stdin, fmt.Sprintf("Rewrite %d activit(ies) and delete %d contributor(s)?", len(updates), len(dropRefs))) {
+		fmt.Fprintln(w, "Aborted.")
+		return nil
+	}
+
+	for _, u := range updates {
+		aturi, err := syntax.ParseATURI(u.entry.URI)
+		if err != nil {
+			continue
+		}
+		u.entry.Value["contributors"] = u.contributors
+		swap := u.entry.CID
+		if _, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), u.entry.Value, &swap); err != nil {
+			// Stop before deleting anything still referenced.
+			return fmt.Errorf("failed to update activity %s: %w", extractRkey(u.entry.URI), err)
+		}
+		fmt.Fprintf(w, "  \033[32m✓\033[0m Updated activity: %s\n", extractRkey(u.entry.URI))
+	}
+
+	for _, ref := range dropRefs {
+		if err := atproto.DeleteRecord(ctx, client, did, ref.Collection, ref.Rkey); err != nil {
+			fmt.Fprintf(w, "  Warning: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(w, "Deleted contributor: %s\n", ref.Rkey)
+	}
+	if len(updates) > 0 {
+		fmt.Fprintln(w, "Records that reference the updated activities now have stale CIDs; run 'hc doctor --fix' to refresh them.")
+	}
+	return nil
+}
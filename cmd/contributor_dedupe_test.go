@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClusterContributors(t *testing.T) {
+	records := []struct {
+		uri   string
+		value map[string]any
+	}{
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/gh", map[string]any{"identifier": "https://github.com/AliceDev", "displayName": "AliceDev"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/did", map[string]any{"identifier": "did:plc:alice", "displayName": "Alice Dev"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/name", map[string]any{"displayName": "alicedev"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/bob", map[string]any{"identifier": "did:plc:bob", "displayName": "Bob"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/bob2", map[string]any{"identifier": "https://bsky.app/profile/did:plc:bob"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/carol", map[string]any{"identifier": "did:plc:carol", "displayName": "Carol"}},
+		{"at://did:plc:me/org.hypercerts.claim.contributorInformation/caro", map[string]any{"displayName": "Caro"}},
+	}
+	var candidates []dedupeCandidate
+	for _, r := range records {
+		candidates = append(candidates, newDedupeCandidate(r.uri, r.value))
+	}
+
+	clusters := clusterContributors(candidates)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters %+v, want 2", len(clusters), clusters)
+	}
+
+	alice := clusters[0]
+	if len(alice.Members) != 3 {
+		t.Fatalf("alice cluster has %d members, want 3", len(alice.Members))
+	}
+	if alice.Members[0].Rkey != "did" {
+		t.Errorf("suggested keep = %s, want the DID record", alice.Members[0].Rkey)
+	}
+
+	bob := clusters[1]
+	if len(bob.Members) != 2 || bob.Reasons[0] != "same DID" {
+		t.Errorf("bob cluster = %+v", bob)
+	}
+}
+
+func TestSimilarNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"alicedev", "alicedev", true},
+		{"alicedev", "alicedef", true},
+		{"alicedev", "alicedevs", true},
+		{"carol", "caro", false},
+		{"bob", "bobb", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := similarNames(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarNames(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMergeContributorRefs(t *testing.T) {
+	keepURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/keep"
+	dropURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/drop"
+	otherURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/other"
+	contributors := []any{
+		map[string]any{"contributorIdentity": buildStrongRef(keepURI, "k1"), "contributionWeight": "3"},
+		map[string]any{"contributorIdentity": buildStrongRef(otherURI, "o1"), "contributionWeight": "1"},
+		map[string]any{"contributorIdentity": buildStrongRef(dropURI, "d1"), "contributionWeight": "2"},
+	}
+
+	got, changed, err := mergeContributorRefs(contributors, buildStrongRef(keepURI, "k1"), map[string]bool{dropURI: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected change")
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d contributors, want 2", len(got))
+	}
+	first := got[0].(map[string]any)
+	if mapStr(first, "contributionWeight") != "5" {
+		t.Errorf("merged weight = %q, want 5", mapStr(first, "contributionWeight"))
+	}
+
+	_, changed, _ = mergeContributorRefs(got, buildStrongRef(keepURI, "k1"), map[string]bool{dropURI: true})
+	if changed {
+		t.Error("expected no change on second merge")
+	}
+}
+
+func TestMergeContributorRefsDetails(t *testing.T) {
+	keepURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/keep"
+	dropURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/drop"
+	role := func(r string) map[string]any { return map[string]any{"role": r} }
+	contribution := func(rkey string) map[string]any {
+		return buildStrongRef("at://did:plc:me/org.hypercerts.claim.contribution/"+rkey, "c"+rkey)
+	}
+
+	tests := []struct {
+		name     string
+		keep     map[string]any
+		drop     map[string]any
+		wantRole string
+		wantURI  string
+		wantErr  string
+	}{
+		{"only dropped has details", nil, role("Planter"), "Planter", "", ""},
+		{"roles joined", role("Planter"), role("Surveyor"), "Planter, Surveyor", "", ""},
+		{"same role", role("Planter"), role("planter"), "Planter", "", ""},
+		{"contribution kept", nil, contribution("c1"), "", "c1", ""},
+		{"same contribution", contribution("c1"), contribution("c1"), "", "c1", ""},
+		{"different contributions", contribution("c1"), contribution("c2"), "", "", "contribution c1 and contribution c2"},
+		{"role and contribution", role("Planter"), contribution("c2"), "", "", `role "Planter" and contribution c2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keepEntry := map[string]any{"contributorIdentity": buildStrongRef(keepURI, "k1")}
+			dropEntry := map[string]any{"contributorIdentity": buildStrongRef(dropURI, "d1")}
+			if tt.keep != nil {
+				keepEntry["contributionDetails"] = tt.keep
+			}
+			if tt.drop != nil {
+				dropEntry["contributionDetails"] = tt.drop
+			}
+			contributors := []any{keepEntry, dropEntry}
+
+			got, _, err := mergeContributorRefs(contributors, buildStrongRef(keepURI, "k1"), map[string]bool{dropURI: true})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				if mapStr(mapMap(dropEntry, "contributorIdentity"), "uri") != dropURI {
+					t.Error("conflicting merge modified the dropped entry")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d contributors, want 1", len(got))
+			}
+			details := mapMap(got[0].(map[string]any), "contributionDetails")
+			if mapStr(details, "role") != tt.wantRole || extractRkey(mapStr(details, "uri")) != tt.wantURI {
+				t.Errorf("details = %v, want role %q uri %q", details, tt.wantRole, tt.wantURI)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// Issue kinds reported by hc doctor.
+const (
+	issueDangling    = "dangling"
+	issueStaleCID    = "stale-cid"
+	issueUnreachable = "unreachable"
+	issueOrphan      = "orphan"
+	issueDuplicate   = "duplicate-contributor"
+)
+
+// maxDoctorPasses bounds --fix rescans. Fixing a ref changes the CID of the
+// record holding it, which can make refs to that record stale in turn.
+const maxDoctorPasses = 5
+
+// doctorIssue is a single referential integrity problem.
+type doctorIssue struct {
+	Kind   string `json:"kind"`
+	Record string `json:"record"`
+	Field  string `json:"field,omitempty"`
+	Target string `json:"target,omitempty"`
+	Detail string `json:"detail"`
+
+	currentCID string // for stale-cid: the CID the ref should point at
+}
+
+// strongRefAt is a strongRef found inside a record, with its field path.
+type strongRefAt struct {
+	Path string
+	Ref  map[string]any
+}
+
+// findStrongRefs walks a record value and returns every embedded strongRef
+// (an object with an at:// uri and a cid), in a stable order.
+func findStrongRefs(v any, path string) []strongRefAt {
+	var refs []strongRefAt
+	switch t := v.(type) {
+	case map[string]any:
+		if strings.HasPrefix(mapStr(t, "uri"), "at://") {
+			if _, ok := t["cid"]; ok {
+				return []strongRefAt{{Path: path, Ref: t}}
+			}
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			refs = append(refs, findStrongRefs(t[k], p)...)
+		}
+	case []any:
+		for i, item := range t {
+			refs = append(refs, findStrongRefs(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return refs
+}
+
+// refTarget is the resolved state of a record outside the checked repo.
+// Err is set if the record could not be fetched.
+type refTarget struct {
+	CID string
+	Err error
+}
+
+var errRecordMissing = errors.New("record does not exist")
+
+// doctorChecker resolves references against the checked repo and fetched foreign records.
+type doctorChecker struct {
+	did     string
+	local   map[string]string // uri -> cid for records in the checked repo
+	targets map[string]refTarget
+}
+
+// lookup returns the state of the record at uri.
+func (c *doctorChecker) lookup(uri string) refTarget {
+	if cid, ok := c.local[uri]; ok {
+		return refTarget{CID: cid}
+	}
+	if aturi, err := syntax.ParseATURI(uri); err == nil && aturi.Authority().String() == c.did {
+		return refTarget{Err: errRecordMissing}
+	}
+	if t, ok := c.targets[uri]; ok {
+		return t
+	}
+	return refTarget{Err: fmt.Errorf("not checked")}
+}
+
+// missing reports whether err means the target record does not exist.
+func missing(err error) bool {
+	return errors.Is(err, errRecordMissing) || atproto.IsNotFound(err)
+}
+
+// diagnoseRepo checks every record in a repo for dangling refs, stale CIDs,
+// orphaned measurements and attachments, and duplicated contributors.
+// targets holds the resolved state of referenced records in other repos.
+func diagnoseRepo(did string, entries []atproto.RecordEntry, targets map[string]refTarget) []doctorIssue {
+	c := &doctorChecker{did: did, local: make(map[string]string, len(entries)), targets: targets}
+	for _, e := range entries {
+		c.local[e.URI] = e.CID
+	}
+
+	var issues []doctorIssue
+	for _, e := range entries {
+		for _, r := range findStrongRefs(e.Value, "") {
+			uri := mapStr(r.Ref, "uri")
+			t := c.lookup(uri)
+			switch {
+			case t.Err != nil && missing(t.Err):
+				issues = append(issues, doctorIssue{Kind: issueDangling, Record: e.URI, Field: r.Path, Target: uri, Detail: "target record does not exist"})
+			case t.Err != nil:
+				issues = append(issues, doctorIssue{Kind: issueUnreachable, Record: e.URI, Field: r.Path, Target: uri, Detail: t.Err.Error()})
+			case mapStr(r.Ref, "cid") != t.CID:
+				issues = append(issues, doctorIssue{
+					Kind: issueStaleCID, Record: e.URI, Field: r.Path, Target: uri,
+					Detail:     fmt.Sprintf("cid %s, current %s", truncate(mapStr(r.Ref, "cid"), 16), truncate(t.CID, 16)),
+					currentCID: t.CID,
+				})
+			}
+		}
+
+		// Funding receipts reference their activity by plain AT-URI.
+		if forURI := mapStr(e.Value, "for"); strings.HasPrefix(forURI, "at://") {
+			if t := c.lookup(forURI); t.Err != nil && missing(t.Err) {
+				issues = append(issues, doctorIssue{Kind: issueDangling, Record: e.URI, Field: "for", Target: forURI, Detail: "target record does not exist"})
+			}
+		}
+
+		issues = append(issues, c.checkOrphan(e)...)
+		issues = append(issues, checkActivityContributors(e)...)
+	}
+	issues = append(issues, checkDuplicateContributors(entries)...)
+	return issues
+}
+
+// checkOrphan flags measurements and attachments with no subject that still exists.
+func (c *doctorChecker) checkOrphan(e atproto.RecordEntry) []doctorIssue {
+	aturi, err := syntax.ParseATURI(e.URI)
+	if err != nil {
+		return nil
+	}
+	coll := aturi.Collection().String()
+	if coll != atproto.CollectionMeasurement && coll != atproto.CollectionAttachment {
+		return nil
+	}
+
+	var subjects []map[string]any
+	for _, s := range mapSlice(e.Value, "subjects") {
+		if m, ok := s.(map[string]any); ok {
+			subjects = append(subjects, m)
+		}
+	}
+	if s := mapMap(e.Value, "subject"); s != nil {
+		subjects = append(subjects, s)
+	}
+	if len(subjects) == 0 {
+		return []doctorIssue{{Kind: issueOrphan, Record: e.URI, Detail: "no subject"}}
+	}
+	for _, s := range subjects {
+		if t := c.lookup(mapStr(s, "uri")); t.Err == nil || !missing(t.Err) {
+			return nil
+		}
+	}
+	return []doctorIssue{{Kind: issueOrphan, Record: e.URI, Detail: "all subjects are missing"}}
+}
+
+// checkActivityContributors flags contributors listed more than once on an activity.
+func checkActivityContributors(e atproto.RecordEntry) []doctorIssue {
+	if !strings.Contains(e.URI, "/"+atproto.CollectionActivity+"/") {
+		return nil
+	}
+	var issues []doctorIssue
+	seen := map[string]int{}
+	for i, c := range mapSlice(e.Value, "contributors") {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		ident := mapMap(cm, "contributorIdentity")
+		key := mapStr(ident, "uri")
+		if key == "" {
+			key = mapStr(ident, "identity")
+		}
+		if key == "" {
+			continue
+		}
+		if first, dup := seen[key]; dup {
+			issues = append(issues, doctorIssue{
+				Kind: issueDuplicate, Record: e.URI, Field: fmt.Sprintf("contributors[%d]", i), Target: key,
+				Detail: fmt.Sprintf("same contributor as contributors[%d]", first),
+			})
+			continue
+		}
+		seen[key] = i
+	}
+	return issues
+}
+
+// checkDuplicateContributors flags contributor records that share an identifier.
+func checkDuplicateContributors(entries []atproto.RecordEntry) []doctorIssue {
+	var issues []doctorIssue
+	first := map[string]string{}
+	for _, e := range entries {
+		if !strings.Contains(e.URI, "/"+atproto.CollectionContributorInfo+"/") {
+			continue
+		}
+		id := strings.TrimSpace(mapStr(e.Value, "identifier"))
+		if id == "" {
+			continue
+		}
+		if uri, ok := first[id]; ok {
+			issues = append(issues, doctorIssue{
+				Kind: issueDuplicate, Record: e.URI, Field: "identifier", Target: uri,
+				Detail: fmt.Sprintf("same identifier %q as %s", id, extractRkey(uri)),
+			})
+			continue
+		}
+		first[id] = e.URI
+	}
+	return issues
+}
+
+// listRepoRecords lists every Hypercerts record in a repo.
+func listRepoRecords(ctx context.Context, client *atclient.APIClient, did string) ([]atproto.RecordEntry, error) {
+	var all []atproto.RecordEntry
+	for _, coll := range atproto.HypercertsCollections {
+		entries, err := atproto.ListAllRecords(ctx, client, did, coll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", coll, err)
+		}
+		all = append(all, entries...)
+	}
+	return all, nil
+}
+
+// resolveForeignTargets fetches every referenced record that lives outside the repo.
+func resolveForeignTargets(ctx context.Context, cmd *cli.Command, did string, entries []atproto.RecordEntry) map[string]refTarget {
+	var refs []atproto.RecordRef
+	seen := map[string]bool{}
+	add := func(uri string) {
+		if seen[uri] {
+			return
+		}
+		seen[uri] = true
+		ref, err := atproto.ParseRecordRef(uri)
+		if err != nil || ref.DID == did {
+			return
+		}
+		refs = append(refs, ref)
+	}
+	for _, e := range entries {
+		for _, r := range findStrongRefs(e.Value, "") {
+			add(mapStr(r.Ref, "uri"))
+		}
+		if forURI := mapStr(e.Value, "for"); strings.HasPrefix(forURI, "at://") {
+			add(forURI)
+		}
+	}
+
+	targets := map[string]refTarget{}
+	for _, res := range getRecordsAnyRepo(ctx, cmd, refs) {
+		targets[res.Ref.URI()] = refTarget{CID: res.CID, Err: res.Err}
+	}
+	return targets
+}
+
+// fixStaleCIDs points stale strongRefs at the target's current CID and writes
+// each affected record back, guarded by its CID. Returns the number of refs fixed.
+func fixStaleCIDs(ctx context.Context, client *atclient.APIClient, did string, entries []atproto.RecordEntry, issues []doctorIssue) (int, error) {
+	stale := map[string]map[string]string{} // record uri -> field path -> current cid
+	for _, is := range issues {
+		if is.Kind != issueStaleCID {
+			continue
+		}
+		if stale[is.Record] == nil {
+			stale[is.Record] = map[string]string{}
+		}
+		stale[is.Record][is.Field] = is.currentCID
+	}
+
+	fixed := 0
+	for _, e := range entries {
+		fields := stale[e.URI]
+		if fields == nil {
+			continue
+		}
+		for _, r := range findStrongRefs(e.Value, "") {
+			if cid, ok := fields[r.Path]; ok {
+				r.Ref["cid"] = cid
+			}
+		}
+		aturi, err := syntax.ParseATURI(e.URI)
+		if err != nil {
+			continue
+		}
+		swap := e.CID
+		if _, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), e.Value, &swap); err != nil {
+			return fixed, fmt.Errorf("failed to update %s: %w", e.URI, err)
+		}
+		fixed += len(fields)
+	}
+	return fixed, nil
+}
+
+func runDoctor(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	fix := cmd.Bool("fix")
+
+	var client *atclient.APIClient
+	var did string
+	var err error
+	if fix {
+		if cmd.String("repo") != "" {
+			return fmt.Errorf("--fix only works on your own repo")
+		}
+		client, err = requireAuth(ctx, cmd)
+		if err == nil {
+			did = client.AccountDID.String()
+		}
+	} else {
+		client, did, err = requireReadClient(ctx, cmd, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	for pass := 1; ; pass++ {
+		entries, err := listRepoRecords(ctx, client, did)
+		if err != nil {
+			return err
+		}
+		issues := diagnoseRepo(did, entries, resolveForeignTargets(ctx, cmd, did, entries))
+
+		staleCount := 0
+		for _, is := range issues {
+			if is.Kind == issueStaleCID {
+				staleCount++
+			}
+		}
+		if !fix || staleCount == 0 || pass > maxDoctorPasses {
+			if cmd.Bool("json") {
+				if issues == nil {
+					issues = []doctorIssue{}
+				}
+				fmt.Fprintln(w, prettyJSON(issues))
+				return nil
+			}
+			printDoctorIssues(w, issues, len(entries))
+			return nil
+		}
+
+		n, err := fixStaleCIDs(ctx, client, did, entries, issues)
+		if n > 0 {
+			fmt.Fprintf(w, "\033[32m✓\033[0m Refreshed %d stale CID(s)\n", n)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func printDoctorIssues(w io.Writer, issues []doctorIssue, recordCount int) {
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "\033[32m✓\033[0m No problems found in %d records\n", recordCount)
+		return
+	}
+
+	fmt.Fprintf(w, "\033[1m%-22s %-45s %-24s %s\033[0m\n", "KIND", "RECORD", "FIELD", "DETAIL")
+	fmt.Fprintf(w, "%-22s %-45s %-24s %s\n",
+		strings.Repeat("-", 20), strings.Repeat("-", 43), strings.Repeat("-", 22), strings.Repeat("-", 30))
+
+	counts := map[string]int{}
+	for _, is := range issues {
+		counts[is.Kind]++
+		record := is.Record
+		if aturi, err := syntax.ParseATURI(is.Record); err == nil {
+			coll := aturi.Collection().String()
+			record = coll[strings.LastIndex(coll, ".")+1:] + "/" + aturi.RecordKey().String()
+		}
+		field := is.Field
+		if field == "" {
+			field = "-"
+		}
+		detail := is.Detail
+		if is.Target != "" && is.Kind != issueDuplicate {
+			detail += " (" + is.Target + ")"
+		}
+		fmt.Fprintf(w, "%-22s %-45s %-24s %s\n", is.Kind, truncate(record, 43), truncate(field, 22), detail)
+	}
+
+	var parts []string
+	for _, kind := range []string{issueDangling, issueStaleCID, issueUnreachable, issueOrphan, issueDuplicate} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	fmt.Fprintf(w, "\nFound %d problem(s) in %d records: %s\n", len(issues), recordCount, strings.Join(parts, ", "))
+	if counts[issueStaleCID] > 0 {
+		fmt.Fprintln(w, "Run 'hc doctor --fix' to refresh stale CIDs.")
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+func TestFindStrongRefs(t *testing.T) {
+	value := map[string]any{
+		"title":  "x",
+		"rights": map[string]any{"uri": "at://did:plc:a/org.hypercerts.claim.rights/r1", "cid": "c1"},
+		"contributors": []any{
+			map[string]any{"contributorIdentity": map[string]any{"uri": "at://did:plc:a/org.hypercerts.claim.contributorInformation/c1", "cid": "c2"}},
+			map[string]any{"contributorIdentity": map[string]any{"identity": "did:plc:b"}},
+		},
+		"link": map[string]any{"uri": "https://example.com", "cid": "c3"},
+	}
+	refs := findStrongRefs(value, "")
+	var paths []string
+	for _, r := range refs {
+		paths = append(paths, r.Path)
+	}
+	want := []string{"contributors[0].contributorIdentity", "rights"}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestDiagnoseRepo(t *testing.T) {
+	const did = "did:plc:me"
+	act := "at://did:plc:me/org.hypercerts.claim.activity/a1"
+	contrib1 := "at://did:plc:me/org.hypercerts.claim.contributorInformation/c1"
+	contrib2 := "at://did:plc:me/org.hypercerts.claim.contributorInformation/c2"
+	gone := "at://did:plc:me/org.hypercerts.claim.activity/gone"
+	foreign := "at://did:plc:other/org.hypercerts.claim.activity/f1"
+	foreignMissing := "at://did:plc:other/org.hypercerts.claim.activity/f2"
+	foreignDown := "at://did:plc:down/org.hypercerts.claim.activity/f3"
+
+	entries := []atproto.RecordEntry{
+		{URI: act, CID: "act-v2", Value: map[string]any{
+			"contributors": []any{
+				map[string]any{"contributorIdentity": map[string]any{"uri": contrib1, "cid": "c1"}},
+				map[string]any{"contributorIdentity": map[string]any{"uri": contrib1, "cid": "c1"}},
+			},
+		}},
+		{URI: contrib1, CID: "c1", Value: map[string]any{"identifier": "did:plc:alice"}},
+		{URI: contrib2, CID: "c2", Value: map[string]any{"identifier": "did:plc:alice"}},
+		// Stale CID: activity was edited after the measurement was created.
+		{URI: "at://did:plc:me/org.hypercerts.context.measurement/m1", CID: "m1", Value: map[string]any{
+			"subjects": []any{map[string]any{"uri": act, "cid": "act-v1"}},
+		}},
+		// Orphan: its only subject was deleted.
+		{URI: "at://did:plc:me/org.hypercerts.context.measurement/m2", CID: "m2", Value: map[string]any{
+			"subjects": []any{map[string]any{"uri": gone, "cid": "x"}},
+		}},
+		// Orphan: no subject at all.
+		{URI: "at://did:plc:me/org.hypercerts.context.attachment/t1", CID: "t1", Value: map[string]any{}},
+		// Foreign refs: one fine, one missing, one unreachable.
+		{URI: "at://did:plc:me/org.hypercerts.context.evaluation/e1", CID: "e1", Value: map[string]any{
+			"subject":      map[string]any{"uri": foreign, "cid": "f1"},
+			"measurements": []any{map[string]any{"uri": foreignMissing, "cid": "f2"}},
+			"location":     map[string]any{"uri": foreignDown, "cid": "f3"},
+		}},
+		{URI: "at://did:plc:me/org.hypercerts.funding.receipt/r1", CID: "r1", Value: map[string]any{"for": gone}},
+	}
+	targets := map[string]refTarget{
+		foreign:        {CID: "f1"},
+		foreignMissing: {Err: &atclient.APIError{StatusCode: 400, Name: "RecordNotFound"}},
+		foreignDown:    {Err: &atclient.APIError{StatusCode: 502}},
+	}
+
+	got := map[string]int{}
+	for _, is := range diagnoseRepo(did, entries, targets) {
+		got[is.Kind+" "+is.Record+" "+is.Field]++
+	}
+	want := []string{
+		issueDuplicate + " " + act + " contributors[1]",
+		issueDuplicate + " " + contrib2 + " identifier",
+		issueStaleCID + " at://did:plc:me/org.hypercerts.context.measurement/m1 subjects[0]",
+		issueDangling + " at://did:plc:me/org.hypercerts.context.measurement/m2 subjects[0]",
+		issueOrphan + " at://did:plc:me/org.hypercerts.context.measurement/m2 ",
+		issueOrphan + " at://did:plc:me/org.hypercerts.context.attachment/t1 ",
+		issueDangling + " at://did:plc:me/org.hypercerts.context.evaluation/e1 measurements[0]",
+		issueUnreachable + " at://did:plc:me/org.hypercerts.context.evaluation/e1 location",
+		issueDangling + " at://did:plc:me/org.hypercerts.funding.receipt/r1 for",
+	}
+	for _, k := range want {
+		if got[k] != 1 {
+			t.Errorf("missing issue %q", k)
+		}
+		delete(got, k)
+	}
+	for k := range got {
+		t.Errorf("unexpected issue %q", k)
+	}
+}
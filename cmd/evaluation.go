@@ -281,14 +281,19 @@ func selectMeasurements(ctx context.Context, client *atclient.APIClient, w io.Wr
 	}
 
 	// Fetch CIDs for selected measurements
-	var refs []map[string]any
+	var recordRefs []atproto.RecordRef
 	for _, m := range selected {
-		aturi, _ := syntax.ParseATURI(m.URI)
-		_, cid, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
-		if err != nil {
+		if ref, err := atproto.ParseRecordRef(m.URI); err == nil {
+			recordRefs = append(recordRefs, ref)
+		}
+	}
+	var refs []map[string]any
+	for _, res := range atproto.GetRecords(ctx, client, recordRefs) {
+		if res.Err != nil {
+			fmt.Fprintf(w, "  Warning: skipping measurement %s: %v\n", res.Ref.Rkey, res.Err)
 			continue
 		}
-		refs = append(refs, buildStrongRef(m.URI, cid))
+		refs = append(refs, buildStrongRef(res.Ref.URI(), res.CID))
 	}
 	return refs, nil
 }
@@ -648,7 +653,7 @@ func runEvaluationEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update evaluation: %w", err)
 	}
@@ -721,13 +726,12 @@ func runEvaluationDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runEvaluationList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionEvaluation)
 	if err != nil {
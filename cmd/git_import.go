@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/gitlog"
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// groupGitAuthors turns git authors into contributors. Emails mapped to the
+// same DID in authorMap become one contributor. Unmapped authors stay one per
+// email: names are not unique, so they are never used to combine authors.
+// Authors without a name get a positional label rather than their email.
+func groupGitAuthors(authors []gitlog.Author, authorMap map[string]string) []source.Contributor {
+	byDID := map[string]int{}
+	var result []source.Contributor
+	unnamed := 0
+	for _, a := range authors {
+		name := a.Name
+		if name == "" {
+			unnamed++
+			name = fmt.Sprintf("Unnamed author %d", unnamed)
+		}
+		did := authorMap[a.Email]
+		if i, ok := byDID[did]; ok && did != "" {
+			result[i].Contributions += a.Commits
+			result[i].Additions += a.Additions
+			continue
+		}
+		if did != "" {
+			byDID[did] = len(result)
+		}
+		// Authors arrive sorted by commits, so the first name is the main one.
+		result = append(result, source.Contributor{
+			Login:         name,
+			DID:           did,
+			Email:         a.Email,
+			Contributions: a.Commits,
+			Additions:     a.Additions,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Contributions > result[j].Contributions })
+	return result
+}
+
+func loadGitAuthorMap(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open author map: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	m, err := gitlog.ParseAuthorMap(f)
+	if err != nil {
+		return nil, err
+	}
+	for email, id := range m {
+		if _, err := syntax.ParseDID(id); err != nil {
+			return nil, fmt.Errorf("author map: %s maps to %q, which is not a DID", email, id)
+		}
+	}
+	return m, nil
+}
+
+func runFromGit(ctx context.Context, cmd *cli.Command, client *atclient.APIClient, path string) error {
+	w := cmd.Root().Writer
+	weightOpts, err := importWeightOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	authorMap, err := loadGitAuthorMap(cmd.String("git-authors"))
+	if err != nil {
+		return err
+	}
+
+	repoInfo, authors, err := gitlog.Read(ctx, path)
+	if err != nil {
+		return err
+	}
+	contributors := groupGitAuthors(authors, authorMap)
+	values, err := fetchWeightValues(ctx, nil, "", weightOpts.By, contributors)
+	if err != nil {
+		return err
+	}
+	report := weighContributors(contributors, values, weightOpts)
+
+	fmt.Fprintf(w, "Importing from git: %s\n", repoInfo.Path)
+	if repoInfo.RemoteURL != "" {
+		fmt.Fprintf(w, "  Remote: %s\n", repoInfo.RemoteURL)
+	}
+	fmt.Fprintf(w, "  Commits: %d (%s to %s)\n", repoInfo.Commits,
+		repoInfo.FirstCommit.Format("2006-01-02"), repoInfo.LastCommit.Format("2006-01-02"))
+	fmt.Fprintf(w, "  Contributors: %d\n", len(contributors))
+	printImportWeightReport(w, weightOpts, report)
+
+	resolver, err := newContributorResolver(ctx, client)
+	if err != nil {
+		return err
+	}
+	resolver.includeEmails = cmd.Bool("include-emails")
+	resolver.printUnlinked(w, report.Kept, "DID (map authors with --git-authors)")
+	createdContribs, err := resolver.resolve(ctx, w, report.Kept)
+	if err != nil {
+		return err
+	}
+
+	activityRecord := buildActivityFromGit(repoInfo, len(contributors), createdContribs)
+	if s := cmd.String("work-scope"); s != "" {
+		activityRecord["workScope"] = map[string]any{
+			"$type": atproto.CollectionActivity + "#workScopeString",
+			"scope": s,
+		}
+	}
+	if s := cmd.String("end-date"); s != "" {
+		activityRecord["endDate"] = normalizeDate(s)
+	}
+
+	uri, _, err := atproto.CreateRecord(ctx, client, atproto.CollectionActivity, activityRecord)
+	if err != nil {
+		return fmt.Errorf("failed to create activity: %w", err)
+	}
+
+	fmt.Fprintf(w, "\033[32m✓\033[0m Created activity: %s\n", uri)
+	return nil
+}
+
+// buildActivityFromGit mirrors buildActivityFromSource for a local repository.
+func buildActivityFromGit(repo *gitlog.RepoInfo, contributorCount int, contribs []createdContributor) map[string]any {
+	record := map[string]any{
+		"$type":     atproto.CollectionActivity,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+		"title":     repo.Name,
+		"shortDescription": fmt.Sprintf("%s — %d commits by %d contributors",
+			repo.Name, repo.Commits, contributorCount),
+		"startDate": repo.FirstCommit.UTC().Format(time.RFC3339),
+		"endDate":   repo.LastCommit.UTC().Format(time.RFC3339),
+	}
+
+	source := repo.RemoteURL
+	if source == "" {
+		source = repo.Path
+	}
+	record["description"] = strings.Join([]string{
+		fmt.Sprintf("Git: %s", source),
+		fmt.Sprintf("Commits: %d", repo.Commits),
+	}, "\n")
+
+	// Contributors with proportional weights (commit counts unless --weight-by)
+	var contributorsArray []any
+	for _, c := range contribs {
+		contributorsArray = append(contributorsArray, map[string]any{
+			"contributorIdentity": buildStrongRef(c.uri, c.cid),
+			"contributionWeight":  c.contributionWeight(),
+		})
+	}
+	if len(contributorsArray) > 0 {
+		record["contributors"] = contributorsArray
+	}
+
+	return record
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// deepImportBatch caps records per applyWrites call.
+const deepImportBatch = 50
+
+// githubStats are the counts recorded as measurements by a deep import.
+type githubStats struct {
+	MergedPRs    int
+	ClosedIssues int
+	Stars        int
+	Contributors int
+}
+
+// releaseAttachment builds an attachment for a release, linked to the activity.
+// Content holds the release page followed by each asset's download URL.
+func releaseAttachment(rel github.Release, subject map[string]any) map[string]any {
+	title := rel.Name
+	if title == "" {
+		title = rel.TagName
+	}
+	content := []any{map[string]any{
+		"$type": "org.hypercerts.defs#uri",
+		"uri":   rel.HTMLURL,
+	}}
+	for _, a := range rel.Assets {
+		content = append(content, map[string]any{
+			"$type": "org.hypercerts.defs#uri",
+			"uri":   a.URL,
+		})
+	}
+
+	record := map[string]any{
+		"$type":       atproto.CollectionAttachment,
+		"createdAt":   time.Now().UTC().Format(time.RFC3339),
+		"title":       truncate(title, 256),
+		"subjects":    []any{subject},
+		"content":     content,
+		"contentType": "release",
+	}
+	if rel.Body != "" {
+		record["shortDescription"] = truncate(rel.Body, 300)
+		record["description"] = rel.Body
+	}
+	return record
+}
+
+// githubMeasurements builds one measurement per stat, linked to the activity.
+// Counts of events cover [start, end]; point-in-time counts (stars,
+// contributors) only carry the end date, so they are not prorated as flows.
+func githubMeasurements(repo *source.RepoInfo, stats githubStats, subject map[string]any, start, end time.Time) []map[string]any {
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	metrics := []struct {
+		metric   string
+		unit     string
+		value    int
+		evidence string
+		snapshot bool
+	}{
+		{"merged pull requests", "count", stats.MergedPRs, fmt.Sprintf("%s/pulls?q=is%%3Apr+is%%3Amerged+merged%%3A%s..%s", repo.HTMLURL, from, to), false},
+		{"closed issues", "count", stats.ClosedIssues, fmt.Sprintf("%s/issues?q=is%%3Aissue+is%%3Aclosed+closed%%3A%s..%s", repo.HTMLURL, from, to), false},
+		{"stars", "count", stats.Stars, repo.HTMLURL + "/stargazers", true},
+		{"contributors", "people", stats.Contributors, repo.HTMLURL + "/graphs/contributors", true},
+	}
+
+	var records []map[string]any
+	for _, m := range metrics {
+		record := map[string]any{
+			"$type":       atproto.CollectionMeasurement,
+			"createdAt":   time.Now().UTC().Format(time.RFC3339),
+			"subjects":    []any{subject},
+			"metric":      m.metric,
+			"unit":        m.unit,
+			"value":       strconv.Itoa(m.value),
+			"endDate":     end.UTC().Format(time.RFC3339),
+			"methodType":  "github-api",
+			"evidenceURI": []any{m.evidence},
+		}
+		if !m.snapshot {
+			record["startDate"] = start.UTC().Format(time.RFC3339)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// deepImportPeriod is the activity period: repo creation to the activity end
+// date if set, else the last push, else now.
+func deepImportPeriod(repo *source.RepoInfo, activity map[string]any) (time.Time, time.Time) {
+	start, _ := time.Parse(time.RFC3339, repo.CreatedAt)
+	end := time.Now().UTC()
+	for _, s := range []string{mapStr(activity, "endDate"), repo.PushedAt} {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			end = t
+			break
+		}
+	}
+	return start, end
+}
+
+// runGitHubDeepImport adds release attachments and activity measurements to a
+// freshly imported activity.
+func runGitHubDeepImport(ctx context.Context, w io.Writer, client *atclient.APIClient, gh *github.Client, path string, repo *source.RepoInfo, contributorCount int, activity map[string]any, uri, cid string) error {
+	subject := buildStrongRef(uri, cid)
+	start, end := deepImportPeriod(repo, activity)
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+
+	releases, err := gh.FetchReleases(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	stats := githubStats{Stars: repo.Stars, Contributors: contributorCount}
+	if stats.MergedPRs, err = gh.CountIssues(ctx, fmt.Sprintf("repo:%s is:pr is:merged merged:%s..%s", path, from, to)); err != nil {
+		return fmt.Errorf("failed to count merged pull requests: %w", err)
+	}
+	if stats.ClosedIssues, err = gh.CountIssues(ctx, fmt.Sprintf("repo:%s is:issue is:closed closed:%s..%s", path, from, to)); err != nil {
+		return fmt.Errorf("failed to count closed issues: %w", err)
+	}
+
+	var attachments []map[string]any
+	for _, rel := range releases {
+		attachments = append(attachments, releaseAttachment(rel, subject))
+	}
+	for i := 0; i < len(attachments); i += deepImportBatch {
+		batch := attachments[i:min(i+deepImportBatch, len(attachments))]
+		if _, err := atproto.CreateRecords(ctx, client, atproto.CollectionAttachment, batch); err != nil {
+			return fmt.Errorf("failed to create release attachments: %w", err)
+		}
+	}
+	fmt.Fprintf(w, "  ✓ Created %d release attachment(s)\n", len(attachments))
+
+	if _, err := atproto.CreateRecords(ctx, client, atproto.CollectionMeasurement, githubMeasurements(repo, stats, subject, start, end)); err != nil {
+		return fmt.Errorf("failed to create measurements: %w", err)
+	}
+	fmt.Fprintf(w, "  ✓ Created measurements (%s to %s): %d merged PRs, %d closed issues, %d stars, %d contributors\n",
+		from, to, stats.MergedPRs, stats.ClosedIssues, stats.Stars, stats.Contributors)
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -10,39 +11,99 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/gitea"
 	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/gitlab"
+	"github.com/GainForest/hypercerts-cli/internal/source"
 )
 
 type createdContributor struct {
 	uri           string
 	cid           string
 	contributions int
+	weight        string // overrides contributions when set
 }
 
-func runFromGitHub(ctx context.Context, cmd *cli.Command, client *atclient.APIClient, repoInput string) error {
+// contributionWeight is the activity's contributionWeight for c.
+func (c createdContributor) contributionWeight() string {
+	if c.weight != "" {
+		return c.weight
+	}
+	return fmt.Sprintf("%d", c.contributions)
+}
+
+// sourceImporter returns the importer selected by --from-github, --from-gitlab,
+// or --from-gitea along with the repo argument, or nil if none is set.
+func sourceImporter(cmd *cli.Command) (source.Importer, string, error) {
+	var imp source.Importer
+	var input string
+	set := 0
+	if s := cmd.String("from-github"); s != "" {
+		imp, input = github.New(cmd.String("github-token")), s
+		set++
+	}
+	if s := cmd.String("from-gitlab"); s != "" {
+		base := cmd.String("gitlab-url")
+		if !cmd.IsSet("gitlab-url") {
+			if u := source.BaseFromURL(s); u != "" {
+				base = u
+			}
+		}
+		imp, input = gitlab.New(base, cmd.String("gitlab-token")), s
+		set++
+	}
+	if s := cmd.String("from-gitea"); s != "" {
+		base := cmd.String("gitea-url")
+		if base == "" {
+			base = source.BaseFromURL(s)
+		}
+		if base == "" {
+			return nil, "", fmt.Errorf("--from-gitea needs --gitea-url or a full repo URL")
+		}
+		imp, input = gitea.New(base, cmd.String("gitea-token")), s
+		set++
+	}
+	if set > 1 {
+		return nil, "", fmt.Errorf("use only one of --from-github, --from-gitlab, --from-gitea")
+	}
+	return imp, input, nil
+}
+
+func runFromSource(ctx context.Context, cmd *cli.Command, client *atclient.APIClient, imp source.Importer, repoInput string) error {
 	w := cmd.Root().Writer
-	token := cmd.String("github-token")
+	if _, ok := imp.(*github.Client); cmd.Bool("deep") && !ok {
+		return fmt.Errorf("--deep is only supported with --from-github")
+	}
+	weightOpts, err := importWeightOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Parse repo input
-	owner, repo, err := github.ParseRepo(repoInput)
+	path, err := imp.ParseRepo(repoInput)
 	if err != nil {
 		return fmt.Errorf("invalid repo format: %w", err)
 	}
 
 	// Fetch repo metadata
-	repoInfo, err := github.FetchRepo(ctx, owner, repo, token)
+	repoInfo, err := imp.FetchRepo(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to fetch repo: %w", err)
 	}
 
-	// Fetch contributors
-	contributors, err := github.FetchContributors(ctx, owner, repo, token)
+	// Fetch contributors and apply --weight-by and filters
+	contributors, err := imp.FetchContributors(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to fetch contributors: %w", err)
 	}
+	values, err := fetchWeightValues(ctx, imp, path, weightOpts.By, contributors)
+	if err != nil {
+		return err
+	}
+	report := weighContributors(contributors, values, weightOpts)
 
 	// Print summary
-	fmt.Fprintf(w, "Importing from GitHub: %s\n", repoInfo.FullName)
+	fmt.Fprintf(w, "Importing from %s: %s\n", imp.Name(), repoInfo.FullName)
 	fmt.Fprintf(w, "  Description: %s\n", repoInfo.Description)
 	fmt.Fprintf(w, "  Language: %s\n", repoInfo.Language)
 	fmt.Fprintf(w, "  License: %s\n", repoInfo.License)
@@ -50,68 +111,22 @@ func runFromGitHub(ctx context.Context, cmd *cli.Command, client *atclient.APICl
 		fmt.Fprintf(w, "  Created: %s\n", t.Format("2006-01-02"))
 	}
 	fmt.Fprintf(w, "  Contributors: %d\n", len(contributors))
+	printImportWeightReport(w, weightOpts, report)
 
-	// Fetch existing contributors to avoid duplicates
-	existingContribs, err := fetchContributors(ctx, client)
+	// Create contributor records (or reuse existing ones)
+	resolver, err := newContributorResolver(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to fetch existing contributors: %w", err)
+		return err
 	}
-
-	// Build a map of identifier -> (URI, CID) for quick lookup
-	existingMap := make(map[string]struct {
-		uri string
-		cid string
-	})
-	for _, ec := range existingContribs {
-		existingMap[ec.Identifier] = struct {
-			uri string
-			cid string
-		}{uri: ec.URI, cid: ec.CID}
-	}
-
-	// Create contributor records (or reuse existing ones)
-	var createdContribs []createdContributor
-	for _, c := range contributors {
-		// Check if contributor already exists
-		if existing, found := existingMap[c.HTMLURL]; found {
-			fmt.Fprintf(w, "  ✓ Found existing contributor: %s (%d commits)\n", c.Login, c.Contributions)
-			createdContribs = append(createdContribs, createdContributor{
-				uri:           existing.uri,
-				cid:           existing.cid,
-				contributions: c.Contributions,
-			})
-			continue
-		}
-
-		// Create new contributor record
-		contribRecord := map[string]any{
-			"$type":       atproto.CollectionContributorInfo,
-			"createdAt":   time.Now().UTC().Format(time.RFC3339),
-			"identifier":  c.HTMLURL,
-			"displayName": c.Login,
-		}
-		if c.AvatarURL != "" {
-			contribRecord["image"] = map[string]any{
-				"$type": "org.hypercerts.defs#uri",
-				"uri":   c.AvatarURL,
-			}
-		}
-
-		uri, cid, err := atproto.CreateRecord(ctx, client, atproto.CollectionContributorInfo, contribRecord)
-		if err != nil {
-			return fmt.Errorf("failed to create contributor %s: %w", c.Login, err)
-		}
-
-		fmt.Fprintf(w, "  ✓ Created contributor: %s (%d commits)\n", c.Login, c.Contributions)
-		createdContribs = append(createdContribs, createdContributor{
-			uri:           uri,
-			cid:           cid,
-			contributions: c.Contributions,
-		})
+	resolver.includeEmails = cmd.Bool("include-emails")
+	resolver.printUnlinked(w, report.Kept, "profile URL")
+	createdContribs, err := resolver.resolve(ctx, w, report.Kept)
+	if err != nil {
+		return err
 	}
 
 	// Build activity record
-	activityRecord := buildActivityFromGitHub(repoInfo, createdContribs)
+	activityRecord := buildActivityFromSource(repoInfo, createdContribs)
 
 	// Pass through --work-scope if provided
 	if s := cmd.String("work-scope"); s != "" {
@@ -127,16 +142,144 @@ func runFromGitHub(ctx context.Context, cmd *cli.Command, client *atclient.APICl
 	}
 
 	// Create activity record
-	uri, _, err := atproto.CreateRecord(ctx, client, atproto.CollectionActivity, activityRecord)
+	uri, cid, err := atproto.CreateRecord(ctx, client, atproto.CollectionActivity, activityRecord)
 	if err != nil {
 		return fmt.Errorf("failed to create activity: %w", err)
 	}
 
 	fmt.Fprintf(w, "\033[32m✓\033[0m Created activity: %s\n", uri)
+
+	if gh, ok := imp.(*github.Client); ok && cmd.Bool("deep") {
+		if err := runGitHubDeepImport(ctx, w, client, gh, path, repoInfo, len(report.Kept), activityRecord, uri, cid); err != nil {
+			return fmt.Errorf("deep import incomplete: %w", err)
+		}
+	}
 	return nil
 }
 
-func buildActivityFromGitHub(repo *github.RepoInfo, contribs []createdContributor) map[string]any {
+// contributorResolver maps imported contributors to contributor records,
+// reusing existing records by profile URL (or commit email with
+// --include-emails). Records it creates are remembered, so contributors shared
+// by several imported repos get a single record.
+type contributorResolver struct {
+	client        *atclient.APIClient
+	existing      map[string]createdContributor // identifier -> record ref
+	used          map[string]bool               // record URIs resolved so far
+	includeEmails bool                          // identify contributors without a profile URL by commit email
+}
+
+func newContributorResolver(ctx context.Context, client *atclient.APIClient) (*contributorResolver, error) {
+	existingContribs, err := fetchContributors(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch existing contributors: %w", err)
+	}
+	r := &contributorResolver{client: client, existing: map[string]createdContributor{}, used: map[string]bool{}}
+	for _, ec := range existingContribs {
+		r.existing[ec.Identifier] = createdContributor{uri: ec.URI, cid: ec.CID}
+	}
+	return r, nil
+}
+
+// identifier is the identity stored for c: its email identifier only when the
+// user opted in with --include-emails.
+func (r *contributorResolver) identifier(c source.Contributor) string {
+	if r.includeEmails {
+		return c.EmailIdentifier()
+	}
+	return c.Identifier()
+}
+
+// key is the identity used to match c with existing records: the DID or
+// profile URL, or the commit email with --include-emails. Names are not
+// unique, so contributors with none of these always get a new record.
+func (r *contributorResolver) key(c source.Contributor) string {
+	switch {
+	case c.Linked():
+		return c.Identifier()
+	case r.includeEmails && c.Email != "":
+		return c.EmailIdentifier()
+	}
+	return ""
+}
+
+// printUnlinked reports, before any records are written, how many kept
+// contributors have no linked identity (described by what, e.g. "profile URL")
+// and how they will be identified.
+func (r *contributorResolver) printUnlinked(w io.Writer, kept []weightedContributor, what string) {
+	n := 0
+	for _, c := range kept {
+		if !c.Linked() {
+			n++
+		}
+	}
+	switch {
+	case n == 0:
+	case r.includeEmails:
+		fmt.Fprintf(w, "Warning: %d contributor(s) without a %s will be identified by commit email in public records\n", n, what)
+	default:
+		fmt.Fprintf(w, "  %d contributor(s) without a %s will get new records identified by name only (match and publish commit emails with --include-emails)\n", n, what)
+	}
+}
+
+// resolve returns a record ref per contributor record, carrying its weight.
+// Kept contributors that resolve to the same record, such as one person
+// reported under two commit emails, are listed once with their weights summed.
+func (r *contributorResolver) resolve(ctx context.Context, w io.Writer, kept []weightedContributor) ([]createdContributor, error) {
+	var result []createdContributor
+	var weights []float64
+	index := map[string]int{} // record URI -> entry in result
+	for _, c := range kept {
+		key := r.key(c.Contributor)
+		ref, found := r.existing[key]
+		found = found && key != ""
+		if found {
+			fmt.Fprintf(w, "  ✓ Found existing contributor: %s (weight %s)\n", c.Login, formatImportWeight(c.Weight))
+		} else {
+			uri, cid, err := atproto.CreateRecord(ctx, r.client, atproto.CollectionContributorInfo, sourceContributorRecord(c.Contributor, r.identifier(c.Contributor)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create contributor %s: %w", c.Login, err)
+			}
+			fmt.Fprintf(w, "  ✓ Created contributor: %s (weight %s)\n", c.Login, formatImportWeight(c.Weight))
+			ref = createdContributor{uri: uri, cid: cid}
+			if key != "" {
+				r.existing[key] = ref
+			}
+		}
+		r.used[ref.uri] = true
+		if i, ok := index[ref.uri]; ok {
+			weights[i] += c.Weight
+			result[i].contributions += c.Contributions
+			result[i].weight = formatImportWeight(weights[i])
+			continue
+		}
+		index[ref.uri] = len(result)
+		weights = append(weights, c.Weight)
+		ref.contributions = c.Contributions
+		ref.weight = formatImportWeight(c.Weight)
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
+// sourceContributorRecord builds a contributor record for a code-host user
+// with the given identifier.
+func sourceContributorRecord(c source.Contributor, identifier string) map[string]any {
+	record := map[string]any{
+		"$type":       atproto.CollectionContributorInfo,
+		"createdAt":   time.Now().UTC().Format(time.RFC3339),
+		"identifier":  identifier,
+		"displayName": c.Login,
+	}
+	if c.AvatarURL != "" {
+		record["image"] = map[string]any{
+			"$type": "org.hypercerts.defs#uri",
+			"uri":   c.AvatarURL,
+		}
+	}
+	return record
+}
+
+func buildActivityFromSource(repo *source.RepoInfo, contribs []createdContributor) map[string]any {
 	record := map[string]any{
 		"$type":     atproto.CollectionActivity,
 		"createdAt": time.Now().UTC().Format(time.RFC3339),
@@ -155,7 +298,11 @@ func buildActivityFromGitHub(repo *github.RepoInfo, contribs []createdContributo
 
 	// Long description with GitHub URL and metadata
 	var descParts []string
-	descParts = append(descParts, fmt.Sprintf("GitHub: %s", repo.HTMLURL))
+	host := repo.Source
+	if host == "" {
+		host = "GitHub"
+	}
+	descParts = append(descParts, fmt.Sprintf("%s: %s", host, repo.HTMLURL))
 	if repo.License != "" {
 		descParts = append(descParts, fmt.Sprintf("License: %s", repo.License))
 	}
@@ -175,12 +322,12 @@ func buildActivityFromGitHub(repo *github.RepoInfo, contribs []createdContributo
 		}
 	}
 
-	// Contributors with proportional weights (raw commit counts)
+	// Contributors with proportional weights (commit counts unless --weight-by)
 	var contributorsArray []any
 	for _, c := range contribs {
 		obj := map[string]any{
 			"contributorIdentity": buildStrongRef(c.uri, c.cid),
-			"contributionWeight":  fmt.Sprintf("%d", c.contributions),
+			"contributionWeight":  c.contributionWeight(),
 		}
 		contributorsArray = append(contributorsArray, obj)
 	}
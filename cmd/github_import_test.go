@@ -1,11 +1,24 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
 
 	"github.com/GainForest/hypercerts-cli/internal/atproto"
 	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/gitlog"
+	"github.com/GainForest/hypercerts-cli/internal/source"
 )
 
 func TestBuildActivityFromGitHub(t *testing.T) {
@@ -205,7 +218,7 @@ func TestBuildActivityFromGitHub(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			record := buildActivityFromGitHub(tt.repo, tt.contribs)
+			record := buildActivityFromSource(tt.repo, tt.contribs)
 
 			// Common checks
 			if record["$type"] != atproto.CollectionActivity {
@@ -226,3 +239,596 @@ func TestBuildActivityFromGitHub(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubRepoFromActivity(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantOwner   string
+		wantRepo    string
+		wantOK      bool
+	}{
+		{"imported", "GitHub: https://github.com/GainForest/hypercerts-cli\nLicense: MIT", "GainForest", "hypercerts-cli", true},
+		{"later_line", "Some notes\nGitHub: https://github.com/a/b", "a", "b", true},
+		{"none", "Rainforest carbon study", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, ok := githubRepoFromActivity(map[string]any{"description": tt.description})
+			if owner != tt.wantOwner || repo != tt.wantRepo || ok != tt.wantOK {
+				t.Errorf("githubRepoFromActivity() = %q, %q, %v", owner, repo, ok)
+			}
+		})
+	}
+}
+
+func TestPlanGitHubSync(t *testing.T) {
+	aliceURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/alice"
+	manualURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/manual"
+	activity := map[string]any{
+		"endDate": "2026-01-01T00:00:00Z",
+		"contributors": []any{
+			map[string]any{"contributorIdentity": buildStrongRef(aliceURI, "a1"), "contributionWeight": "10"},
+			map[string]any{"contributorIdentity": buildStrongRef(manualURI, "m1"), "contributionWeight": "5"},
+		},
+	}
+	identifiers := map[string]string{
+		aliceURI:  "https://github.com/alice",
+		manualURI: "did:plc:manual",
+	}
+	repo := &github.RepoInfo{PushedAt: "2026-03-09T12:00:00Z"}
+	gh := []github.Contributor{
+		{Login: "alice", HTMLURL: "https://github.com/alice", Contributions: 14},
+		{Login: "bob", HTMLURL: "https://github.com/bob", Contributions: 3},
+		{Login: "dependabot[bot]", HTMLURL: "https://github.com/apps/dependabot", Contributions: 40},
+	}
+	commits := func(cs []github.Contributor) []weightedContributor {
+		values, _ := fetchWeightValues(context.Background(), nil, "", weightByCommits, cs)
+		return weighContributors(cs, values, importWeightOptions{By: weightByCommits}).Kept
+	}
+
+	// Without --reweight only missing contributors are added, scaled to the
+	// existing weights: alice's 14 commits are stored as 10.
+	if plan := planGitHubSync(activity, identifiers, repo, commits(gh), false); len(plan.Changes) != 1 || plan.Changes[0].Contributor.Login != "bob" || plan.Changes[0].NewWeight != "2.14" {
+		t.Errorf("without reweight: changes = %+v, want only bob added with weight 2.14", plan.Changes)
+	}
+
+	plan := planGitHubSync(activity, identifiers, repo, commits(gh), true)
+	if plan.NewEndDate != "2026-03-09T12:00:00Z" || plan.OldEndDate != "2026-01-01T00:00:00Z" {
+		t.Errorf("end date %q → %q", plan.OldEndDate, plan.NewEndDate)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("got %d changes %+v, want 2", len(plan.Changes), plan.Changes)
+	}
+	if c := plan.Changes[0]; c.Index != 0 || c.OldWeight != "10" || c.NewWeight != "14" {
+		t.Errorf("alice change = %+v", c)
+	}
+	if c := plan.Changes[1]; c.Index != -1 || c.Contributor.Login != "bob" || c.NewWeight != "3" {
+		t.Errorf("bob change = %+v", c)
+	}
+
+	// Re-planning after applying is a no-op.
+	gh = gh[:1]
+	activity["endDate"] = repo.PushedAt
+	activity["contributors"].([]any)[0].(map[string]any)["contributionWeight"] = "14"
+	if plan := planGitHubSync(activity, identifiers, repo, commits(gh), true); !plan.empty() {
+		t.Errorf("expected empty plan, got %+v", plan)
+	}
+}
+
+func TestPlanGitHubSyncScalesNewWeights(t *testing.T) {
+	aliceURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/alice"
+	bobURI := "at://did:plc:me/org.hypercerts.claim.contributorInformation/bob"
+	identifiers := map[string]string{aliceURI: "https://github.com/alice", bobURI: "https://github.com/bob"}
+	repo := &github.RepoInfo{}
+	kept := []weightedContributor{
+		{Contributor: github.Contributor{Login: "alice", HTMLURL: "https://github.com/alice"}, Weight: 30},
+		{Contributor: github.Contributor{Login: "bob", HTMLURL: "https://github.com/bob"}, Weight: 10},
+		{Contributor: github.Contributor{Login: "carol", HTMLURL: "https://github.com/carol"}, Weight: 8},
+	}
+	tests := []struct {
+		name    string
+		weights []string
+		want    string
+	}{
+		{"normalized percentages", []string{"75", "25"}, "20"},
+		{"raw counts", []string{"30", "10"}, "8"},
+		{"legacy weight", []string{"75", "high"}, "8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := map[string]any{"contributors": []any{
+				map[string]any{"contributorIdentity": buildStrongRef(aliceURI, "a"), "contributionWeight": tt.weights[0]},
+				map[string]any{"contributorIdentity": buildStrongRef(bobURI, "b"), "contributionWeight": tt.weights[1]},
+			}}
+			plan := planGitHubSync(activity, identifiers, repo, kept, false)
+			if len(plan.Changes) != 1 || plan.Changes[0].NewWeight != tt.want {
+				t.Errorf("changes = %+v, want carol added with weight %s", plan.Changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanGitHubSyncKeepsEqualWeights(t *testing.T) {
+	gh := []github.Contributor{
+		{Login: "alice", HTMLURL: "https://github.com/alice", Contributions: 14},
+		{Login: "bob", HTMLURL: "https://github.com/bob", Contributions: 3},
+		{Login: "carol", HTMLURL: "https://github.com/carol", Contributions: 1},
+		{Login: "renovate[bot]", HTMLURL: "https://github.com/apps/renovate", Contributions: 9},
+	}
+	opts := importWeightOptions{By: weightByEqual, Exclude: map[string]bool{"carol": true}}
+	values, err := fetchWeightValues(context.Background(), nil, "", opts.By, gh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := weighContributors(gh, values, opts).Kept
+
+	// The activity as imported with --weight-by equal --exclude carol.
+	identifiers := map[string]string{}
+	var entries []any
+	for _, c := range kept {
+		uri := "at://did:plc:me/org.hypercerts.claim.contributorInformation/" + c.Login
+		identifiers[uri] = c.Identifier()
+		entries = append(entries, map[string]any{"contributorIdentity": buildStrongRef(uri, "c"), "contributionWeight": formatImportWeight(c.Weight)})
+	}
+	activity := map[string]any{"endDate": "2026-03-09T12:00:00Z", "contributors": entries}
+	repo := &github.RepoInfo{PushedAt: "2026-03-09T12:00:00Z"}
+
+	for _, reweight := range []bool{false, true} {
+		if plan := planGitHubSync(activity, identifiers, repo, kept, reweight); !plan.empty() {
+			t.Errorf("reweight=%v: equal-weighted activity changed: %+v", reweight, plan.Changes)
+		}
+	}
+	// A default (commits) sync leaves the equal weights alone too.
+	values, _ = fetchWeightValues(context.Background(), nil, "", weightByCommits, gh)
+	byCommits := weighContributors(gh, values, importWeightOptions{By: weightByCommits, Exclude: opts.Exclude}).Kept
+	if plan := planGitHubSync(activity, identifiers, repo, byCommits, false); !plan.empty() {
+		t.Errorf("commits sync without --reweight changed weights: %+v", plan.Changes)
+	}
+}
+
+func TestGroupGitAuthors(t *testing.T) {
+	authors := []gitlog.Author{
+		{Name: "Alice", Email: "alice@work.org", Commits: 10, Additions: 100},
+		{Name: "Bob", Email: "bob@example.org", Commits: 6, Additions: 20},
+		{Name: "alice", Email: "alice@home.net", Commits: 3, Additions: 5},
+		{Name: "Bob", Email: "bob@other.org", Commits: 2},
+		{Name: "", Email: "ci@example.org", Commits: 1},
+	}
+	authorMap := map[string]string{
+		"alice@work.org": "did:plc:alice",
+		"alice@home.net": "did:plc:alice",
+	}
+	got := groupGitAuthors(authors, authorMap)
+	if len(got) != 4 {
+		t.Fatalf("got %d contributors %+v, want 4", len(got), got)
+	}
+	if a := got[0]; a.Identifier() != "did:plc:alice" || !a.Linked() || a.Login != "Alice" || a.Contributions != 13 || a.Additions != 105 {
+		t.Errorf("alice = %+v", a)
+	}
+	// Same-name authors are not combined: names do not identify people.
+	if b := got[1]; b.Identifier() != "Bob" || b.Linked() || b.Contributions != 6 {
+		t.Errorf("bob = %+v, want a name-only identifier", b)
+	}
+	if b := got[2]; b.Login != "Bob" || b.Contributions != 2 {
+		t.Errorf("second bob = %+v", b)
+	}
+	if u := got[3]; u.Identifier() != "Unnamed author 1" {
+		t.Errorf("unnamed = %+v, want a positional label", u)
+	}
+	for _, c := range got {
+		if strings.Contains(c.Identifier(), "@") {
+			t.Errorf("identifier %q exposes an email", c.Identifier())
+		}
+	}
+	if id := got[1].EmailIdentifier(); id != "mailto:bob@example.org" {
+		t.Errorf("bob email identifier = %q", id)
+	}
+	if id := got[0].EmailIdentifier(); id != "did:plc:alice" {
+		t.Errorf("mapped email identifier = %q, want the DID", id)
+	}
+}
+
+func TestGitAuthorWeights(t *testing.T) {
+	contributors := groupGitAuthors([]gitlog.Author{
+		{Name: "Alice", Email: "alice@example.org", Commits: 8, Additions: 10},
+		{Name: "dependabot[bot]", Email: "bot@example.org", Commits: 5, Additions: 50},
+		{Name: "Carol", Email: "carol@example.org", Commits: 1, Additions: 300},
+	}, nil)
+
+	values, err := fetchWeightValues(context.Background(), nil, "", weightByAdditions, contributors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := weighContributors(contributors, values, importWeightOptions{By: weightByAdditions, Exclude: map[string]bool{}, Min: 20})
+	if len(report.Kept) != 1 || report.Kept[0].Login != "Carol" || report.Kept[0].Weight != 300 {
+		t.Errorf("kept = %+v, want only Carol weighted by additions", report.Kept)
+	}
+	if len(report.Bots) != 1 || len(report.BelowMin) != 1 {
+		t.Errorf("bots = %v, below min = %v", report.Bots, report.BelowMin)
+	}
+
+	if _, err := fetchWeightValues(context.Background(), nil, "", weightByPRs, contributors); err == nil {
+		t.Error("expected --weight-by prs to be rejected for git history")
+	}
+}
+
+func TestBuildActivityFromGit(t *testing.T) {
+	repo := &gitlog.RepoInfo{
+		Name:        "forest-monitor",
+		Path:        "/src/forest-monitor",
+		FirstCommit: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		LastCommit:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Commits:     42,
+	}
+	record := buildActivityFromGit(repo, 2, []createdContributor{
+		{uri: "at://did:plc:me/org.hypercerts.claim.contributorInformation/1", cid: "bafy1", contributions: 30},
+		{uri: "at://did:plc:me/org.hypercerts.claim.contributorInformation/2", cid: "bafy2", contributions: 12},
+	})
+	if record["title"] != "forest-monitor" {
+		t.Errorf("title = %v", record["title"])
+	}
+	if record["startDate"] != "2025-01-02T03:04:05Z" || record["endDate"] != "2025-06-01T00:00:00Z" {
+		t.Errorf("dates = %v..%v", record["startDate"], record["endDate"])
+	}
+	if desc := mapStr(record, "description"); !strings.Contains(desc, "Git: /src/forest-monitor") {
+		t.Errorf("description = %q", desc)
+	}
+	contributors := mapSlice(record, "contributors")
+	if len(contributors) != 2 || mapStr(contributors[1].(map[string]any), "contributionWeight") != "12" {
+		t.Errorf("contributors = %v", contributors)
+	}
+}
+
+func TestReleaseAttachment(t *testing.T) {
+	subject := buildStrongRef("at://did:plc:me/org.hypercerts.claim.activity/a1", "bafya")
+	rel := github.Release{
+		TagName: "v1.0.0",
+		Body:    strings.Repeat("notes ", 100),
+		HTMLURL: "https://github.com/o/r/releases/tag/v1.0.0",
+		Assets:  []github.ReleaseAsset{{Name: "bin.zip", URL: "https://github.com/o/r/releases/download/v1.0.0/bin.zip"}},
+	}
+	record := releaseAttachment(rel, subject)
+	if record["$type"] != atproto.CollectionAttachment || record["title"] != "v1.0.0" {
+		t.Errorf("record = %v", record)
+	}
+	content := mapSlice(record, "content")
+	if len(content) != 2 || mapStr(content[1].(map[string]any), "uri") != rel.Assets[0].URL {
+		t.Errorf("content = %v", content)
+	}
+	if len(mapStr(record, "shortDescription")) != 300 || mapStr(record, "description") != rel.Body {
+		t.Errorf("release notes not carried over")
+	}
+	if subjects := mapSlice(record, "subjects"); len(subjects) != 1 {
+		t.Errorf("subjects = %v", subjects)
+	}
+}
+
+func TestGitHubMeasurements(t *testing.T) {
+	repo := &github.RepoInfo{HTMLURL: "https://github.com/o/r", CreatedAt: "2025-01-01T00:00:00Z", PushedAt: "2025-12-31T00:00:00Z"}
+	start, end := deepImportPeriod(repo, map[string]any{})
+	if start.Format("2006-01-02") != "2025-01-01" || end.Format("2006-01-02") != "2025-12-31" {
+		t.Errorf("period = %v..%v", start, end)
+	}
+	_, end = deepImportPeriod(repo, map[string]any{"endDate": "2025-06-30T00:00:00Z"})
+	if end.Format("2006-01-02") != "2025-06-30" {
+		t.Errorf("activity endDate should win, got %v", end)
+	}
+
+	subject := buildStrongRef("at://did:plc:me/org.hypercerts.claim.activity/a1", "bafya")
+	records := githubMeasurements(repo, githubStats{MergedPRs: 12, ClosedIssues: 7, Stars: 300, Contributors: 4}, subject, start, end)
+	got := map[string]string{}
+	for _, r := range records {
+		got[mapStr(r, "metric")] = mapStr(r, "value") + " " + mapStr(r, "unit")
+		if measurementSubjectURI(r) != "at://did:plc:me/org.hypercerts.claim.activity/a1" {
+			t.Errorf("measurement %q not linked to activity", mapStr(r, "metric"))
+		}
+		// Stars and contributors are snapshots at the end date, not flows over the period.
+		snapshot := mapStr(r, "metric") == "stars" || mapStr(r, "metric") == "contributors"
+		if _, hasStart := r["startDate"]; hasStart == snapshot || mapStr(r, "endDate") != "2025-06-30T00:00:00Z" {
+			t.Errorf("%s period = %v..%v", mapStr(r, "metric"), r["startDate"], r["endDate"])
+		}
+	}
+	want := map[string]string{
+		"merged pull requests": "12 count",
+		"closed issues":        "7 count",
+		"stars":                "300 count",
+		"contributors":         "4 people",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestParseCustomWeights(t *testing.T) {
+	input := `login,weight
+# core team
+Alice, 3
+https://github.com/bob,1.5
+`
+	got, err := parseCustomWeights(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["alice"] != 3 || got["https://github.com/bob"] != 1.5 {
+		t.Errorf("parseCustomWeights() = %v", got)
+	}
+
+	for _, bad := range []string{"carol\n", "carol,-1\n", "carol,lots\n"} {
+		if _, err := parseCustomWeights(strings.NewReader(bad)); err == nil {
+			t.Errorf("parseCustomWeights(%q) expected error", bad)
+		}
+	}
+}
+
+func TestWeighContributors(t *testing.T) {
+	contributors := []github.Contributor{
+		{Login: "alice", HTMLURL: "https://github.com/alice", Contributions: 40},
+		{Login: "dependabot[bot]", HTMLURL: "https://github.com/apps/dependabot", Contributions: 30},
+		{Login: "ci-user", HTMLURL: "https://github.com/ci-user", Contributions: 20, Bot: true},
+		{Login: "bob", HTMLURL: "https://github.com/bob", Contributions: 10},
+		{Login: "carol", HTMLURL: "https://github.com/carol", Contributions: 2},
+	}
+	commits := []float64{40, 30, 20, 10, 2}
+
+	logins := func(kept []weightedContributor) string {
+		var s []string
+		for _, c := range kept {
+			s = append(s, c.Login+"="+formatImportWeight(c.Weight))
+		}
+		return strings.Join(s, " ")
+	}
+
+	tests := []struct {
+		name     string
+		values   []float64
+		opts     importWeightOptions
+		wantKept string
+		check    func(t *testing.T, r importWeightReport)
+	}{
+		{
+			name:     "bots dropped by default",
+			values:   commits,
+			opts:     importWeightOptions{By: weightByCommits},
+			wantKept: "alice=40 bob=10 carol=2",
+			check: func(t *testing.T, r importWeightReport) {
+				if strings.Join(r.Bots, ",") != "dependabot[bot],ci-user" {
+					t.Errorf("Bots = %v", r.Bots)
+				}
+			},
+		},
+		{
+			name:     "include bots",
+			values:   commits,
+			opts:     importWeightOptions{By: weightByCommits, IncludeBots: true},
+			wantKept: "alice=40 dependabot[bot]=30 ci-user=20 bob=10 carol=2",
+		},
+		{
+			name:     "exclude by login or profile URL",
+			values:   commits,
+			opts:     importWeightOptions{By: weightByCommits, Exclude: map[string]bool{"alice": true, "https://github.com/carol": true}},
+			wantKept: "bob=10",
+			check: func(t *testing.T, r importWeightReport) {
+				if len(r.Excluded) != 2 {
+					t.Errorf("Excluded = %v", r.Excluded)
+				}
+			},
+		},
+		{
+			name:     "minimum threshold",
+			values:   commits,
+			opts:     importWeightOptions{By: weightByCommits, Min: 5},
+			wantKept: "alice=40 bob=10",
+			check: func(t *testing.T, r importWeightReport) {
+				if len(r.BelowMin) != 1 || r.BelowMin[0] != "carol" {
+					t.Errorf("BelowMin = %v", r.BelowMin)
+				}
+			},
+		},
+		{
+			name:     "custom weights skip unlisted",
+			values:   []float64{1, 1, 1, 1, 1},
+			opts:     importWeightOptions{By: weightByCustom, Custom: map[string]float64{"alice": 2, "https://github.com/bob": 0.5}},
+			wantKept: "alice=2 bob=0.5",
+			check: func(t *testing.T, r importWeightReport) {
+				if len(r.Unlisted) != 1 || r.Unlisted[0] != "carol" {
+					t.Errorf("Unlisted = %v", r.Unlisted)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := weighContributors(contributors, tt.values, tt.opts)
+			if got := logins(r.Kept); got != tt.wantKept {
+				t.Errorf("kept = %q, want %q", got, tt.wantKept)
+			}
+			if tt.check != nil {
+				tt.check(t, r)
+			}
+		})
+	}
+}
+
+func TestFilterOrgRepos(t *testing.T) {
+	repos := []*github.RepoInfo{
+		{FullName: "o/api", Language: "Go", Topics: []string{"hypercerts", "atproto"}},
+		{FullName: "o/web", Language: "TypeScript", Topics: []string{"Hypercerts"}},
+		{FullName: "o/old", Language: "Go", Archived: true, Topics: []string{"hypercerts"}},
+		{FullName: "o/fork", Language: "Go", Fork: true},
+		{FullName: "o/docs"},
+	}
+	names := func(rs []*github.RepoInfo) string {
+		var s []string
+		for _, r := range rs {
+			s = append(s, r.FullName)
+		}
+		return strings.Join(s, " ")
+	}
+
+	tests := []struct {
+		name   string
+		filter orgRepoFilter
+		want   string
+	}{
+		{"defaults skip archived and forks", orgRepoFilter{}, "o/api o/web o/docs"},
+		{"include archived and forks", orgRepoFilter{IncludeArchived: true, IncludeForks: true}, "o/api o/web o/old o/fork o/docs"},
+		{"language", orgRepoFilter{Languages: []string{"go"}}, "o/api"},
+		{"topic is case-insensitive", orgRepoFilter{Topics: []string{"hypercerts"}}, "o/api o/web"},
+		{"topic and archived", orgRepoFilter{Topics: []string{"hypercerts"}, Languages: []string{"go"}, IncludeArchived: true}, "o/api o/old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(filterOrgRepos(repos, tt.filter)); got != tt.want {
+				t.Errorf("filterOrgRepos() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrgCollectionItems(t *testing.T) {
+	activities := []orgActivity{
+		{uri: "at://did:plc:me/org.hypercerts.claim.activity/a", cid: "bafya", commits: 300, contributors: 1},
+		{uri: "at://did:plc:me/org.hypercerts.claim.activity/b", cid: "bafyb", commits: 100, contributors: 3},
+	}
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{"commits", []string{"75", "25"}},
+		{"contributors", []string{"25", "75"}},
+		{"equal", []string{"50", "50"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			items, err := orgCollectionItems(activities, tt.by)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, item := range items {
+				if item["itemWeight"] != tt.want[i] {
+					t.Errorf("item %d weight = %v, want %s", i, item["itemWeight"], tt.want[i])
+				}
+				if mapStr(mapMap(item, "itemIdentifier"), "uri") != activities[i].uri {
+					t.Errorf("item %d identifier = %v", i, item["itemIdentifier"])
+				}
+			}
+		})
+	}
+
+	if _, err := orgCollectionItems(activities, "stars"); err == nil {
+		t.Error("expected error for unknown weighting")
+	}
+	if _, err := orgCollectionItems([]orgActivity{{uri: "at://x"}}, "commits"); err == nil {
+		t.Error("expected error when all sizes are zero")
+	}
+}
+
+func TestContributorResolverIdentifier(t *testing.T) {
+	gitlabUser := source.Contributor{Login: "Alice", Email: "alice@example.org", Contributions: 4}
+	kept := []weightedContributor{{Contributor: gitlabUser, Weight: 4}}
+
+	var r contributorResolver
+	if id := r.identifier(gitlabUser); id != "Alice" {
+		t.Errorf("identifier = %q, want the name without --include-emails", id)
+	}
+	var buf bytes.Buffer
+	r.printUnlinked(&buf, kept, "profile URL")
+	if !strings.Contains(buf.String(), "1 contributor(s) without a profile URL will get new records identified by name only") {
+		t.Errorf("printUnlinked = %q", buf.String())
+	}
+
+	r.includeEmails = true
+	if id := r.identifier(gitlabUser); id != "mailto:alice@example.org" {
+		t.Errorf("identifier with --include-emails = %q", id)
+	}
+	buf.Reset()
+	r.printUnlinked(&buf, kept, "profile URL")
+	if !strings.HasPrefix(buf.String(), "Warning: 1 contributor(s)") {
+		t.Errorf("printUnlinked with emails = %q", buf.String())
+	}
+}
+
+// newCreateRecordPDS returns a client for a fake PDS that accepts
+// createRecord calls, numbering the new records and capturing them in created.
+func newCreateRecordPDS(t *testing.T, created *[]map[string]any) *atclient.APIClient {
+	t.Helper()
+	pds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/xrpc/com.atproto.repo.createRecord" {
+			http.NotFound(w, r)
+			return
+		}
+		var in struct {
+			Collection string         `json:"collection"`
+			Record     map[string]any `json:"record"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		*created = append(*created, in.Record)
+		n := len(*created)
+		_ = json.NewEncoder(w).Encode(map[string]any{"uri": fmt.Sprintf("at://did:plc:test/%s/r%d", in.Collection, n), "cid": fmt.Sprintf("bafy%d", n)})
+	}))
+	t.Cleanup(pds.Close)
+	client := atclient.NewAPIClient(pds.URL)
+	did := syntax.DID("did:plc:test")
+	client.AccountDID = &did
+	return client
+}
+
+func TestContributorResolverResolve(t *testing.T) {
+	existingURI := "at://did:plc:test/org.hypercerts.claim.contributorInformation/old"
+	kept := []weightedContributor{
+		{Contributor: source.Contributor{Login: "alex", HTMLURL: "https://gitea.example/alex", Email: "alex@work.example", Contributions: 5}, Weight: 5},
+		{Contributor: source.Contributor{Login: "alex", HTMLURL: "https://gitea.example/alex", Email: "alex@home.example", Contributions: 3}, Weight: 3},
+		{Contributor: source.Contributor{Login: "Sam", Email: "sam@a.example", Contributions: 2}, Weight: 2},
+		{Contributor: source.Contributor{Login: "Sam", Email: "sam@b.example", Contributions: 1}, Weight: 1},
+		{Contributor: source.Contributor{Login: "Kim", Email: "Kim@a.example", Contributions: 4}, Weight: 4},
+		{Contributor: source.Contributor{Login: "Kim", Email: "kim@a.example", Contributions: 1}, Weight: 1},
+	}
+	tests := []struct {
+		name          string
+		includeEmails bool
+		wantWeights   []string
+		wantCreated   int
+	}{
+		// Same-name authors are never merged or matched to the existing "Sam" record.
+		{"names only", false, []string{"8", "2", "1", "4", "1"}, 4},
+		{"with emails", true, []string{"8", "2", "1", "5"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []map[string]any
+			r := &contributorResolver{
+				client: newCreateRecordPDS(t, &created),
+				existing: map[string]createdContributor{
+					"https://gitea.example/alex": {uri: existingURI, cid: "bafyold"},
+					"Sam":                        {uri: existingURI, cid: "bafyold"},
+				},
+				used:          map[string]bool{},
+				includeEmails: tt.includeEmails,
+			}
+			refs, err := r.resolve(context.Background(), io.Discard, kept)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var weights []string
+			for _, ref := range refs {
+				weights = append(weights, ref.contributionWeight())
+			}
+			if strings.Join(weights, ",") != strings.Join(tt.wantWeights, ",") {
+				t.Errorf("weights = %v, want %v", weights, tt.wantWeights)
+			}
+			if refs[0].uri != existingURI || refs[0].contributions != 8 {
+				t.Errorf("first ref = %+v, want the existing record with 8 contributions", refs[0])
+			}
+			if len(created) != tt.wantCreated {
+				t.Errorf("created %d records, want %d", len(created), tt.wantCreated)
+			}
+			if len(r.used) != len(refs) {
+				t.Errorf("used = %d records, want %d", len(r.used), len(refs))
+			}
+		})
+	}
+}
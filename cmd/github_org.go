@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/menu"
+)
+
+// orgRepoFilter selects which organization repos to import.
+type orgRepoFilter struct {
+	Topics          []string // keep repos with any of these topics (lowercase)
+	Languages       []string // keep repos in any of these languages (lowercase)
+	IncludeArchived bool
+	IncludeForks    bool
+}
+
+// filterOrgRepos applies the filter, keeping the input order.
+func filterOrgRepos(repos []*github.RepoInfo, f orgRepoFilter) []*github.RepoInfo {
+	var result []*github.RepoInfo
+	for _, r := range repos {
+		if r.Archived && !f.IncludeArchived || r.Fork && !f.IncludeForks {
+			continue
+		}
+		if len(f.Languages) > 0 && !slices.Contains(f.Languages, strings.ToLower(r.Language)) {
+			continue
+		}
+		if len(f.Topics) > 0 && !slices.ContainsFunc(r.Topics, func(t string) bool {
+			return slices.Contains(f.Topics, strings.ToLower(t))
+		}) {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// orgActivity is one repo's activity in an org import, created or reused.
+type orgActivity struct {
+	repo         *github.RepoInfo
+	uri          string
+	cid          string
+	commits      int // commits by the kept contributors
+	contributors int // kept contributors
+	existing     bool
+}
+
+// orgCollectionItems builds collection items for the imported activities,
+// weighted by size as percentages summing to 100. by is commits, contributors, or equal.
+func orgCollectionItems(activities []orgActivity, by string) ([]map[string]any, error) {
+	sizes := make([]float64, len(activities))
+	for i, a := range activities {
+		switch by {
+		case "commits":
+			sizes[i] = float64(a.commits)
+		case "contributors":
+			sizes[i] = float64(a.contributors)
+		case "equal":
+			sizes[i] = 1
+		default:
+			return nil, fmt.Errorf("invalid --collection-weight %q: use commits, contributors, or equal", by)
+		}
+	}
+	weights, err := normalizeWeights(sizes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot weight collection by %s: %w", by, err)
+	}
+	items := make([]map[string]any, len(activities))
+	for i, a := range activities {
+		items[i] = map[string]any{
+			"itemIdentifier": buildStrongRef(a.uri, a.cid),
+			"itemWeight":     weights[i],
+		}
+	}
+	return items, nil
+}
+
+func splitLowerList(s string) []string {
+	var result []string
+	for v := range strings.SplitSeq(s, ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// fetchImportedGitHubActivities maps lowercase "owner/repo" to the activity
+// already imported from it, so re-running an org import does not duplicate.
+func fetchImportedGitHubActivities(ctx context.Context, client *atclient.APIClient) (map[string]orgActivity, error) {
+	entries, err := atproto.ListAllRecords(ctx, client, client.AccountDID.String(), atproto.CollectionActivity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activities: %w", err)
+	}
+	result := map[string]orgActivity{}
+	for _, e := range entries {
+		if owner, repo, ok := githubRepoFromActivity(e.Value); ok {
+			result[strings.ToLower(owner+"/"+repo)] = orgActivity{uri: e.URI, cid: e.CID, existing: true}
+		}
+	}
+	return result, nil
+}
+
+func runActivityImportGitHubOrg(ctx context.Context, cmd *cli.Command) error {
+	org := strings.TrimSpace(cmd.Args().First())
+	if org == "" {
+		return fmt.Errorf("usage: hc activity import-github-org <org>")
+	}
+	w := cmd.Root().Writer
+
+	weightOpts, err := importWeightOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	collectionTitle := cmd.String("collection")
+	collectionWeight := strings.ToLower(cmd.String("collection-weight"))
+	switch collectionWeight {
+	case "commits", "contributors", "equal":
+	default:
+		return fmt.Errorf("invalid --collection-weight %q: use commits, contributors, or equal", collectionWeight)
+	}
+
+	gh := github.New(cmd.String("github-token"))
+	all, err := gh.FetchOrgRepos(ctx, org)
+	if err != nil {
+		return fmt.Errorf("failed to list repos for %s: %w", org, err)
+	}
+	repos := filterOrgRepos(all, orgRepoFilter{
+		Topics:          splitLowerList(cmd.String("topic")),
+		Languages:       splitLowerList(cmd.String("language")),
+		IncludeArchived: cmd.Bool("include-archived"),
+		IncludeForks:    cmd.Bool("include-forks"),
+	})
+
+	fmt.Fprintf(w, "Found %d repo(s) in %s, %d selected\n\n", len(all), org, len(repos))
+	if len(repos) == 0 {
+		fmt.Fprintln(w, "\033[90m(no repos found)\033[0m")
+		return nil
+	}
+	printOrgRepos(w, repos)
+
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	imported, err := fetchImportedGitHubActivities(ctx, client)
+	if err != nil {
+		return err
+	}
+	if !cmd.Bool("force") && !menu.Confirm(w, os.Stdin, fmt.Sprintf("Import %d repo(s)?", len(repos))) {
+		fmt.Fprintln(w, "Aborted.")
+		return nil
+	}
+
+	resolver, err := newContributorResolver(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var activities []orgActivity
+	failed := 0
+	for _, repo := range repos {
+		fmt.Fprintf(w, "\n%s\n", repo.FullName)
+		a, err := importOrgRepo(ctx, w, client, gh, resolver, repo, weightOpts, imported)
+		if err != nil {
+			fmt.Fprintf(w, "Warning: skipping %s: %v\n", repo.FullName, err)
+			failed++
+			continue
+		}
+		activities = append(activities, a)
+	}
+
+	created := 0
+	for _, a := range activities {
+		if !a.existing {
+			created++
+		}
+	}
+	fmt.Fprintf(w, "\n\033[32m✓\033[0m Imported %d activity(ies) with %d distinct contributor(s); %d already imported, %d failed\n",
+		created, len(resolver.used), len(activities)-created, failed)
+
+	if collectionTitle == "" || len(activities) == 0 {
+		return nil
+	}
+	items, err := orgCollectionItems(activities, collectionWeight)
+	if err != nil {
+		return err
+	}
+	record := map[string]any{
+		"$type":     atproto.CollectionCollection,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+		"title":     collectionTitle,
+		"type":      "project",
+		"items":     items,
+	}
+	uri, _, err := atproto.CreateRecord(ctx, client, atproto.CollectionCollection, record)
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	fmt.Fprintf(w, "\033[32m✓\033[0m Created collection: %s (%d items, weighted by %s)\n", uri, len(items), collectionWeight)
+	return nil
+}
+
+// importOrgRepo imports one repo as an activity, or reuses the activity
+// already imported from it. Contributors are still fetched so the activity's
+// size can weight the collection.
+func importOrgRepo(ctx context.Context, w io.Writer, client *atclient.APIClient, gh *github.Client, resolver *contributorResolver, repo *github.RepoInfo, opts importWeightOptions, imported map[string]orgActivity) (orgActivity, error) {
+	path := repo.FullName
+	contributors, err := gh.FetchContributors(ctx, path)
+	if err != nil {
+		return orgActivity{}, fmt.Errorf("failed to fetch contributors: %w", err)
+	}
+	values, err := fetchWeightValues(ctx, gh, path, opts.By, contributors)
+	if err != nil {
+		return orgActivity{}, err
+	}
+	report := weighContributors(contributors, values, opts)
+
+	a, found := imported[strings.ToLower(path)]
+	a.repo = repo
+	a.contributors = len(report.Kept)
+	for _, c := range report.Kept {
+		a.commits += c.Contributions
+	}
+	if found {
+		fmt.Fprintf(w, "  ✓ Already imported: %s\n", a.uri)
+		return a, nil
+	}
+
+	printImportWeightReport(w, opts, report)
+	refs, err := resolver.resolve(ctx, w, report.Kept)
+	if err != nil {
+		return orgActivity{}, err
+	}
+	a.uri, a.cid, err = atproto.CreateRecord(ctx, client, atproto.CollectionActivity, buildActivityFromSource(repo, refs))
+	if err != nil {
+		return orgActivity{}, fmt.Errorf("failed to create activity: %w", err)
+	}
+	fmt.Fprintf(w, "  ✓ Created activity: %s\n", a.uri)
+	return a, nil
+}
+
+func printOrgRepos(w io.Writer, repos []*github.RepoInfo) {
+	fmt.Fprintf(w, "\033[1m%-40s %-14s %-6s %s\033[0m\n", "REPO", "LANGUAGE", "STARS", "TOPICS")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 80))
+	for _, r := range repos {
+		name := r.FullName
+		if r.Archived {
+			name += " (archived)"
+		}
+		fmt.Fprintf(w, "%-40s %-14s %-6d %s\n", truncate(name, 40), truncate(r.Language, 14), r.Stars, strings.Join(r.Topics, ", "))
+	}
+	fmt.Fprintln(w)
+}
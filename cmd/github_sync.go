@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/menu"
+)
+
+// githubSyncChange is one contributor difference between an activity and its GitHub repo.
+type githubSyncChange struct {
+	Contributor github.Contributor
+	Index       int // entry in contributors[], or -1 for a new contributor
+	OldWeight   string
+	NewWeight   string
+}
+
+// githubSyncPlan is everything sync-github would change on an activity.
+type githubSyncPlan struct {
+	OldEndDate string
+	NewEndDate string
+	Changes    []githubSyncChange
+}
+
+func (p githubSyncPlan) empty() bool {
+	return p.OldEndDate == p.NewEndDate && len(p.Changes) == 0
+}
+
+// githubRepoFromActivity recovers the GitHub repo an activity was imported from
+// using the "GitHub: <url>" line written by buildActivityFromSource.
+func githubRepoFromActivity(activity map[string]any) (owner, repo string, ok bool) {
+	for line := range strings.SplitSeq(mapStr(activity, "description"), "\n") {
+		if u, found := strings.CutPrefix(strings.TrimSpace(line), "GitHub: "); found {
+			owner, repo, err := github.ParseRepo(strings.TrimSpace(u))
+			return owner, repo, err == nil
+		}
+	}
+	return "", "", false
+}
+
+// planGitHubSync compares an activity's contributors[] with the GitHub
+// contributors kept by the import weighting and filters. identifiers maps
+// contributor record URIs to their identifier (the GitHub profile URL for
+// imported contributors). Kept contributors missing from the activity are
+// added. Existing weights are only rewritten when reweight is set, so weights
+// from --weight-by or --normalize survive a sync; new weights are then scaled
+// to match them (see syncWeightScale). Entries with no GitHub match are left
+// alone, so manually added contributors survive too.
+func planGitHubSync(activity map[string]any, identifiers map[string]string, repo *github.RepoInfo, kept []weightedContributor, reweight bool) githubSyncPlan {
+	plan := githubSyncPlan{OldEndDate: mapStr(activity, "endDate"), NewEndDate: mapStr(activity, "endDate")}
+	if repo.PushedAt != "" {
+		plan.NewEndDate = repo.PushedAt
+	}
+
+	entries := mapSlice(activity, "contributors")
+	byProfile := map[string]int{}
+	for i, c := range entries {
+		cm, _ := c.(map[string]any)
+		uri := mapStr(mapMap(cm, "contributorIdentity"), "uri")
+		if id := identifiers[uri]; id != "" {
+			byProfile[id] = i
+		}
+	}
+
+	scale := 1.0
+	if !reweight {
+		scale = syncWeightScale(entries, byProfile, kept)
+	}
+	for _, c := range kept {
+		i, found := byProfile[c.Identifier()]
+		if !found {
+			weight := formatImportWeight(c.Weight)
+			if scale != 1 {
+				weight = formatImportWeight(math.Round(c.Weight*scale*100) / 100)
+			}
+			plan.Changes = append(plan.Changes, githubSyncChange{Contributor: c.Contributor, Index: -1, NewWeight: weight})
+			continue
+		}
+		if !reweight {
+			continue
+		}
+		weight := formatImportWeight(c.Weight)
+		cm, _ := entries[i].(map[string]any)
+		if old := mapStr(cm, "contributionWeight"); old != weight {
+			plan.Changes = append(plan.Changes, githubSyncChange{Contributor: c.Contributor, Index: i, OldWeight: old, NewWeight: weight})
+		}
+	}
+	return plan
+}
+
+// syncWeightScale returns the factor that maps freshly computed weights onto
+// the scale of the activity's existing weights, e.g. percentages written by
+// --normalize: the sum of existing weights over the sum of new weights, for
+// the contributors present in both. It is 1 when there is nothing to compare
+// or an existing weight is not a number.
+func syncWeightScale(entries []any, byProfile map[string]int, kept []weightedContributor) float64 {
+	var oldSum, newSum float64
+	for _, c := range kept {
+		i, found := byProfile[c.Identifier()]
+		if !found {
+			continue
+		}
+		cm, _ := entries[i].(map[string]any)
+		old, err := parseWeight(mapStr(cm, "contributionWeight"))
+		if err != nil {
+			return 1
+		}
+		oldSum += old
+		newSum += c.Weight
+	}
+	if oldSum == 0 || newSum == 0 {
+		return 1
+	}
+	return oldSum / newSum
+}
+
+func printGitHubSyncPlan(w io.Writer, plan githubSyncPlan) {
+	if plan.OldEndDate != plan.NewEndDate {
+		fmt.Fprintf(w, "  endDate: %s → %s\n", formatDate(plan.OldEndDate), formatDate(plan.NewEndDate))
+	}
+	for _, c := range plan.Changes {
+		if c.Index < 0 {
+			fmt.Fprintf(w, "  \033[32m+ %s\033[0m (weight %s)\n", c.Contributor.Login, c.NewWeight)
+			continue
+		}
+		old := c.OldWeight
+		if old == "" {
+			old = "-"
+		}
+		fmt.Fprintf(w, "  \033[33m~ %s\033[0m weight %s → %s\n", c.Contributor.Login, old, c.NewWeight)
+	}
+}
+
+func runActivitySyncGitHub(ctx context.Context, cmd *cli.Command) error {
+	arg := cmd.Args().First()
+	if arg == "" {
+		return fmt.Errorf("usage: hc activity sync-github <id|at-uri>")
+	}
+	weightOpts, err := importWeightOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+	did := client.AccountDID.String()
+
+	uri := resolveRecordURI(did, atproto.CollectionActivity, arg)
+	aturi, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return fmt.Errorf("invalid URI: %w", err)
+	}
+	activity, cid, err := atproto.GetRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String())
+	if err != nil {
+		return fmt.Errorf("activity not found: %s", arg)
+	}
+
+	var owner, repo string
+	if s := cmd.String("from-github"); s != "" {
+		if owner, repo, err = github.ParseRepo(s); err != nil {
+			return fmt.Errorf("invalid repo format: %w", err)
+		}
+	} else if o, r, ok := githubRepoFromActivity(activity); ok {
+		owner, repo = o, r
+	} else {
+		return fmt.Errorf("activity has no GitHub URL in its description; pass --from-github owner/repo")
+	}
+
+	gh := github.New(cmd.String("github-token"))
+	repoInfo, err := gh.FetchRepo(ctx, owner+"/"+repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repo: %w", err)
+	}
+	ghContributors, err := gh.FetchContributors(ctx, owner+"/"+repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch contributors: %w", err)
+	}
+	values, err := fetchWeightValues(ctx, gh, owner+"/"+repo, weightOpts.By, ghContributors)
+	if err != nil {
+		return err
+	}
+	report := weighContributors(ghContributors, values, weightOpts)
+
+	existing, err := fetchContributors(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to fetch existing contributors: %w", err)
+	}
+	identifiers := map[string]string{}
+	byIdentifier := map[string]contributorOption{}
+	for _, c := range existing {
+		identifiers[c.URI] = c.Identifier
+		byIdentifier[c.Identifier] = c
+	}
+
+	plan := planGitHubSync(activity, identifiers, repoInfo, report.Kept, cmd.Bool("reweight"))
+	fmt.Fprintf(w, "Syncing %s from GitHub: %s\n", extractRkey(uri), repoInfo.FullName)
+	printImportWeightReport(w, weightOpts, report)
+	if plan.empty() {
+		fmt.Fprintln(w, "Already up to date.")
+		return nil
+	}
+	printGitHubSyncPlan(w, plan)
+
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+	if !cmd.Bool("force") && !menu.Confirm(w, os.Stdin, "Apply these changes?") {
+		fmt.Fprintln(w, "Aborted.")
+		return nil
+	}
+
+	entries := mapSlice(activity, "contributors")
+	for _, c := range plan.Changes {
+		if c.Index >= 0 {
+			entries[c.Index].(map[string]any)["contributionWeight"] = c.NewWeight
+			continue
+		}
+		ref, found := byIdentifier[c.Contributor.Identifier()]
+		if !found {
+			curi, ccid, err := atproto.CreateRecord(ctx, client, atproto.CollectionContributorInfo, sourceContributorRecord(c.Contributor, c.Contributor.Identifier()))
+			if err != nil {
+				return fmt.Errorf("failed to create contributor %s: %w", c.Contributor.Login, err)
+			}
+			fmt.Fprintf(w, "  ✓ Created contributor: %s\n", c.Contributor.Login)
+			ref = contributorOption{URI: curi, CID: ccid}
+		}
+		entries = append(entries, map[string]any{
+			"contributorIdentity": buildStrongRef(ref.URI, ref.CID),
+			"contributionWeight":  c.NewWeight,
+		})
+	}
+	if len(entries) > 0 {
+		activity["contributors"] = entries
+	}
+	if plan.NewEndDate != "" {
+		activity["endDate"] = plan.NewEndDate
+	}
+
+	if _, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), activity, &cid); err != nil {
+		return fmt.Errorf("failed to update activity (it may have changed since the diff was computed): %w", err)
+	}
+	fmt.Fprintf(w, "\033[32m✓\033[0m Synced activity: %s\n", uri)
+	return nil
+}
@@ -0,0 +1,454 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/jetstream"
+)
+
+const (
+	hooksFile       = "hc/hooks.json"
+	hooksCursorFile = "hc/hooks-cursor.json"
+)
+
+// hookCollections are the record types hooks fire on by default: records
+// other accounts create about our work.
+var hookCollections = []string{
+	atproto.CollectionEvaluation,
+	atproto.CollectionAcknowledgement,
+	atproto.CollectionBadgeAward,
+	atproto.CollectionFundingReceipt,
+}
+
+// Hook timing. Variables so tests can shorten them.
+var (
+	hookRetryDelay     = 2 * time.Second
+	hookAttemptTimeout = time.Minute
+	hookCursorInterval = 30 * time.Second
+)
+
+// hook runs a command or POSTs to a URL when a matching record appears.
+type hook struct {
+	Name        string   `json:"name"`
+	Collections []string `json:"collections,omitempty"` // NSIDs; hookCollections if empty
+	Subjects    []string `json:"subjects,omitempty"`    // AT-URIs or activity IDs in our repo; any of our records if empty
+	Exec        string   `json:"exec,omitempty"`        // shell command, payload on stdin
+	URL         string   `json:"url,omitempty"`         // endpoint for a JSON POST
+	Retries     int      `json:"retries"`
+}
+
+func (h hook) action() string {
+	if h.Exec != "" {
+		return "exec: " + h.Exec
+	}
+	return "POST " + h.URL
+}
+
+// hookPayload is what a hook receives: on stdin for exec, as the body for POST.
+type hookPayload struct {
+	Hook     string     `json:"hook"`
+	Event    watchEvent `json:"event"`
+	Subjects []string   `json:"subjects"` // our records (or DID) the new record references
+}
+
+func loadHooks() ([]hook, error) {
+	path, err := xdg.SearchConfigFile(hooksFile)
+	if err != nil {
+		return nil, nil // no hooks configured
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hooks: %w", err)
+	}
+	var hooks []hook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return hooks, nil
+}
+
+func saveHooks(hooks []hook) error {
+	path, err := xdg.ConfigFile(hooksFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(hooks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// loadHookCursor returns the Jetstream cursor saved by the last hook run, or 0.
+func loadHookCursor() int64 {
+	path, err := xdg.SearchStateFile(hooksCursorFile)
+	if err != nil {
+		return 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var state struct {
+		Cursor int64 `json:"cursor"`
+	}
+	if json.Unmarshal(data, &state) != nil {
+		return 0
+	}
+	return state.Cursor
+}
+
+func saveHookCursor(cursor int64) error {
+	path, err := xdg.StateFile(hooksCursorFile)
+	if err != nil {
+		return err
+	}
+	data, _ := json.Marshal(map[string]int64{"cursor": cursor})
+	return os.WriteFile(path, data, 0600)
+}
+
+// recordReferences returns the distinct strings in v that point into did's
+// repo (AT-URIs with that authority) or name did itself.
+func recordReferences(v any, did string) []string {
+	var refs []string
+	var walk func(any)
+	walk = func(v any) {
+		switch t := v.(type) {
+		case string:
+			if (t == did || strings.HasPrefix(t, "at://"+did+"/")) && !slices.Contains(refs, t) {
+				refs = append(refs, t)
+			}
+		case map[string]any:
+			for _, item := range t {
+				walk(item)
+			}
+		case []any:
+			for _, item := range t {
+				walk(item)
+			}
+		}
+	}
+	walk(v)
+	slices.Sort(refs)
+	return refs
+}
+
+// matches reports whether h fires for a record in collection that references refs.
+// subjects are the hook's subjects resolved to AT-URIs.
+func (h hook) matches(collection string, refs, subjects []string) bool {
+	collections := h.Collections
+	if len(collections) == 0 {
+		collections = hookCollections
+	}
+	if !slices.Contains(collections, collection) {
+		return false
+	}
+	if len(subjects) == 0 {
+		return true
+	}
+	for _, s := range subjects {
+		if slices.Contains(refs, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver runs the hook's action once.
+func (h hook) deliver(ctx context.Context, payload []byte, ev watchEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, hookAttemptTimeout)
+	defer cancel()
+
+	if h.Exec != "" {
+		c := exec.CommandContext(ctx, "sh", "-c", h.Exec)
+		c.Stdin = bytes.NewReader(payload)
+		c.Env = append(os.Environ(),
+			"HC_HOOK="+h.Name,
+			"HC_EVENT_URI="+ev.URI,
+			"HC_EVENT_DID="+ev.DID,
+			"HC_EVENT_COLLECTION="+ev.Collection,
+		)
+		out, err := c.CombinedOutput()
+		if err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				return fmt.Errorf("%w: %s", err, truncate(msg, 200))
+			}
+			return err
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hc/"+Version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", h.URL, resp.Status)
+	}
+	return nil
+}
+
+// fire delivers payload, retrying failures with exponential backoff.
+func (h hook) fire(ctx context.Context, payload []byte, ev watchEvent) error {
+	wait := hookRetryDelay
+	var err error
+	for attempt := 0; attempt <= h.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+		if err = h.deliver(ctx, payload, ev); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// hookRunner dispatches Jetstream events to hooks.
+type hookRunner struct {
+	w        io.Writer
+	did      string
+	hooks    []hook
+	subjects [][]string // per hook, resolved to AT-URIs
+}
+
+func newHookRunner(w io.Writer, did string, hooks []hook) *hookRunner {
+	r := &hookRunner{w: w, did: did, hooks: hooks}
+	for _, h := range hooks {
+		var subjects []string
+		for _, s := range h.Subjects {
+			subjects = append(subjects, resolveRecordURI(did, atproto.CollectionActivity, s))
+		}
+		r.subjects = append(r.subjects, subjects)
+	}
+	return r
+}
+
+// collections returns the union of the hooks' collections, for the subscription filter.
+func (r *hookRunner) collections() []string {
+	var out []string
+	for _, h := range r.hooks {
+		cs := h.Collections
+		if len(cs) == 0 {
+			cs = hookCollections
+		}
+		for _, c := range cs {
+			if !slices.Contains(out, c) {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// handle fires every hook matching e. Failed deliveries are reported, not
+// returned, so one broken hook does not stop the others. It reports whether
+// any hook matched.
+func (r *hookRunner) handle(ctx context.Context, e jetstream.Event) bool {
+	if e.Commit.Operation != jetstream.OpCreate || e.DID == r.did {
+		return false
+	}
+	refs := recordReferences(e.Commit.Record, r.did)
+	if len(refs) == 0 {
+		return false
+	}
+	ev := newWatchEvent(e)
+	matched := false
+	for i, h := range r.hooks {
+		if !h.matches(e.Commit.Collection, refs, r.subjects[i]) {
+			continue
+		}
+		matched = true
+		payload, _ := json.Marshal(hookPayload{Hook: h.Name, Event: ev, Subjects: refs})
+		if err := h.fire(ctx, payload, ev); err != nil {
+			if ctx.Err() != nil {
+				return matched
+			}
+			fmt.Fprintf(r.w, "Warning: hook %s failed for %s after %d attempt(s): %v\n", h.Name, ev.URI, h.Retries+1, err)
+			continue
+		}
+		fmt.Fprintf(r.w, "\033[32m✓\033[0m %s  %s → %s\n", time.Now().Format("15:04:05"), ev.URI, h.Name)
+	}
+	return matched
+}
+
+func runHookAdd(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	name := cmd.Args().First()
+	if name == "" {
+		return fmt.Errorf("usage: hc hook add <name> --exec <command> | --post <url>")
+	}
+	h := hook{Name: name, Exec: cmd.String("exec"), URL: cmd.String("post"), Retries: int(cmd.Int("retries"))}
+	if (h.Exec == "") == (h.URL == "") {
+		return fmt.Errorf("specify exactly one of --exec or --post")
+	}
+	if h.URL != "" && !strings.HasPrefix(h.URL, "http://") && !strings.HasPrefix(h.URL, "https://") {
+		return fmt.Errorf("--post must be an http(s) URL")
+	}
+	if h.Retries < 0 {
+		return fmt.Errorf("--retries must not be negative")
+	}
+	if s := cmd.String("collection"); s != "" {
+		collections, err := parseWatchCollections(s)
+		if err != nil {
+			return err
+		}
+		for _, c := range collections {
+			if strings.HasSuffix(c, "*") {
+				return fmt.Errorf("hook collections must be exact NSIDs or names, not %s", c)
+			}
+		}
+		h.Collections = collections
+	}
+	for _, s := range strings.Split(cmd.String("subject"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			h.Subjects = append(h.Subjects, s)
+		}
+	}
+
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(hooks, func(x hook) bool { return x.Name == name }) {
+		return fmt.Errorf("hook %q already exists (remove it first: hc hook rm %s)", name, name)
+	}
+	if err := saveHooks(append(hooks, h)); err != nil {
+		return fmt.Errorf("failed to save hooks: %w", err)
+	}
+	fmt.Fprintf(w, "\033[32m✓\033[0m Added hook %s (%s)\n", name, h.action())
+	return nil
+}
+
+func runHookList(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	if cmd.Bool("json") {
+		if hooks == nil {
+			hooks = []hook{}
+		}
+		fmt.Fprintln(w, prettyJSON(hooks))
+		return nil
+	}
+
+	fmt.Fprintf(w, "\033[1m%-20s %-36s %-30s %s\033[0m\n", "NAME", "COLLECTIONS", "SUBJECTS", "ACTION")
+	fmt.Fprintf(w, "%-20s %-36s %-30s %s\n",
+		strings.Repeat("-", 18), strings.Repeat("-", 34), strings.Repeat("-", 28), strings.Repeat("-", 20))
+	for _, h := range hooks {
+		collections := "(default)"
+		if len(h.Collections) > 0 {
+			collections = truncate(strings.Join(h.Collections, ","), 34)
+		}
+		subjects := "(any of yours)"
+		if len(h.Subjects) > 0 {
+			subjects = truncate(strings.Join(h.Subjects, ","), 28)
+		}
+		fmt.Fprintf(w, "%-20s %-36s %-30s %s\n", h.Name, collections, subjects, h.action())
+	}
+	if len(hooks) == 0 {
+		fmt.Fprintln(w, "\033[90m(no hooks found)\033[0m")
+	}
+	return nil
+}
+
+func runHookRemove(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	name := cmd.Args().First()
+	if name == "" {
+		return fmt.Errorf("usage: hc hook rm <name>")
+	}
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(hooks, func(h hook) bool { return h.Name == name })
+	if i < 0 {
+		return fmt.Errorf("hook not found: %s", name)
+	}
+	if err := saveHooks(slices.Delete(hooks, i, i+1)); err != nil {
+		return fmt.Errorf("failed to save hooks: %w", err)
+	}
+	fmt.Fprintf(w, "Removed hook: %s\n", name)
+	return nil
+}
+
+func runHookRun(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	hooks, err := loadHooks()
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return errors.New("no hooks configured (run: hc hook add)")
+	}
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	r := newHookRunner(w, client.AccountDID.String(), hooks)
+
+	js := &jetstream.Client{URL: cmd.String("url"), Collections: r.collections(), Cursor: loadHookCursor()}
+	if d := cmd.Duration("since"); d > 0 {
+		js.Cursor = time.Now().Add(-d).UnixMicro()
+	}
+	js.OnReconnect = func(err error, wait time.Duration) {
+		// stderr, so reconnects never interleave with the event stream.
+		fmt.Fprintf(os.Stderr, "Warning: %v; reconnecting in %s\n", err, wait)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if js.Cursor > 0 {
+		fmt.Fprintf(w, "Running %d hook(s) for %s from %s (Ctrl-C to stop)\n", len(hooks), r.did, time.UnixMicro(js.Cursor).Format(time.RFC3339))
+	} else {
+		fmt.Fprintf(w, "Running %d hook(s) for %s (Ctrl-C to stop)\n", len(hooks), r.did)
+	}
+	lastSave := time.Now()
+	return js.Subscribe(ctx, func(e jetstream.Event) error {
+		// Save after every fired event, and periodically otherwise so a
+		// restart does not replay a long quiet stretch.
+		if r.handle(ctx, e) || time.Since(lastSave) > hookCursorInterval {
+			if ctx.Err() != nil {
+				return ctx.Err() // a delivery was interrupted; replay this event next run
+			}
+			if err := saveHookCursor(e.TimeUS); err != nil {
+				fmt.Fprintf(w, "Warning: failed to save cursor: %v\n", err)
+			}
+			lastSave = time.Now()
+		}
+		return nil
+	})
+}
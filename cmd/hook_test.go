@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/jetstream"
+)
+
+const hookTestDID = "did:plc:me"
+
+func TestRecordReferences(t *testing.T) {
+	record := map[string]any{
+		"subject":   map[string]any{"uri": "at://did:plc:me/org.hypercerts.claim.activity/a1", "cid": "bafy"},
+		"evaluator": []any{"did:plc:me", "did:plc:other"},
+		"related":   []any{map[string]any{"uri": "at://did:plc:other/org.hypercerts.claim.activity/x"}},
+		"note":      "see at://did:plc:meow/foo/bar",
+	}
+	got := recordReferences(record, hookTestDID)
+	want := []string{"at://did:plc:me/org.hypercerts.claim.activity/a1", "did:plc:me"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("recordReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestHookMatches(t *testing.T) {
+	refs := []string{"at://did:plc:me/org.hypercerts.claim.activity/a1"}
+	tests := []struct {
+		name       string
+		hook       hook
+		collection string
+		want       bool
+	}{
+		{"default collections", hook{}, atproto.CollectionEvaluation, true},
+		{"not a default collection", hook{}, atproto.CollectionMeasurement, false},
+		{"explicit collection", hook{Collections: []string{atproto.CollectionMeasurement}}, atproto.CollectionMeasurement, true},
+		{"collection filtered out", hook{Collections: []string{atproto.CollectionBadgeAward}}, atproto.CollectionEvaluation, false},
+		{"subject by activity ID", hook{Subjects: []string{"a1"}}, atproto.CollectionEvaluation, true},
+		{"other subject", hook{Subjects: []string{"a2"}}, atproto.CollectionEvaluation, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newHookRunner(io.Discard, hookTestDID, []hook{tt.hook})
+			if got := tt.hook.matches(tt.collection, refs, r.subjects[0]); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHookRunnerHandle(t *testing.T) {
+	hookRetryDelay = time.Millisecond
+
+	var mu sync.Mutex
+	var posts []hookPayload
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		var p hookPayload
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		posts = append(posts, p)
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(t.TempDir(), "events")
+	var log bytes.Buffer
+	r := newHookRunner(&log, hookTestDID, []hook{
+		{Name: "post", URL: srv.URL, Retries: 1},
+		{Name: "exec", Exec: `cat >> "` + out + `"; echo >> "` + out + `"`, Subjects: []string{"a2"}},
+		{Name: "broken", Exec: "echo nope >&2; exit 3", Collections: []string{atproto.CollectionBadgeAward}},
+	})
+
+	event := func(did, coll, op string, record map[string]any) jetstream.Event {
+		return jetstream.Event{DID: did, TimeUS: 1, Kind: "commit", Commit: &jetstream.Commit{Operation: op, Collection: coll, RKey: "r1", Record: record}}
+	}
+	refTo := func(rkey string) map[string]any {
+		return map[string]any{"subject": map[string]any{"uri": "at://did:plc:me/org.hypercerts.claim.activity/" + rkey}}
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		event jetstream.Event
+		want  bool
+	}{
+		{"evaluation of a1", event("did:plc:eve", atproto.CollectionEvaluation, "create", refTo("a1")), true},
+		{"evaluation of a2", event("did:plc:eve", atproto.CollectionEvaluation, "create", refTo("a2")), true},
+		{"own record", event(hookTestDID, atproto.CollectionEvaluation, "create", refTo("a1")), false},
+		{"update", event("did:plc:eve", atproto.CollectionEvaluation, "update", refTo("a1")), false},
+		{"unrelated", event("did:plc:eve", atproto.CollectionEvaluation, "create", map[string]any{"subject": "at://did:plc:x/c/r"}), false},
+		{"badge award", event("did:plc:eve", atproto.CollectionBadgeAward, "create", map[string]any{"subject": map[string]any{"did": hookTestDID}}), true},
+	}
+	for _, tt := range tests {
+		if got := r.handle(ctx, tt.event); got != tt.want {
+			t.Errorf("%s: handle() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// post fired for both evaluations (the first after one retry) and the badge award.
+	if len(posts) != 3 || calls != 4 {
+		t.Errorf("POST deliveries = %d in %d calls, want 3 in 4", len(posts), calls)
+	}
+	if len(posts) > 0 && (posts[0].Hook != "post" || posts[0].Event.URI != "at://did:plc:eve/org.hypercerts.context.evaluation/r1" || len(posts[0].Subjects) != 1) {
+		t.Errorf("payload = %+v", posts[0])
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "/a2") {
+		t.Errorf("exec hook received %q, want only the a2 evaluation", data)
+	}
+	if !strings.Contains(log.String(), "hook broken failed") || !strings.Contains(log.String(), "nope") {
+		t.Errorf("log = %q, want the broken hook's failure", log.String())
+	}
+}
+
+func TestHookCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	defer xdg.Reload()
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		err := BuildApp(&buf).Run(context.Background(), append([]string{"hc", "hook"}, args...))
+		return buf.String(), err
+	}
+
+	if _, err := run("add", "slack", "--post", "https://hooks.example.com/x", "--collection", "evaluations,badge-awards", "--subject", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("add", "slack", "--exec", "true"); err == nil {
+		t.Error("duplicate name should fail")
+	}
+	if _, err := run("add", "both", "--exec", "true", "--post", "https://example.com"); err == nil {
+		t.Error("--exec with --post should fail")
+	}
+	if _, err := run("add", "star", "--exec", "true", "--collection", "org.hypercerts.*"); err == nil {
+		t.Error("wildcard collection should fail")
+	}
+
+	hooks, err := loadHooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || hooks[0].Retries != 3 || strings.Join(hooks[0].Collections, ",") != atproto.CollectionEvaluation+","+atproto.CollectionBadgeAward {
+		t.Errorf("hooks = %+v", hooks)
+	}
+	if out, _ := run("ls"); !strings.Contains(out, "POST https://hooks.example.com/x") {
+		t.Errorf("ls output = %q", out)
+	}
+	if _, err := run("rm", "slack"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := run("ls"); !strings.Contains(out, "no hooks found") {
+		t.Errorf("ls after rm = %q", out)
+	}
+
+	if loadHookCursor() != 0 {
+		t.Error("cursor should start empty")
+	}
+	if err := saveHookCursor(12345); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadHookCursor(); got != 12345 {
+		t.Errorf("loadHookCursor() = %d, want 12345", got)
+	}
+}
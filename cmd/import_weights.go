@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/gitea"
+	"github.com/GainForest/hypercerts-cli/internal/github"
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// Weighting strategies for --weight-by.
+const (
+	weightByCommits   = "commits"
+	weightByAdditions = "additions"
+	weightByPRs       = "prs"
+	weightByEqual     = "equal"
+	weightByCustom    = "custom"
+)
+
+// importWeightOptions controls how imported contributors are weighted and filtered.
+type importWeightOptions struct {
+	By          string
+	Custom      map[string]float64 // login or identifier (lowercased) -> weight, for "custom"
+	Exclude     map[string]bool    // lowercased logins or identifiers
+	IncludeBots bool
+	Min         float64
+}
+
+// weightedContributor is a contributor that survived filtering, with its weight.
+type weightedContributor struct {
+	source.Contributor
+	Weight float64
+}
+
+// importWeightReport lists kept contributors and why the others were dropped.
+type importWeightReport struct {
+	Kept     []weightedContributor
+	Bots     []string
+	Excluded []string
+	Unlisted []string // not in the custom weights file
+	BelowMin []string
+}
+
+func importWeightOptionsFromFlags(cmd *cli.Command) (importWeightOptions, error) {
+	opts := importWeightOptions{
+		By:          strings.ToLower(cmd.String("weight-by")),
+		Exclude:     map[string]bool{},
+		IncludeBots: cmd.Bool("include-bots"),
+		Min:         cmd.Float("min-weight"),
+	}
+	switch opts.By {
+	case weightByCommits, weightByAdditions, weightByPRs, weightByEqual:
+		if cmd.String("weights") != "" {
+			return opts, fmt.Errorf("--weights requires --weight-by custom")
+		}
+	case weightByCustom:
+		path := cmd.String("weights")
+		if path == "" {
+			return opts, fmt.Errorf("--weight-by custom requires --weights <file>")
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return opts, fmt.Errorf("failed to open weights file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		if opts.Custom, err = parseCustomWeights(f); err != nil {
+			return opts, err
+		}
+	default:
+		return opts, fmt.Errorf("invalid --weight-by %q: use commits, additions, prs, equal, or custom", opts.By)
+	}
+	for s := range strings.SplitSeq(cmd.String("exclude"), ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			opts.Exclude[s] = true
+		}
+	}
+	if opts.Min < 0 {
+		return opts, fmt.Errorf("--min-weight must not be negative")
+	}
+	return opts, nil
+}
+
+// parseCustomWeights reads "login,weight" lines (CSV, # comments allowed).
+// The first column may also be a contributor identifier such as a profile URL.
+func parseCustomWeights(r io.Reader) (map[string]float64, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	weights := map[string]float64{}
+	for i := 0; ; i++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read weights file: %w", err)
+		}
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(row[0]))
+		if i == 0 && (key == "login" || key == "contributor") {
+			continue
+		}
+		if len(row) < 2 {
+			return nil, fmt.Errorf("weights file: no weight for %s", key)
+		}
+		v, err := parseWeight(row[1])
+		if err != nil {
+			return nil, fmt.Errorf("weights file: %s: %w", key, err)
+		}
+		weights[key] = v
+	}
+	return weights, nil
+}
+
+func isBotContributor(c source.Contributor) bool {
+	return c.Bot || strings.HasSuffix(strings.ToLower(c.Login), "[bot]")
+}
+
+// fetchWeightValues returns the raw metric for each contributor under the
+// chosen strategy. Additions and PRs need extra API calls and host support.
+func fetchWeightValues(ctx context.Context, imp source.Importer, path, by string, contributors []source.Contributor) ([]float64, error) {
+	values := make([]float64, len(contributors))
+	switch by {
+	case weightByCommits:
+		for i, c := range contributors {
+			values[i] = float64(c.Contributions)
+		}
+	case weightByEqual, weightByCustom:
+		for i := range values {
+			values[i] = 1
+		}
+	case weightByAdditions:
+		if gh, ok := imp.(*github.Client); ok {
+			additions, err := gh.FetchAdditions(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch contributor statistics: %w", err)
+			}
+			for i, c := range contributors {
+				values[i] = float64(additions[c.Login])
+			}
+			break
+		}
+		if _, ok := imp.(*gitea.Client); ok {
+			return nil, fmt.Errorf("--weight-by additions is not supported for %s", imp.Name())
+		}
+		for i, c := range contributors {
+			values[i] = float64(c.Additions)
+		}
+	case weightByPRs:
+		gh, ok := imp.(*github.Client)
+		if !ok {
+			return nil, fmt.Errorf("--weight-by prs is only supported for GitHub")
+		}
+		prs, err := gh.FetchMergedPRCounts(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+		}
+		for i, c := range contributors {
+			values[i] = float64(prs[c.Login])
+		}
+	}
+	return values, nil
+}
+
+// weighContributors applies the bot filter, excludes, custom weights, and the
+// minimum threshold, in that order. values[i] is contributor i's raw metric.
+func weighContributors(contributors []source.Contributor, values []float64, opts importWeightOptions) importWeightReport {
+	var report importWeightReport
+	for i, c := range contributors {
+		// Emails are only matched against the user's own lists, never stored.
+		login, id, email := strings.ToLower(c.Login), strings.ToLower(c.Identifier()), strings.ToLower(c.EmailIdentifier())
+		switch {
+		case !opts.IncludeBots && isBotContributor(c):
+			report.Bots = append(report.Bots, c.Login)
+			continue
+		case opts.Exclude[login] || opts.Exclude[id] || opts.Exclude[email]:
+			report.Excluded = append(report.Excluded, c.Login)
+			continue
+		}
+
+		weight := values[i]
+		if opts.By == weightByCustom {
+			v, ok := opts.Custom[login]
+			if !ok {
+				v, ok = opts.Custom[id]
+			}
+			if !ok {
+				v, ok = opts.Custom[email]
+			}
+			if !ok {
+				report.Unlisted = append(report.Unlisted, c.Login)
+				continue
+			}
+			weight = v
+		}
+		if opts.Min > 0 && weight < opts.Min {
+			report.BelowMin = append(report.BelowMin, c.Login)
+			continue
+		}
+		report.Kept = append(report.Kept, weightedContributor{Contributor: c, Weight: weight})
+	}
+	return report
+}
+
+func formatImportWeight(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// printImportWeightReport summarizes the weighting for the import output.
+func printImportWeightReport(w io.Writer, opts importWeightOptions, report importWeightReport) {
+	fmt.Fprintf(w, "  Weighting: %s (%d contributor(s) kept)\n", opts.By, len(report.Kept))
+	dropped := []struct {
+		label string
+		names []string
+	}{
+		{"bots", report.Bots},
+		{"excluded", report.Excluded},
+		{"not in weights file", report.Unlisted},
+		{fmt.Sprintf("below minimum %s", formatImportWeight(opts.Min)), report.BelowMin},
+	}
+	for _, d := range dropped {
+		if len(d.names) > 0 {
+			fmt.Fprintf(w, "    skipped %d %s: %s\n", len(d.names), d.label, strings.Join(d.names, ", "))
+		}
+	}
+}
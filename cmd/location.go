@@ -401,7 +401,7 @@ func runLocationEdit(ctx context.Context, cmd *cli.Command) error {
 	existing["name"] = newName
 	existing["description"] = newDesc
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update location: %w", err)
 	}
@@ -479,13 +479,12 @@ func runLocationDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runLocationList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionLocation)
 	if err != nil {
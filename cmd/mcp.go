@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/mcp"
+)
+
+// mcpSearchLimit caps the matches returned by the search tool.
+const mcpSearchLimit = 50
+
+// mcpTools implements the hc MCP tools against one authenticated session.
+type mcpTools struct {
+	cmd    *cli.Command
+	client *atclient.APIClient
+}
+
+// stringArgs reads the named string arguments, rejecting non-string values.
+func stringArgs(args map[string]any, names ...string) (map[string]string, error) {
+	out := make(map[string]string, len(names))
+	for _, n := range names {
+		s, err := mcp.StringArg(args, n)
+		if err != nil {
+			return nil, err
+		}
+		out[n] = strings.TrimSpace(s)
+	}
+	return out, nil
+}
+
+// repoClient returns a client and DID for repo (handle or DID), or the
+// session's account when repo is empty.
+func (t *mcpTools) repoClient(ctx context.Context, repo string) (*atclient.APIClient, string, error) {
+	if repo == "" || repo == t.client.AccountDID.String() {
+		return t.client, t.client.AccountDID.String(), nil
+	}
+	ident, err := resolveIdent(ctx, t.cmd, repo)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve repo %s: %w", repo, err)
+	}
+	client, err := publicClient(ident)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, ident.DID.String(), nil
+}
+
+// activityRef resolves an activity ID or AT-URI in the session's repo to a strongRef.
+func (t *mcpTools) activityRef(ctx context.Context, id string) (map[string]any, error) {
+	did := t.client.AccountDID.String()
+	uri := resolveRecordURI(did, atproto.CollectionActivity, id)
+	aturi, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid activity URI: %w", err)
+	}
+	if aturi.Authority().String() != did || aturi.Collection().String() != atproto.CollectionActivity {
+		return nil, fmt.Errorf("%s is not an activity in your repo", id)
+	}
+	_, cid, err := atproto.GetRecord(ctx, t.client, did, atproto.CollectionActivity, aturi.RecordKey().String())
+	if err != nil {
+		return nil, fmt.Errorf("activity not found: %s", id)
+	}
+	return buildStrongRef(uri, cid), nil
+}
+
+func (t *mcpTools) listActivities(ctx context.Context, args map[string]any) (any, error) {
+	a, err := stringArgs(args, "repo")
+	if err != nil {
+		return nil, err
+	}
+	client, did, err := t.repoClient(ctx, a["repo"])
+	if err != nil {
+		return nil, err
+	}
+	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionActivity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activities: %w", err)
+	}
+	activities := []any{}
+	for _, e := range entries {
+		activities = append(activities, map[string]any{
+			"id":               extractRkey(e.URI),
+			"uri":              e.URI,
+			"title":            mapStr(e.Value, "title"),
+			"shortDescription": mapStr(e.Value, "shortDescription"),
+			"startDate":        mapStr(e.Value, "startDate"),
+			"endDate":          mapStr(e.Value, "endDate"),
+			"contributors":     len(mapSlice(e.Value, "contributors")),
+		})
+	}
+	return map[string]any{"repo": did, "activities": activities}, nil
+}
+
+func (t *mcpTools) getActivity(ctx context.Context, args map[string]any) (any, error) {
+	a, err := stringArgs(args, "activity", "repo")
+	if err != nil {
+		return nil, err
+	}
+	repo := a["repo"]
+	if repo == "" && strings.HasPrefix(a["activity"], "at://") {
+		aturi, err := syntax.ParseATURI(a["activity"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid activity URI: %w", err)
+		}
+		repo = aturi.Authority().String()
+	}
+	client, did, err := t.repoClient(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	uri := resolveRecordURI(did, atproto.CollectionActivity, a["activity"])
+	value, cid, err := atproto.GetRecord(ctx, client, did, atproto.CollectionActivity, extractRkey(uri))
+	if err != nil {
+		return nil, fmt.Errorf("activity not found: %s", a["activity"])
+	}
+
+	result := map[string]any{"uri": uri, "cid": cid, "activity": value}
+	measurements, err := fetchMeasurementsForActivity(ctx, client, did, uri)
+	if err == nil {
+		var ms []any
+		for _, m := range measurements {
+			ms = append(ms, map[string]any{"uri": m.URI, "metric": m.Metric, "value": m.Value, "unit": m.Unit})
+		}
+		result["measurements"] = ms
+	}
+	return result, nil
+}
+
+func (t *mcpTools) createMeasurement(ctx context.Context, args map[string]any) (any, error) {
+	a, err := stringArgs(args, "activity", "metric", "unit", "value", "startDate", "endDate", "methodType", "evidenceURI")
+	if err != nil {
+		return nil, err
+	}
+	subject, err := t.activityRef(ctx, a["activity"])
+	if err != nil {
+		return nil, err
+	}
+	var notes bytes.Buffer
+	unit, value, err := normalizeMeasurement(&notes, a["unit"], a["value"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", a["value"], err)
+	}
+
+	record := map[string]any{
+		"$type":     atproto.CollectionMeasurement,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+		"subjects":  []any{subject},
+		"metric":    a["metric"],
+		"unit":      unit,
+		"value":     value,
+	}
+	if s := a["startDate"]; s != "" {
+		record["startDate"] = normalizeDate(s)
+	}
+	if s := a["endDate"]; s != "" {
+		record["endDate"] = normalizeDate(s)
+	}
+	if s := a["methodType"]; s != "" {
+		record["methodType"] = s
+	}
+	if s := a["evidenceURI"]; s != "" {
+		record["evidenceURI"] = []any{s}
+	}
+
+	uri, cid, err := atproto.CreateRecord(ctx, t.client, atproto.CollectionMeasurement, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create measurement: %w", err)
+	}
+	result := map[string]any{"uri": uri, "cid": cid, "unit": unit, "value": value}
+	if n := strings.TrimSpace(notes.String()); n != "" {
+		result["warning"] = n
+	}
+	return result, nil
+}
+
+func (t *mcpTools) attachEvidence(ctx context.Context, args map[string]any) (any, error) {
+	a, err := stringArgs(args, "activity", "title", "uri", "description", "contentType")
+	if err != nil {
+		return nil, err
+	}
+	subject, err := t.activityRef(ctx, a["activity"])
+	if err != nil {
+		return nil, err
+	}
+	record := map[string]any{
+		"$type":     atproto.CollectionAttachment,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+		"title":     truncate(a["title"], 256),
+		"subjects":  []any{subject},
+		"content": []any{map[string]any{
+			"$type": "org.hypercerts.defs#uri",
+			"uri":   a["uri"],
+		}},
+	}
+	if s := a["description"]; s != "" {
+		record["shortDescription"] = truncate(s, 300)
+		record["description"] = s
+	}
+	if s := a["contentType"]; s != "" {
+		record["contentType"] = s
+	}
+
+	uri, cid, err := atproto.CreateRecord(ctx, t.client, atproto.CollectionAttachment, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return map[string]any{"uri": uri, "cid": cid}, nil
+}
+
+// recordMatches reports whether any string in v contains query (lowercase).
+func recordMatches(v any, query string) bool {
+	switch t := v.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(t), query)
+	case map[string]any:
+		for k, item := range t {
+			if k != "$type" && recordMatches(item, query) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if recordMatches(item, query) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordLabel picks a human-readable label for a record of any type.
+func recordLabel(value map[string]any) string {
+	for _, k := range []string{"title", "displayName", "name", "metric", "rightsName", "key", "identifier"} {
+		if s := mapStr(value, k); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func (t *mcpTools) search(ctx context.Context, args map[string]any) (any, error) {
+	a, err := stringArgs(args, "query", "collection", "repo")
+	if err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(a["query"])
+	collections := atproto.HypercertsCollections
+	if c := a["collection"]; c != "" {
+		if res, ok := findServeResource(c); ok {
+			c = res.Collection
+		}
+		if !strings.Contains(c, ".") {
+			return nil, fmt.Errorf("unknown collection %q", a["collection"])
+		}
+		collections = []string{c}
+	}
+	client, did, err := t.repoClient(ctx, a["repo"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect every match before truncating, so the newest ones are kept
+	// whichever collection they are in.
+	matches := []map[string]any{}
+	for _, coll := range collections {
+		entries, err := atproto.ListAllRecords(ctx, client, did, coll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", coll, err)
+		}
+		for _, e := range entries {
+			if !recordMatches(e.Value, query) {
+				continue
+			}
+			matches = append(matches, map[string]any{
+				"uri":        e.URI,
+				"collection": coll,
+				"label":      recordLabel(e.Value),
+				"createdAt":  mapStr(e.Value, "createdAt"),
+			})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return mapStr(matches[i], "createdAt") > mapStr(matches[j], "createdAt")
+	})
+	truncated := len(matches) > mcpSearchLimit
+	if truncated {
+		matches = matches[:mcpSearchLimit]
+	}
+	return map[string]any{"matches": matches, "truncated": truncated}, nil
+}
+
+// tools returns the tool definitions with their argument schemas.
+func (t *mcpTools) tools() []mcp.Tool {
+	str := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "description": desc}
+	}
+	schema := func(props map[string]any, required ...string) map[string]any {
+		s := map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	}
+	repo := str("handle or DID of another account's repo (default: your own)")
+	activity := str("activity record key or AT-URI in your repo")
+
+	return []mcp.Tool{
+		{
+			Name:        "list_activities",
+			Description: "List hypercert activities with their titles and dates.",
+			InputSchema: schema(map[string]any{"repo": repo}),
+			Handler:     t.listActivities,
+		},
+		{
+			Name:        "get_activity",
+			Description: "Get an activity record, including its contributors, and the measurements recorded for it.",
+			InputSchema: schema(map[string]any{
+				"activity": str("activity record key or AT-URI"),
+				"repo":     repo,
+			}, "activity"),
+			Handler: t.getActivity,
+		},
+		{
+			Name:        "create_measurement",
+			Description: "Record a quantitative measurement for one of your activities. Units are normalized against the bundled unit registry.",
+			InputSchema: schema(map[string]any{
+				"activity":    activity,
+				"metric":      str("what is measured, e.g. 'trees planted'"),
+				"unit":        str("unit, e.g. 'trees', 'ha', 'tCO2e'"),
+				"value":       str("numeric value, e.g. '1500' or '12.5'"),
+				"startDate":   str("start of the measured period (YYYY-MM-DD or RFC3339)"),
+				"endDate":     str("end of the measured period (YYYY-MM-DD or RFC3339)"),
+				"methodType":  str("measurement method, e.g. 'field survey'"),
+				"evidenceURI": str("URL of supporting evidence"),
+			}, "activity", "metric", "unit", "value"),
+			Handler: t.createMeasurement,
+		},
+		{
+			Name:        "attach_evidence",
+			Description: "Attach an evidence document (report, dataset, photo) by URL to one of your activities.",
+			InputSchema: schema(map[string]any{
+				"activity":    activity,
+				"title":       str("attachment title"),
+				"uri":         str("URL of the evidence"),
+				"description": str("what the evidence shows"),
+				"contentType": str("kind of content, e.g. 'report', 'dataset'"),
+			}, "activity", "title", "uri"),
+			Handler: t.attachEvidence,
+		},
+		{
+			Name:        "search",
+			Description: "Search Hypercerts records by text in any field. Returns up to 50 matches, newest first.",
+			InputSchema: schema(map[string]any{
+				"query":      str("text to look for (case-insensitive)"),
+				"collection": str("limit to one record type: a collection NSID or a name such as 'activities' or 'measurements'"),
+				"repo":       repo,
+			}, "query"),
+			Handler: t.search,
+		},
+	}
+}
+
+func runMCP(ctx context.Context, cmd *cli.Command) error {
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	t := &mcpTools{cmd: cmd, client: client}
+	s := &mcp.Server{Name: "hc", Version: Version, Tools: t.tools()}
+	// stdout carries the protocol; diagnostics go to stderr.
+	fmt.Fprintf(os.Stderr, "hc mcp: serving %s over stdio\n", client.AccountDID)
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
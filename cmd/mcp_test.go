@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// newTestMCPTools returns tools whose session points at a fake PDS holding
+// one activity. Created records are captured in created.
+func newTestMCPTools(t *testing.T, created *[]map[string]any) *mcpTools {
+	t.Helper()
+	activity := map[string]any{"$type": atproto.CollectionActivity, "title": "Reforestation", "shortDescription": "Planting trees in Borneo"}
+	pds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xrpc/com.atproto.repo.listRecords":
+			records := []any{}
+			if r.URL.Query().Get("collection") == atproto.CollectionActivity {
+				records = append(records, map[string]any{
+					"uri":   "at://did:plc:test/org.hypercerts.claim.activity/a1",
+					"cid":   "bafya1",
+					"value": activity,
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"records": records})
+		case "/xrpc/com.atproto.repo.getRecord":
+			if r.URL.Query().Get("rkey") != "a1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "RecordNotFound", "message": "not found"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"uri":   "at://did:plc:test/org.hypercerts.claim.activity/a1",
+				"cid":   "bafya1",
+				"value": activity,
+			})
+		case "/xrpc/com.atproto.repo.createRecord":
+			var in struct {
+				Record map[string]any `json:"record"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			*created = append(*created, in.Record)
+			_ = json.NewEncoder(w).Encode(map[string]any{"uri": "at://did:plc:test/org.hypercerts.context.measurement/m1", "cid": "bafym1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(pds.Close)
+
+	client := atclient.NewAPIClient(pds.URL)
+	did := syntax.DID("did:plc:test")
+	client.AccountDID = &did
+	return &mcpTools{cmd: BuildApp(io.Discard), client: client}
+}
+
+func TestMCPTools(t *testing.T) {
+	var created []map[string]any
+	tools := newTestMCPTools(t, &created)
+	handlers := map[string]func(context.Context, map[string]any) (any, error){}
+	for _, tool := range tools.tools() {
+		handlers[tool.Name] = tool.Handler
+	}
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    map[string]any
+		want    string
+		wantErr string
+	}{
+		{"list", "list_activities", map[string]any{}, `"title":"Reforestation"`, ""},
+		{"search hit", "search", map[string]any{"query": "borneo"}, `"label":"Reforestation"`, ""},
+		{"search by resource name", "search", map[string]any{"query": "borneo", "collection": "measurements"}, `"matches":[]`, ""},
+		{"search unknown collection", "search", map[string]any{"query": "x", "collection": "widgets"}, "", "unknown collection"},
+		{"measurement", "create_measurement", map[string]any{"activity": "a1", "metric": "trees planted", "unit": "trees", "value": "1,500"}, `"cid":"bafym1"`, ""},
+		{"measurement bad value", "create_measurement", map[string]any{"activity": "a1", "metric": "m", "unit": "trees", "value": "lots"}, "", "invalid value"},
+		{"measurement missing activity", "create_measurement", map[string]any{"activity": "zz", "metric": "m", "unit": "trees", "value": "1"}, "", "activity not found"},
+		{"measurement foreign activity", "create_measurement", map[string]any{"activity": "at://did:plc:other/org.hypercerts.claim.activity/a1", "metric": "m", "unit": "trees", "value": "1"}, "", "not an activity in your repo"},
+		{"evidence", "attach_evidence", map[string]any{"activity": "a1", "title": "Survey", "uri": "https://example.com/survey.pdf"}, `"uri":"at://`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handlers[tt.tool](context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, _ := json.Marshal(got)
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("result = %s, want it to contain %s", data, tt.want)
+			}
+		})
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("created %d records, want 2", len(created))
+	}
+	m := created[0]
+	subjects := mapSlice(m, "subjects")
+	if m["$type"] != atproto.CollectionMeasurement || m["value"] != "1500" || len(subjects) != 1 {
+		t.Errorf("measurement = %v", m)
+	} else if ref, _ := subjects[0].(map[string]any); ref["cid"] != "bafya1" {
+		t.Errorf("measurement subject = %v, want strongRef to a1", ref)
+	}
+	if a := created[1]; a["$type"] != atproto.CollectionAttachment || len(mapSlice(a, "content")) != 1 {
+		t.Errorf("attachment = %v", a)
+	}
+}
+
+func TestMCPSearchKeepsNewestMatches(t *testing.T) {
+	pds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records := []any{}
+		switch r.URL.Query().Get("collection") {
+		case atproto.CollectionActivity:
+			for i := range mcpSearchLimit {
+				records = append(records, map[string]any{
+					"uri":   fmt.Sprintf("at://did:plc:test/%s/a%d", atproto.CollectionActivity, i),
+					"cid":   "bafya1",
+					"value": map[string]any{"title": "Tree planting", "createdAt": fmt.Sprintf("2024-01-%02dT00:00:00Z", i%28+1)},
+				})
+			}
+		case atproto.CollectionMeasurement:
+			records = append(records, map[string]any{
+				"uri":   "at://did:plc:test/" + atproto.CollectionMeasurement + "/m1",
+				"cid":   "bafym1",
+				"value": map[string]any{"metric": "trees planted", "createdAt": "2026-01-01T00:00:00Z"},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"records": records})
+	}))
+	defer pds.Close()
+	client := atclient.NewAPIClient(pds.URL)
+	did := syntax.DID("did:plc:test")
+	client.AccountDID = &did
+	tools := &mcpTools{cmd: BuildApp(io.Discard), client: client}
+
+	got, err := tools.search(context.Background(), map[string]any{"query": "tree"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := got.(map[string]any)
+	matches := result["matches"].([]map[string]any)
+	if len(matches) != mcpSearchLimit || result["truncated"] != true {
+		t.Fatalf("got %d matches, truncated %v; want %d, true", len(matches), result["truncated"], mcpSearchLimit)
+	}
+	if matches[0]["collection"] != atproto.CollectionMeasurement {
+		t.Errorf("first match = %v, want the newest record (the measurement)", matches[0])
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/GainForest/hypercerts-cli/internal/atproto"
 	"github.com/GainForest/hypercerts-cli/internal/menu"
 	"github.com/GainForest/hypercerts-cli/internal/style"
+	"github.com/GainForest/hypercerts-cli/internal/units"
 )
 
 type measurementOption struct {
@@ -41,12 +42,8 @@ func fetchMeasurements(ctx context.Context, client *atclient.APIClient, did stri
 		if err != nil {
 			continue
 		}
-		subjectURI := ""
-		subjectRkey := ""
-		if subject := mapMap(e.Value, "subject"); subject != nil {
-			subjectURI = mapStr(subject, "uri")
-			subjectRkey = extractRkey(subjectURI)
-		}
+		subjectURI := measurementSubjectURI(e.Value)
+		subjectRkey := extractRkey(subjectURI)
 		created := ""
 		if createdAt := mapStr(e.Value, "createdAt"); createdAt != "" {
 			if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
@@ -67,6 +64,39 @@ func fetchMeasurements(ctx context.Context, client *atclient.APIClient, did stri
 	return result, nil
 }
 
+// measurementSubjectURI returns the first subject of a measurement, reading
+// subjects[] (current schema) before subject (old schema).
+func measurementSubjectURI(record map[string]any) string {
+	for _, s := range mapSlice(record, "subjects") {
+		if m, ok := s.(map[string]any); ok {
+			return mapStr(m, "uri")
+		}
+	}
+	if subject := mapMap(record, "subject"); subject != nil {
+		return mapStr(subject, "uri")
+	}
+	return ""
+}
+
+// normalizeMeasurement validates a numeric value and maps the unit to its
+// registry symbol. Unknown units are kept as entered, with a warning.
+func normalizeMeasurement(w io.Writer, unit, value string) (string, string, error) {
+	v, err := units.ParseValue(value)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := units.Lookup(unit); !ok {
+		fmt.Fprintf(w, "Warning: unit %q is not in the units registry; it cannot be converted or aggregated\n", strings.TrimSpace(unit))
+	}
+	return units.Normalize(unit), units.FormatValue(v), nil
+}
+
+// validateValue is a huh validator for numeric measurement values.
+func validateValue(s string) error {
+	_, err := units.ParseValue(s)
+	return err
+}
+
 func fetchMeasurementsForActivity(ctx context.Context, client *atclient.APIClient, did, activityURI string) ([]measurementOption, error) {
 	all, err := fetchMeasurements(ctx, client, did)
 	if err != nil {
@@ -216,12 +246,7 @@ func runMeasurementCreate(ctx context.Context, cmd *cli.Command) error {
 		}
 		if value == "" {
 			err = huh.NewInput().Title("Value").Description("numeric").
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return errors.New("value is required")
-					}
-					return nil
-				}).Value(&value).WithTheme(style.Theme()).Run()
+				Validate(validateValue).Value(&value).WithTheme(style.Theme()).Run()
 			if err != nil {
 				if errors.Is(err, huh.ErrUserAborted) {
 					return fmt.Errorf("cancelled")
@@ -229,6 +254,9 @@ func runMeasurementCreate(ctx context.Context, cmd *cli.Command) error {
 				return err
 			}
 		}
+		if unit, value, err = normalizeMeasurement(w, unit, value); err != nil {
+			return err
+		}
 		record["metric"] = metric
 		record["unit"] = unit
 		record["value"] = value
@@ -267,12 +295,7 @@ func runMeasurementCreate(ctx context.Context, cmd *cli.Command) error {
 				huh.NewInput().
 					Title("Value").
 					Description("Numeric measurement value").
-					Validate(func(s string) error {
-						if strings.TrimSpace(s) == "" {
-							return errors.New("value is required")
-						}
-						return nil
-					}).
+					Validate(validateValue).
 					Value(&value),
 			).Title("Measurement Data"),
 
@@ -321,6 +344,9 @@ func runMeasurementCreate(ctx context.Context, cmd *cli.Command) error {
 			return err
 		}
 
+		if unit, value, err = normalizeMeasurement(w, unit, value); err != nil {
+			return err
+		}
 		record["metric"] = metric
 		record["unit"] = unit
 		record["value"] = value
@@ -449,7 +475,7 @@ func runMeasurementEdit(ctx context.Context, cmd *cli.Command) error {
 			huh.NewGroup(
 				huh.NewInput().Title("Metric").Description("Required").Value(&newMetric),
 				huh.NewInput().Title("Unit").Description("Required").Value(&newUnit),
-				huh.NewInput().Title("Value").Description("Required").Value(&newValue),
+				huh.NewInput().Title("Value").Description("Required").Validate(validateValue).Value(&newValue),
 				huh.NewConfirm().Title("Edit optional fields?").Inline(true).Value(&editOptional),
 			).Title("Edit Measurement"),
 		).WithTheme(style.Theme())
@@ -537,6 +563,12 @@ func runMeasurementEdit(ctx context.Context, cmd *cli.Command) error {
 		newValue = currentValue
 	}
 
+	if newUnit != currentUnit || newValue != currentValue {
+		if newUnit, newValue, err = normalizeMeasurement(w, newUnit, newValue); err != nil {
+			return err
+		}
+	}
+
 	if newMetric != currentMetric {
 		existing["metric"] = newMetric
 		changed = true
@@ -563,7 +595,7 @@ func runMeasurementEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update measurement: %w", err)
 	}
@@ -638,13 +670,21 @@ func runMeasurementDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runMeasurementList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
+
+	convertTo := cmd.String("convert-to")
+	if convertTo != "" {
+		u, ok := units.Lookup(convertTo)
+		if !ok {
+			return fmt.Errorf("unknown unit %q", convertTo)
+		}
+		convertTo = u.Symbol
+	}
 
 	// Filter by activity if specified
 	activityFilter := cmd.String("activity")
@@ -691,12 +731,19 @@ func runMeasurementList(ctx context.Context, cmd *cli.Command) error {
 					continue
 				}
 			}
-			records = append(records, map[string]any{"uri": e.URI, "record": e.Value})
+			entry := map[string]any{"uri": e.URI, "record": e.Value}
+			if convertTo != "" {
+				if v, ok := convertMeasurement(mapStr(e.Value, "value"), mapStr(e.Value, "unit"), convertTo); ok {
+					entry["converted"] = map[string]any{"value": units.FormatValue(v), "unit": convertTo}
+				}
+			}
+			records = append(records, entry)
 		}
 		fmt.Fprintln(w, prettyJSON(records))
 		return nil
 	}
 
+	skipped := 0
 	fmt.Fprintf(w, "\033[1m%-15s %-20s %-10s %-10s %-15s %s\033[0m\n", "ID", "METRIC", "VALUE", "UNIT", "ACTIVITY", "CREATED")
 	fmt.Fprintf(w, "%-15s %-20s %-10s %-10s %-15s %s\n",
 		strings.Repeat("-", 13), strings.Repeat("-", 18),
@@ -708,11 +755,17 @@ func runMeasurementList(ctx context.Context, cmd *cli.Command) error {
 		if len(metric) > 18 {
 			metric = metric[:15] + "..."
 		}
-		value := m.Value
+		value, unit := m.Value, m.Unit
+		if convertTo != "" {
+			if v, ok := convertMeasurement(m.Value, m.Unit, convertTo); ok {
+				value, unit = units.FormatValue(v), convertTo
+			} else {
+				skipped++
+			}
+		}
 		if len(value) > 8 {
 			value = value[:5] + "..."
 		}
-		unit := m.Unit
 		if len(unit) > 8 {
 			unit = unit[:5] + "..."
 		}
@@ -730,9 +783,25 @@ func runMeasurementList(ctx context.Context, cmd *cli.Command) error {
 	if len(entries) == 0 {
 		fmt.Fprintln(w, "\033[90m(no measurements found)\033[0m")
 	}
+	if skipped > 0 {
+		fmt.Fprintf(w, "Warning: %d measurement(s) could not be converted to %s and are shown as recorded\n", skipped, convertTo)
+	}
 	return nil
 }
 
+// convertMeasurement converts a recorded value/unit pair to the target unit.
+func convertMeasurement(value, unit, to string) (float64, bool) {
+	v, err := units.ParseValue(value)
+	if err != nil {
+		return 0, false
+	}
+	converted, err := units.Convert(v, unit, to)
+	if err != nil {
+		return 0, false
+	}
+	return converted, true
+}
+
 func runMeasurementGet(ctx context.Context, cmd *cli.Command) error {
 	return runSimpleGet(ctx, cmd, atproto.CollectionMeasurement, "measurement")
 }
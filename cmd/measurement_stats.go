@@ -0,0 +1,522 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/units"
+)
+
+// Group-by dimensions accepted by hc measurement stats.
+const (
+	groupMetric     = "metric"
+	groupActivity   = "activity"
+	groupCollection = "collection"
+	groupLocation   = "location"
+	groupMonth      = "month"
+	groupQuarter    = "quarter"
+	groupYear       = "year"
+)
+
+// statsSample is one measurement prepared for aggregation.
+type statsSample struct {
+	Metric      string
+	Unit        string
+	Value       float64
+	Start, End  time.Time // End equals Start for a point-in-time measurement
+	Activity    string
+	Collections []string
+	Locations   []string
+}
+
+// statsRow is the aggregate for one group.
+type statsRow struct {
+	Keys  []string
+	Unit  string
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+// Mean returns the average contribution per measurement in the group.
+func (r statsRow) Mean() float64 {
+	if r.Count == 0 {
+		return 0
+	}
+	return r.Sum / float64(r.Count)
+}
+
+// bucketShare is the fraction of a measurement that falls in one time bucket.
+type bucketShare struct {
+	Label    string
+	Fraction float64
+}
+
+// parseGroupBy validates a comma-separated --group-by value.
+// At most one time bucket (month, quarter, year) may be given.
+func parseGroupBy(s string) (groups []string, bucket string, err error) {
+	for _, g := range strings.Split(s, ",") {
+		g = strings.ToLower(strings.TrimSpace(g))
+		switch g {
+		case "":
+			continue
+		case groupMetric, groupActivity, groupCollection, groupLocation:
+		case groupMonth, groupQuarter, groupYear:
+			if bucket != "" {
+				return nil, "", fmt.Errorf("only one time bucket can be grouped by, got %s and %s", bucket, g)
+			}
+			bucket = g
+		default:
+			return nil, "", fmt.Errorf("unknown group %q (use metric, activity, collection, location, month, quarter, or year)", g)
+		}
+		groups = append(groups, g)
+	}
+	if len(groups) == 0 {
+		groups = []string{groupMetric}
+	}
+	return groups, bucket, nil
+}
+
+// bucketStart returns the start of the bucket containing t.
+func bucketStart(t time.Time, bucket string) time.Time {
+	y, m, _ := t.Date()
+	switch bucket {
+	case groupMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case groupQuarter:
+		return time.Date(y, ((m-1)/3)*3+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// bucketNext returns the start of the bucket after the one starting at start.
+func bucketNext(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case groupMonth:
+		return start.AddDate(0, 1, 0)
+	case groupQuarter:
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
+// bucketLabel formats a bucket start as 2025-03, 2025-Q1, or 2025.
+func bucketLabel(start time.Time, bucket string) string {
+	switch bucket {
+	case groupMonth:
+		return start.Format("2006-01")
+	case groupQuarter:
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	default:
+		return strconv.Itoa(start.Year())
+	}
+}
+
+// prorate splits a measurement period across time buckets and the optional
+// [from, to) window. Each share is the fraction of the period's duration that
+// falls in that bucket; a point-in-time measurement lands wholly in one bucket.
+// With no bucket, a single unlabeled share covers the part inside the window.
+func prorate(start, end time.Time, bucket string, from, to time.Time) []bucketShare {
+	clipStart, clipEnd := start, end
+	if !from.IsZero() && clipStart.Before(from) {
+		clipStart = from
+	}
+	if !to.IsZero() && clipEnd.After(to) {
+		clipEnd = to
+	}
+
+	if !end.After(start) {
+		if (!from.IsZero() && start.Before(from)) || (!to.IsZero() && !start.Before(to)) {
+			return nil
+		}
+		label := ""
+		if bucket != "" {
+			label = bucketLabel(bucketStart(start, bucket), bucket)
+		}
+		return []bucketShare{{Label: label, Fraction: 1}}
+	}
+	if !clipEnd.After(clipStart) {
+		return nil
+	}
+
+	total := end.Sub(start).Seconds()
+	if bucket == "" {
+		return []bucketShare{{Fraction: clipEnd.Sub(clipStart).Seconds() / total}}
+	}
+	var shares []bucketShare
+	for cur := bucketStart(clipStart, bucket); cur.Before(clipEnd); cur = bucketNext(cur, bucket) {
+		segStart := cur
+		if segStart.Before(clipStart) {
+			segStart = clipStart
+		}
+		segEnd := bucketNext(cur, bucket)
+		if segEnd.After(clipEnd) {
+			segEnd = clipEnd
+		}
+		shares = append(shares, bucketShare{
+			Label:    bucketLabel(cur, bucket),
+			Fraction: segEnd.Sub(segStart).Seconds() / total,
+		})
+	}
+	return shares
+}
+
+// aggregateStats groups samples and computes count, sum, min, and max per
+// group. Samples are always kept apart by unit so incompatible values are
+// never summed.
+func aggregateStats(samples []statsSample, groups []string, bucket string, from, to time.Time) []statsRow {
+	rows := map[string]*statsRow{}
+	for _, s := range samples {
+		for _, share := range prorate(s.Start, s.End, bucket, from, to) {
+			combos := [][]string{{}}
+			for _, g := range groups {
+				var vals []string
+				switch g {
+				case groupMetric:
+					vals = []string{s.Metric}
+				case groupActivity:
+					vals = []string{s.Activity}
+				case groupCollection:
+					vals = s.Collections
+				case groupLocation:
+					vals = s.Locations
+				default:
+					vals = []string{share.Label}
+				}
+				if len(vals) == 0 {
+					vals = []string{"-"}
+				}
+				var next [][]string
+				for _, c := range combos {
+					for _, v := range vals {
+						next = append(next, append(append([]string(nil), c...), v))
+					}
+				}
+				combos = next
+			}
+
+			v := s.Value * share.Fraction
+			for _, keys := range combos {
+				id := strings.Join(append(append([]string(nil), keys...), s.Unit), "\x00")
+				r, ok := rows[id]
+				if !ok {
+					r = &statsRow{Keys: keys, Unit: s.Unit, Min: v, Max: v}
+					rows[id] = r
+				}
+				r.Count++
+				r.Sum += v
+				r.Min = math.Min(r.Min, v)
+				r.Max = math.Max(r.Max, v)
+			}
+		}
+	}
+
+	result := make([]statsRow, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		for k := range a.Keys {
+			if a.Keys[k] != b.Keys[k] {
+				return a.Keys[k] < b.Keys[k]
+			}
+		}
+		return a.Unit < b.Unit
+	})
+	return result
+}
+
+// measurementPeriod returns the period a measurement covers. End dates at
+// midnight UTC are dates (hc stores YYYY-MM-DD input as midnight) and are
+// inclusive, so 2025-01-01..2025-12-31 spans the whole year and an end date
+// alone covers that day. Without start or end date, createdAt is used as a
+// point in time.
+func measurementPeriod(record map[string]any) (start, end time.Time, ok bool) {
+	parse := func(key string) time.Time {
+		t, err := time.Parse(time.RFC3339, normalizeDate(mapStr(record, key)))
+		if err != nil {
+			return time.Time{}
+		}
+		return t.UTC()
+	}
+	start, end = parse("startDate"), parse("endDate")
+	if !end.IsZero() && end.Equal(end.Truncate(24*time.Hour)) {
+		if start.IsZero() {
+			start = end
+		}
+		end = end.Add(24 * time.Hour)
+	}
+	switch {
+	case !start.IsZero() && !end.IsZero():
+		return start, end, true
+	case !start.IsZero():
+		return start, start, true
+	case !end.IsZero():
+		return end, end, true
+	}
+	if created := parse("createdAt"); !created.IsZero() {
+		return created, created, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// statsLabels maps record URIs to display names for grouping.
+type statsLabels struct {
+	activities  map[string]string   // activity uri -> title
+	collections map[string][]string // activity uri -> collection titles
+	locations   map[string]string   // location uri -> name
+}
+
+// fetchStatsLabels loads the records needed to label the requested groups.
+func fetchStatsLabels(ctx context.Context, client *atclient.APIClient, did string, groups []string) (statsLabels, error) {
+	labels := statsLabels{activities: map[string]string{}, collections: map[string][]string{}, locations: map[string]string{}}
+	for _, g := range groups {
+		switch g {
+		case groupActivity:
+			activities, err := fetchActivities(ctx, client, did)
+			if err != nil {
+				return labels, err
+			}
+			for _, a := range activities {
+				labels.activities[a.URI] = a.Title
+			}
+		case groupCollection:
+			entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionCollection)
+			if err != nil {
+				return labels, fmt.Errorf("failed to list collections: %w", err)
+			}
+			for _, e := range entries {
+				title := mapStr(e.Value, "title")
+				if title == "" {
+					title = extractRkey(e.URI)
+				}
+				for _, item := range mapSlice(e.Value, "items") {
+					if im, ok := item.(map[string]any); ok {
+						uri := mapStr(mapMap(im, "itemIdentifier"), "uri")
+						labels.collections[uri] = append(labels.collections[uri], title)
+					}
+				}
+			}
+		case groupLocation:
+			entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionLocation)
+			if err != nil {
+				return labels, fmt.Errorf("failed to list locations: %w", err)
+			}
+			for _, e := range entries {
+				if name := mapStr(e.Value, "name"); name != "" {
+					labels.locations[e.URI] = name
+				}
+			}
+		}
+	}
+	return labels, nil
+}
+
+func runMeasurementStats(ctx context.Context, cmd *cli.Command) error {
+	groups, bucket, err := parseGroupBy(cmd.String("group-by"))
+	if err != nil {
+		return err
+	}
+	format := cmd.String("format")
+	if format != "table" && format != "csv" && format != "json" {
+		return fmt.Errorf("unknown format %q (use table, csv, or json)", format)
+	}
+	var from, to time.Time
+	if s := cmd.String("from"); s != "" {
+		if from, err = time.Parse(time.RFC3339, normalizeDate(s)); err != nil {
+			return fmt.Errorf("invalid --from date: %s", s)
+		}
+	}
+	if s := cmd.String("to"); s != "" {
+		if to, err = time.Parse(time.RFC3339, normalizeDate(s)); err != nil {
+			return fmt.Errorf("invalid --to date: %s", s)
+		}
+		if len(s) == 10 {
+			to = to.Add(24 * time.Hour) // date-only --to is inclusive
+		}
+	}
+	convertTo := cmd.String("convert-to")
+	if convertTo != "" {
+		u, ok := units.Lookup(convertTo)
+		if !ok {
+			return fmt.Errorf("unknown unit %q", convertTo)
+		}
+		convertTo = u.Symbol
+	}
+
+	client, did, err := requireReadClient(ctx, cmd, "")
+	if err != nil {
+		return err
+	}
+	w := cmd.Root().Writer
+
+	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionMeasurement)
+	if err != nil {
+		return fmt.Errorf("failed to list measurements: %w", err)
+	}
+	labels, err := fetchStatsLabels(ctx, client, did, groups)
+	if err != nil {
+		return err
+	}
+
+	activityURI := ""
+	if a := cmd.String("activity"); a != "" {
+		activityURI = resolveRecordURI(did, atproto.CollectionActivity, a)
+	}
+	metricFilter := strings.ToLower(cmd.String("metric"))
+
+	var samples []statsSample
+	var nonNumeric, unconvertible, undated int
+	for _, e := range entries {
+		subject := measurementSubjectURI(e.Value)
+		if activityURI != "" && subject != activityURI {
+			continue
+		}
+		metric := mapStr(e.Value, "metric")
+		if metricFilter != "" && !strings.Contains(strings.ToLower(metric), metricFilter) {
+			continue
+		}
+		value, err := units.ParseValue(mapStr(e.Value, "value"))
+		if err != nil {
+			nonNumeric++
+			continue
+		}
+		unit := units.Normalize(mapStr(e.Value, "unit"))
+		if convertTo != "" {
+			if value, err = units.Convert(value, unit, convertTo); err != nil {
+				unconvertible++
+				continue
+			}
+			unit = convertTo
+		}
+		start, end, ok := measurementPeriod(e.Value)
+		if !ok && (bucket != "" || !from.IsZero() || !to.IsZero()) {
+			undated++
+			continue
+		}
+
+		s := statsSample{Metric: metric, Unit: unit, Value: value, Start: start, End: end}
+		s.Activity = labels.activities[subject]
+		if s.Activity == "" && subject != "" {
+			s.Activity = extractRkey(subject)
+		}
+		s.Collections = labels.collections[subject]
+		for _, l := range mapSlice(e.Value, "locations") {
+			if lm, ok := l.(map[string]any); ok {
+				uri := mapStr(lm, "uri")
+				name := labels.locations[uri]
+				if name == "" {
+					name = extractRkey(uri)
+				}
+				s.Locations = append(s.Locations, name)
+			}
+		}
+		samples = append(samples, s)
+	}
+
+	rows := aggregateStats(samples, groups, bucket, from, to)
+	switch format {
+	case "json":
+		printStatsJSON(w, rows, groups)
+	case "csv":
+		if err := printStatsCSV(w, rows, groups); err != nil {
+			return err
+		}
+	default:
+		printStatsTable(w, rows, groups)
+	}
+
+	if format == "table" {
+		if nonNumeric > 0 {
+			fmt.Fprintf(w, "Warning: skipped %d measurement(s) with non-numeric values\n", nonNumeric)
+		}
+		if unconvertible > 0 {
+			fmt.Fprintf(w, "Warning: skipped %d measurement(s) that cannot be converted to %s\n", unconvertible, convertTo)
+		}
+		if undated > 0 {
+			fmt.Fprintf(w, "Warning: skipped %d measurement(s) with no dates\n", undated)
+		}
+	}
+	return nil
+}
+
+// formatStat rounds an aggregate for table display.
+func formatStat(v float64) string {
+	return units.FormatValue(math.Round(v*100) / 100)
+}
+
+func printStatsTable(w io.Writer, rows []statsRow, groups []string) {
+	var header, dashes []any
+	pattern := ""
+	for _, g := range groups {
+		header = append(header, strings.ToUpper(g))
+		dashes = append(dashes, strings.Repeat("-", 18))
+		pattern += "%-20s "
+	}
+	header = append(header, "UNIT", "COUNT", "SUM", "MEAN", "MIN", "MAX")
+	dashes = append(dashes, strings.Repeat("-", 10), strings.Repeat("-", 5),
+		strings.Repeat("-", 10), strings.Repeat("-", 10), strings.Repeat("-", 10), strings.Repeat("-", 10))
+	pattern += "%-12s %-7s %-12s %-12s %-12s %s\n"
+
+	fmt.Fprintf(w, "\033[1m"+strings.TrimSuffix(pattern, "\n")+"\033[0m\n", header...)
+	fmt.Fprintf(w, pattern, dashes...)
+	for _, r := range rows {
+		var cols []any
+		for _, k := range r.Keys {
+			cols = append(cols, truncate(k, 18))
+		}
+		cols = append(cols, truncate(r.Unit, 10), strconv.Itoa(r.Count),
+			formatStat(r.Sum), formatStat(r.Mean()), formatStat(r.Min), formatStat(r.Max))
+		fmt.Fprintf(w, pattern, cols...)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "\033[90m(no measurements found)\033[0m")
+	}
+}
+
+func printStatsCSV(w io.Writer, rows []statsRow, groups []string) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(append(append([]string(nil), groups...), "unit", "count", "sum", "mean", "min", "max"))
+	for _, r := range rows {
+		record := append(append([]string(nil), r.Keys...), r.Unit, strconv.Itoa(r.Count),
+			units.FormatValue(r.Sum), units.FormatValue(r.Mean()), units.FormatValue(r.Min), units.FormatValue(r.Max))
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func printStatsJSON(w io.Writer, rows []statsRow, groups []string) {
+	out := []map[string]any{}
+	for _, r := range rows {
+		group := map[string]any{}
+		for i, g := range groups {
+			group[g] = r.Keys[i]
+		}
+		out = append(out, map[string]any{
+			"group": group,
+			"unit":  r.Unit,
+			"count": r.Count,
+			"sum":   r.Sum,
+			"mean":  r.Mean(),
+			"min":   r.Min,
+			"max":   r.Max,
+		})
+	}
+	fmt.Fprintln(w, prettyJSON(out))
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantGroups int
+		wantBucket string
+		wantErr    bool
+	}{
+		{"", 1, "", false},
+		{"metric", 1, "", false},
+		{"metric, year", 2, "year", false},
+		{"activity,location,quarter", 3, "quarter", false},
+		{"month,year", 0, "", true},
+		{"week", 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			groups, bucket, err := parseGroupBy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGroupBy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if len(groups) != tt.wantGroups || bucket != tt.wantBucket {
+				t.Errorf("parseGroupBy(%q) = %v, %q", tt.input, groups, bucket)
+			}
+		})
+	}
+}
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		bucket   string
+		from, to string
+		want     map[string]float64
+	}{
+		{"whole_year", "2025-01-01", "2026-01-01", "year", "", "", map[string]float64{"2025": 1}},
+		{"straddles_years", "2024-07-01", "2025-07-01", "year", "", "", map[string]float64{"2024": 184.0 / 365, "2025": 181.0 / 365}},
+		{"quarters", "2025-02-01", "2025-05-01", "quarter", "", "", map[string]float64{"2025-Q1": 59.0 / 89, "2025-Q2": 30.0 / 89}},
+		{"point", "2025-03-15", "2025-03-15", "month", "", "", map[string]float64{"2025-03": 1}},
+		{"window_clips", "2024-07-01", "2025-07-01", "", "2025-01-01", "2026-01-01", map[string]float64{"": 181.0 / 365}},
+		{"outside_window", "2023-01-01", "2023-06-01", "", "2025-01-01", "2026-01-01", map[string]float64{}},
+		{"point_outside_window", "2024-12-31", "2024-12-31", "year", "2025-01-01", "", map[string]float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var from, to time.Time
+			if tt.from != "" {
+				from = mustDate(tt.from)
+			}
+			if tt.to != "" {
+				to = mustDate(tt.to)
+			}
+			shares := prorate(mustDate(tt.start), mustDate(tt.end), tt.bucket, from, to)
+			if len(shares) != len(tt.want) {
+				t.Fatalf("got %d shares %v, want %v", len(shares), shares, tt.want)
+			}
+			for _, s := range shares {
+				want, ok := tt.want[s.Label]
+				if !ok || math.Abs(s.Fraction-want) > 1e-9 {
+					t.Errorf("share %q = %v, want %v", s.Label, s.Fraction, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAggregateStats(t *testing.T) {
+	samples := []statsSample{
+		{Metric: "area restored", Unit: "ha", Value: 10, Start: mustDate("2025-01-01"), End: mustDate("2025-01-01"), Locations: []string{"site a"}},
+		{Metric: "area restored", Unit: "ha", Value: 30, Start: mustDate("2025-06-01"), End: mustDate("2025-06-01"), Locations: []string{"site a", "site b"}},
+		{Metric: "area restored", Unit: "ha", Value: 12, Start: mustDate("2024-07-01"), End: mustDate("2025-07-01")},
+		{Metric: "area restored", Unit: "acre", Value: 5, Start: mustDate("2025-02-01"), End: mustDate("2025-02-01")},
+	}
+
+	rows := aggregateStats(samples, []string{groupMetric, groupYear}, groupYear, time.Time{}, time.Time{})
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows %+v, want 3", len(rows), rows)
+	}
+	// Rows sort by keys then unit: 2024/ha, 2025/acre, 2025/ha.
+	if r := rows[0]; r.Keys[1] != "2024" || r.Count != 1 || math.Abs(r.Sum-12*184.0/365) > 1e-9 {
+		t.Errorf("2024 row = %+v", r)
+	}
+	if r := rows[1]; r.Unit != "acre" || r.Sum != 5 {
+		t.Errorf("acre row = %+v", r)
+	}
+	r := rows[2]
+	if r.Keys[1] != "2025" || r.Unit != "ha" || r.Count != 3 {
+		t.Fatalf("2025 ha row = %+v", r)
+	}
+	wantSum := 40 + 12*181.0/365
+	if math.Abs(r.Sum-wantSum) > 1e-9 || r.Max != 30 || math.Abs(r.Min-12*181.0/365) > 1e-9 {
+		t.Errorf("2025 ha row = %+v, want sum %v", r, wantSum)
+	}
+	if math.Abs(r.Mean()-wantSum/3) > 1e-9 {
+		t.Errorf("mean = %v, want %v", r.Mean(), wantSum/3)
+	}
+
+	// A measurement at several locations counts toward each one.
+	rows = aggregateStats(samples[:3], []string{groupLocation}, "", time.Time{}, time.Time{})
+	got := map[string]float64{}
+	for _, r := range rows {
+		got[r.Keys[0]] = r.Sum
+	}
+	if got["site a"] != 40 || got["site b"] != 30 || got["-"] != 12 {
+		t.Errorf("location sums = %v", got)
+	}
+}
+
+func TestMeasurementPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		record    map[string]any
+		wantStart string
+		wantEnd   time.Time
+	}{
+		{"date-only end", map[string]any{"startDate": "2025-01-01", "endDate": "2025-12-31"}, "2025-01-01", mustDate("2026-01-01")},
+		{"date-only end without start", map[string]any{"endDate": "2025-12-31"}, "2025-12-31", mustDate("2026-01-01")},
+		{"midnight timestamp end", map[string]any{"startDate": "2025-01-01T00:00:00Z", "endDate": "2025-12-31T00:00:00Z"}, "2025-01-01", mustDate("2026-01-01")},
+		{"midnight timestamp end without start", map[string]any{"endDate": "2025-12-31T00:00:00Z"}, "2025-12-31", mustDate("2026-01-01")},
+		{"end with a time of day", map[string]any{"startDate": "2025-01-01", "endDate": "2025-12-31T12:00:00Z"}, "2025-01-01", mustDate("2025-12-31").Add(12 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := measurementPeriod(tt.record)
+			if !ok || !start.Equal(mustDate(tt.wantStart)) || !end.Equal(tt.wantEnd) {
+				t.Errorf("period = %v..%v, %v; want %s..%v", start, end, ok, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	start, end, ok := measurementPeriod(map[string]any{"createdAt": "2025-03-04T10:00:00Z"})
+	if !ok || !start.Equal(end) || start.Month() != time.March {
+		t.Errorf("createdAt fallback = %v..%v, %v", start, end, ok)
+	}
+	if _, _, ok := measurementPeriod(map[string]any{}); ok {
+		t.Error("expected no period for undated measurement")
+	}
+}
@@ -98,7 +98,7 @@ func runProfileSet(ctx context.Context, cmd *cli.Command) error {
 	_, cid, err := atproto.GetRecord(ctx, client, did, atproto.CollectionActorProfile, "self")
 	if err == nil {
 		// Record exists, use PutRecord
-		uri, err := atproto.PutRecord(ctx, client, did, atproto.CollectionActorProfile, "self", record, &cid)
+		uri, _, err := atproto.PutRecord(ctx, client, did, atproto.CollectionActorProfile, "self", record, &cid)
 		if err != nil {
 			return fmt.Errorf("failed to update profile: %w", err)
 		}
@@ -117,12 +117,11 @@ func runProfileSet(ctx context.Context, cmd *cli.Command) error {
 
 // runProfileGet fetches and displays the user's profile record.
 func runProfileGet(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	record, _, err := atproto.GetRecord(ctx, client, did, atproto.CollectionActorProfile, "self")
 	if err != nil {
@@ -295,7 +294,7 @@ func runOrganizationSet(ctx context.Context, cmd *cli.Command) error {
 	_, cid, err := atproto.GetRecord(ctx, client, did, atproto.CollectionActorOrganization, "self")
 	if err == nil {
 		// Record exists, use PutRecord
-		uri, err := atproto.PutRecord(ctx, client, did, atproto.CollectionActorOrganization, "self", record, &cid)
+		uri, _, err := atproto.PutRecord(ctx, client, did, atproto.CollectionActorOrganization, "self", record, &cid)
 		if err != nil {
 			return fmt.Errorf("failed to update organization: %w", err)
 		}
@@ -314,12 +313,11 @@ func runOrganizationSet(ctx context.Context, cmd *cli.Command) error {
 
 // runOrganizationGet fetches and displays the user's organization record.
 func runOrganizationGet(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	record, _, err := atproto.GetRecord(ctx, client, did, atproto.CollectionActorOrganization, "self")
 	if err != nil {
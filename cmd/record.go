@@ -7,7 +7,6 @@ import (
 
 	"github.com/bluesky-social/indigo/api/agnostic"
 	comatproto "github.com/bluesky-social/indigo/api/atproto"
-	"github.com/bluesky-social/indigo/atproto/atclient"
 	"github.com/bluesky-social/indigo/atproto/identity"
 	"github.com/bluesky-social/indigo/atproto/syntax"
 	"github.com/urfave/cli/v3"
@@ -30,8 +29,10 @@ func runRecordGet(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	c := atclient.NewAPIClient(ident.PDSEndpoint())
-	c.Headers.Set("User-Agent", userAgentString())
+	c, err := publicClient(ident)
+	if err != nil {
+		return err
+	}
 
 	resp, err := agnostic.RepoGetRecord(ctx, c, "", aturi.Collection().String(), ident.DID.String(), aturi.RecordKey().String())
 	if err != nil {
@@ -61,10 +62,9 @@ func runRecordList(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	c := atclient.NewAPIClient(ident.PDSEndpoint())
-	c.Headers.Set("User-Agent", userAgentString())
-	if c.Host == "" {
-		return fmt.Errorf("no PDS endpoint for identity")
+	c, err := publicClient(ident)
+	if err != nil {
+		return err
 	}
 
 	desc, err := comatproto.RepoDescribeRepo(ctx, c, ident.DID.String())
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/license"
 	"github.com/GainForest/hypercerts-cli/internal/menu"
 	"github.com/GainForest/hypercerts-cli/internal/style"
 )
@@ -103,40 +105,62 @@ func selectRights(ctx context.Context, client *atclient.APIClient, w io.Writer)
 	return selected, nil
 }
 
+// selectLicense shows a filterable picker over the license catalog. It returns
+// nil when the user chooses to enter a custom license.
+func selectLicense(w io.Writer) (*license.License, error) {
+	selected, isCustom, err := menu.SingleSelectWithCreate(w, license.All(), "license",
+		func(l license.License) string { return l.ID },
+		func(l license.License) string { return l.Name },
+		"Custom license...",
+	)
+	if err != nil || isCustom {
+		return nil, err
+	}
+	return selected, nil
+}
+
 func createRightsInline(ctx context.Context, client *atclient.APIClient, w io.Writer) (*rightsOption, error) {
-	var name, rightsType, description string
+	var name, rightsType, description, attachmentURI string
 
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().Title("Rights name").Description("max 100 chars").CharLimit(100).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return errors.New("rights name is required")
-					}
-					return nil
-				}).Value(&name),
-			huh.NewInput().Title("Rights type").Description("short ID max 10 chars, e.g. CC-BY-4.0").CharLimit(10).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return errors.New("rights type is required")
-					}
-					return nil
-				}).Value(&rightsType),
-			huh.NewInput().Title("Description").
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return errors.New("description is required")
-					}
-					return nil
-				}).Value(&description),
-		).Title("New Rights"),
-	).WithTheme(style.Theme())
+	l, err := selectLicense(w)
+	if err != nil {
+		return nil, err
+	}
+	if l != nil {
+		name, rightsType, description, attachmentURI = l.Name, l.Type, l.Description, l.URL
+	} else {
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().Title("Rights name").Description("max 100 chars").CharLimit(100).
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return errors.New("rights name is required")
+						}
+						return nil
+					}).Value(&name),
+				huh.NewInput().Title("Rights type").Description("short ID max 10 chars, e.g. CC-BY-4.0").CharLimit(10).
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return errors.New("rights type is required")
+						}
+						return nil
+					}).Value(&rightsType),
+				huh.NewInput().Title("Description").
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return errors.New("description is required")
+						}
+						return nil
+					}).Value(&description),
+			).Title("New Rights"),
+		).WithTheme(style.Theme())
 
-	if err := form.Run(); err != nil {
-		if errors.Is(err, huh.ErrUserAborted) {
-			return nil, fmt.Errorf("cancelled")
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return nil, fmt.Errorf("cancelled")
+			}
+			return nil, err
 		}
-		return nil, err
 	}
 
 	record := map[string]any{
@@ -146,6 +170,12 @@ func createRightsInline(ctx context.Context, client *atclient.APIClient, w io.Wr
 		"rightsDescription": description,
 		"createdAt":         time.Now().UTC().Format(time.RFC3339),
 	}
+	if attachmentURI != "" {
+		record["attachment"] = map[string]any{
+			"$type": "org.hypercerts.defs#uri",
+			"uri":   attachmentURI,
+		}
+	}
 
 	uri, cid, err := atproto.CreateRecord(ctx, client, atproto.CollectionRights, record)
 	if err != nil {
@@ -177,8 +207,28 @@ func runRightsCreate(ctx context.Context, cmd *cli.Command) error {
 	description := cmd.String("description")
 	attachmentURI := cmd.String("attachment")
 
+	// A catalog license fills the fields not given as flags.
+	var l *license.License
+	if id := cmd.String("license"); id != "" {
+		found, ok := license.Lookup(id)
+		if !ok {
+			return fmt.Errorf("unknown license %q (see: hc rights licenses)", id)
+		}
+		l = &found
+	} else if name == "" && rightsType == "" && description == "" {
+		if l, err = selectLicense(w); err != nil {
+			return err
+		}
+	}
+	if l != nil {
+		name = cmp.Or(name, l.Name)
+		rightsType = cmp.Or(rightsType, l.Type)
+		description = cmp.Or(description, l.Description)
+		attachmentURI = cmp.Or(attachmentURI, l.URL)
+	}
+
 	if name == "" && rightsType == "" && description == "" {
-		// Interactive mode: show all fields at once using huh form
+		// Custom license: show all fields at once using huh form
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
@@ -450,7 +500,7 @@ func runRightsEdit(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	resultURI, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
+	resultURI, _, err := atproto.PutRecord(ctx, client, did, aturi.Collection().String(), aturi.RecordKey().String(), existing, &cid)
 	if err != nil {
 		return fmt.Errorf("failed to update rights: %w", err)
 	}
@@ -518,13 +568,12 @@ func runRightsDelete(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runRightsList(ctx context.Context, cmd *cli.Command) error {
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, "")
 	if err != nil {
 		return err
 	}
 
 	w := cmd.Root().Writer
-	did := client.AccountDID.String()
 
 	entries, err := atproto.ListAllRecords(ctx, client, did, atproto.CollectionRights)
 	if err != nil {
@@ -534,16 +583,23 @@ func runRightsList(ctx context.Context, cmd *cli.Command) error {
 	if cmd.Bool("json") {
 		var records []map[string]any
 		for _, e := range entries {
-			records = append(records, map[string]any{"uri": e.URI, "record": e.Value})
+			l, status, problems := checkRightsLicense(e.Value)
+			records = append(records, map[string]any{
+				"uri":     e.URI,
+				"record":  e.Value,
+				"license": map[string]any{"id": l.ID, "status": status, "problems": problems},
+			})
 		}
 		fmt.Fprintln(w, prettyJSON(records))
 		return nil
 	}
 
-	fmt.Fprintf(w, "\033[1m%-15s %-25s %-12s %-35s %s\033[0m\n", "ID", "NAME", "TYPE", "DESCRIPTION", "CREATED")
-	fmt.Fprintf(w, "%-15s %-25s %-12s %-35s %s\n",
+	fmt.Fprintf(w, "\033[1m%-15s %-25s %-12s %-35s %-12s %s\033[0m\n", "ID", "NAME", "TYPE", "DESCRIPTION", "CREATED", "LICENSE")
+	fmt.Fprintf(w, "%-15s %-25s %-12s %-35s %-12s %s\n",
 		strings.Repeat("-", 13), strings.Repeat("-", 23),
-		strings.Repeat("-", 10), strings.Repeat("-", 33), strings.Repeat("-", 10))
+		strings.Repeat("-", 10), strings.Repeat("-", 33), strings.Repeat("-", 10), strings.Repeat("-", 10))
+
+	var warnings []string
 
 	for _, e := range entries {
 		aturi, err := syntax.ParseATURI(e.URI)
@@ -572,12 +628,55 @@ func runRightsList(ctx context.Context, cmd *cli.Command) error {
 			}
 		}
 
-		fmt.Fprintf(w, "%-15s %-25s %-12s %-35s %s\n", id, name, rightsType, description, created)
+		l, status, problems := checkRightsLicense(e.Value)
+		catalog := l.ID
+		switch status {
+		case license.StatusCustom:
+			catalog = "\033[90mnot in catalog\033[0m"
+		case license.StatusInconsistent:
+			catalog = "\033[33minconsistent\033[0m"
+			for _, p := range problems {
+				warnings = append(warnings, fmt.Sprintf("Warning: %s: %s", id, p))
+			}
+		}
+
+		fmt.Fprintf(w, "%-15s %-25s %-12s %-35s %-12s %s\n", id, name, rightsType, description, created, catalog)
 	}
 
 	if len(entries) == 0 {
 		fmt.Fprintln(w, "\033[90m(no rights found)\033[0m")
 	}
+	if len(warnings) > 0 {
+		fmt.Fprintln(w)
+		for _, warning := range warnings {
+			fmt.Fprintln(w, warning)
+		}
+	}
+	return nil
+}
+
+// checkRightsLicense compares a rights record with the license catalog.
+func checkRightsLicense(record map[string]any) (license.License, license.Status, []string) {
+	return license.Check(mapStr(record, "rightsType"), mapStr(record, "rightsName"), mapStr(mapMap(record, "attachment"), "uri"))
+}
+
+func runRightsLicenses(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	licenses := license.Search(cmd.Args().First())
+
+	if cmd.Bool("json") {
+		fmt.Fprintln(w, prettyJSON(licenses))
+		return nil
+	}
+
+	fmt.Fprintf(w, "\033[1m%-21s %-12s %s\033[0m\n", "LICENSE", "TYPE", "NAME")
+	fmt.Fprintf(w, "%-21s %-12s %s\n", strings.Repeat("-", 19), strings.Repeat("-", 10), strings.Repeat("-", 40))
+	for _, l := range licenses {
+		fmt.Fprintf(w, "%-21s %-12s %s\n", l.ID, l.Type, l.Name)
+	}
+	if len(licenses) == 0 {
+		fmt.Fprintln(w, "\033[90m(no licenses found)\033[0m")
+	}
 	return nil
 }
 
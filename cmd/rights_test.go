@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GainForest/hypercerts-cli/internal/license"
+)
+
+func TestCheckRightsLicense(t *testing.T) {
+	tests := []struct {
+		name   string
+		record map[string]any
+		want   license.Status
+	}{
+		{"catalog", map[string]any{
+			"rightsType": "CC-BY-4.0",
+			"rightsName": "Creative Commons Attribution 4.0 International",
+			"attachment": map[string]any{"uri": "https://creativecommons.org/licenses/by/4.0/"},
+		}, license.StatusOK},
+		{"wrong attachment", map[string]any{
+			"rightsType": "CC-BY-4.0",
+			"rightsName": "CC-BY-4.0",
+			"attachment": map[string]any{"uri": "https://creativecommons.org/licenses/by-nc/4.0/"},
+		}, license.StatusInconsistent},
+		{"custom", map[string]any{"rightsType": "OWN", "rightsName": "Our terms"}, license.StatusCustom},
+	}
+	for _, tt := range tests {
+		if _, got, _ := checkRightsLicense(tt.record); got != tt.want {
+			t.Errorf("%s: status = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRightsLicenses(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildApp(&buf).Run(context.Background(), []string{"hc", "rights", "licenses", "sharealike"}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "CC-BY-SA-4.0") || !strings.Contains(out, "CC-BY-SA-4 ") || strings.Contains(out, "MIT") {
+		t.Errorf("rights licenses sharealike:\n%s", out)
+	}
+}
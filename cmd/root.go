@@ -8,6 +8,10 @@ import (
 
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/gitlab"
+	"github.com/GainForest/hypercerts-cli/internal/jetstream"
 )
 
 // version can be set at build time with -ldflags="-X github.com/GainForest/hypercerts-cli/cmd.version=X.Y.Z"
@@ -35,9 +39,12 @@ func ExecuteWithOutput(args []string, w io.Writer) error {
 	return app.Run(context.Background(), args)
 }
 
-// BuildApp creates the root CLI command with all subcommands.
+// BuildApp creates the root CLI command with all subcommands. Each call builds a
+// fresh command tree, so apps can run repeatedly in one process without sharing
+// flag state.
 func BuildApp(w io.Writer) *cli.Command {
-	return &cli.Command{
+	loader := &configLoader{}
+	app := &cli.Command{
 		Name:      "hc",
 		Usage:     "Hypercerts CLI - manage impact claims on ATProto",
 		Version:   Version,
@@ -46,6 +53,18 @@ func BuildApp(w io.Writer) *cli.Command {
 		ExitErrHandler: func(_ context.Context, _ *cli.Command, _ error) {
 			// Don't call os.Exit, let the error propagate
 		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			cfg := atproto.DefaultRetryConfig
+			cfg.MaxRetries = cmd.Int("max-retries")
+			cfg.Timeout = cmd.Duration("timeout")
+			atproto.SetRetryConfig(cfg)
+			atproto.FetchWorkers = cmd.Int("workers")
+			atproto.ConstellationBase = cmd.String("backlink-url")
+			if _, err := loader.load(); err != nil {
+				return ctx, err
+			}
+			return ctx, nil
+		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "log-level",
@@ -59,6 +78,30 @@ func BuildApp(w io.Writer) *cli.Command {
 				Value:   "https://plc.directory",
 				Sources: cli.EnvVars("ATP_PLC_HOST"),
 			},
+			&cli.StringFlag{
+				Name:    "backlink-url",
+				Usage:   "Constellation backlink index URL",
+				Value:   atproto.DefaultConstellationBase,
+				Sources: cli.EnvVars("HYPER_BACKLINK_URL"),
+			},
+			&cli.IntFlag{
+				Name:    "max-retries",
+				Usage:   "retries for rate-limited or failed PDS and backlink requests",
+				Value:   atproto.DefaultRetryConfig.MaxRetries,
+				Sources: cli.EnvVars("HYPER_MAX_RETRIES"),
+			},
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Usage:   "per-request timeout for PDS and backlink requests",
+				Value:   atproto.DefaultRetryConfig.Timeout,
+				Sources: cli.EnvVars("HYPER_TIMEOUT"),
+			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Usage:   "maximum concurrent record fetches",
+				Value:   atproto.DefaultFetchWorkers,
+				Sources: cli.EnvVars("HYPER_WORKERS"),
+			},
 			&cli.StringFlag{
 				Name:    "username",
 				Usage:   "handle or DID (ephemeral auth)",
@@ -72,976 +115,1370 @@ func BuildApp(w io.Writer) *cli.Command {
 		},
 		Commands: []*cli.Command{
 			// Top-level shortcuts
-			cmdGet,
-			cmdLs,
-			cmdResolve,
+			cmdGet(),
+			cmdLs(),
+			cmdResolve(),
+			cmdDoctor(),
+			cmdServe(),
+			cmdMCP(),
+			cmdWatch(),
+			cmdHook(),
 			// Auth & Account
-			cmdAccount,
+			cmdAccount(),
 			// Domain commands
-			cmdActivity,
-			cmdContributor,
-			cmdContribution,
-			cmdLocation,
-			cmdMeasurement,
-			cmdAttachment,
-			cmdAcknowledgement,
-			cmdRights,
-			cmdEvaluation,
-			cmdCollection,
-			cmdFunding,
-			cmdWorkScope,
-			cmdBadge,
-			cmdProfile,
-			cmdOrganization,
+			cmdActivity(),
+			cmdContributor(),
+			cmdContribution(),
+			cmdLocation(),
+			cmdMeasurement(),
+			cmdAttachment(),
+			cmdAcknowledgement(),
+			cmdRights(),
+			cmdEvaluation(),
+			cmdCollection(),
+			cmdFunding(),
+			cmdWorkScope(),
+			cmdBadge(),
+			cmdProfile(),
+			cmdOrganization(),
+			cmdConfig(),
+			cmdCompletion(),
+			{
+				Name:            "__complete",
+				Hidden:          true,
+				SkipFlagParsing: true,
+				Action:          runComplete,
+			},
 		},
 	}
+	loader.root = app
+	bindConfig(app, loader)
+	return app
 }
 
-// --- Top-level shortcuts ---
-
-var cmdGet = &cli.Command{
-	Name:      "get",
-	Usage:     "get a record by AT-URI",
-	ArgsUsage: "<at-uri>",
-	Action:    runRecordGet,
+// repoFlag lets read-only commands target another account's repo.
+func repoFlag() cli.Flag {
+	return &cli.StringFlag{Name: "repo", Usage: "handle or DID of the repo to read (default: logged-in account)"}
 }
 
-var cmdLs = &cli.Command{
-	Name:      "ls",
-	Aliases:   []string{"list"},
-	Usage:     "list records for an account",
-	ArgsUsage: "<at-identifier>",
-	Flags: []cli.Flag{
-		&cli.StringFlag{Name: "collection", Usage: "filter by collection NSID"},
-		&cli.BoolFlag{Name: "collections", Aliases: []string{"c"}, Usage: "list collection names only"},
-	},
-	Action: runRecordList,
+// importWeightFlags are the contributor weighting flags shared by repo imports.
+func importWeightFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "weight-by", Value: weightByCommits, Usage: "contributor weights for repo imports: commits, additions, prs, equal, or custom"},
+		&cli.StringFlag{Name: "weights", Usage: "CSV file of login,weight for --weight-by custom (unlisted contributors are skipped)"},
+		&cli.StringFlag{Name: "exclude", Usage: "comma-separated logins or identifiers to leave out of repo imports"},
+		&cli.BoolFlag{Name: "include-bots", Usage: "keep bot accounts (e.g. dependabot[bot]) in repo imports"},
+		&cli.FloatFlag{Name: "min-weight", Usage: "skip contributors whose weight is below this value"},
+	}
 }
 
-var cmdResolve = &cli.Command{
-	Name:      "resolve",
-	Usage:     "lookup identity metadata (DID document)",
-	ArgsUsage: "<at-identifier>",
-	Flags: []cli.Flag{
-		&cli.BoolFlag{Name: "did", Aliases: []string{"d"}, Usage: "just resolve to DID"},
-	},
-	Action: runResolve,
+// --- Top-level shortcuts ---
+
+func cmdGet() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "get a record by AT-URI",
+		ArgsUsage: "<at-uri>",
+		Action:    runRecordGet,
+	}
 }
 
-// --- Account ---
+func cmdLs() *cli.Command {
+	return &cli.Command{
+		Name:      "ls",
+		Aliases:   []string{"list"},
+		Usage:     "list records for an account",
+		ArgsUsage: "<at-identifier>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "collection", Usage: "filter by collection NSID"},
+			&cli.BoolFlag{Name: "collections", Aliases: []string{"c"}, Usage: "list collection names only"},
+		},
+		Action: runRecordList,
+	}
+}
 
-var cmdAccount = &cli.Command{
-	Name:  "account",
-	Usage: "auth session and account management",
-	Commands: []*cli.Command{
-		{
-			Name:  "login",
-			Usage: "create session with PDS",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "username", Aliases: []string{"u"}, Usage: "handle or DID", Required: true, Sources: cli.EnvVars("HYPER_USERNAME", "ATP_USERNAME")},
-				&cli.StringFlag{Name: "password", Aliases: []string{"p"}, Usage: "app password", Required: true, Sources: cli.EnvVars("HYPER_PASSWORD", "ATP_PASSWORD")},
-				&cli.StringFlag{Name: "pds-host", Usage: "override PDS URL", Sources: cli.EnvVars("ATP_PDS_HOST")},
-			},
-			Action: runAccountLogin,
+func cmdResolve() *cli.Command {
+	return &cli.Command{
+		Name:      "resolve",
+		Usage:     "lookup identity metadata (DID document)",
+		ArgsUsage: "<at-identifier>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "did", Aliases: []string{"d"}, Usage: "just resolve to DID"},
 		},
-		{
-			Name:   "logout",
-			Usage:  "delete current session",
-			Action: runAccountLogout,
+		Action: runResolve,
+	}
+}
+
+func cmdDoctor() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "check records for dangling refs, stale CIDs, orphans, and duplicates",
+		Flags: []cli.Flag{
+			repoFlag(),
+			&cli.BoolFlag{Name: "fix", Usage: "refresh stale CIDs in your own records"},
+			&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
 		},
-		{
-			Name:   "status",
-			Usage:  "check auth and account status",
-			Action: runAccountStatus,
+		Action: runDoctor,
+	}
+}
+
+func cmdServe() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "serve a token-protected REST API for the logged-in account",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "addr", Value: "127.0.0.1:8080", Usage: "listen address, e.g. :8080"},
+			&cli.StringFlag{Name: "token", Usage: "bearer token clients must send (default: generated at startup)", Sources: cli.EnvVars("HC_API_TOKEN")},
 		},
-	},
+		Action: runServe,
+	}
 }
 
-// --- Activity ---
+func cmdMCP() *cli.Command {
+	return &cli.Command{
+		Name:   "mcp",
+		Usage:  "serve Hypercerts tools to AI agents over the Model Context Protocol (stdio)",
+		Action: runMCP,
+	}
+}
 
-var cmdActivity = &cli.Command{
-	Name:  "activity",
-	Usage: "manage hypercert activities",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new activity (hypercert)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "title", Usage: "activity title"},
-				&cli.StringFlag{Name: "short-description", Usage: "short description (max 300 graphemes)"},
-				&cli.StringFlag{Name: "avatar", Usage: "avatar image URL"},
-				&cli.StringFlag{Name: "banner", Usage: "banner image URL"},
-				&cli.StringFlag{Name: "description", Usage: "longer description text"},
-				&cli.StringFlag{Name: "start-date", Usage: "start date (RFC3339 or YYYY-MM-DD)"},
-				&cli.StringFlag{Name: "end-date", Usage: "end date (RFC3339 or YYYY-MM-DD)"},
-				&cli.StringFlag{Name: "work-scope", Usage: "work scope string"},
-				&cli.StringFlag{Name: "work-scope-cel", Usage: "CEL work scope tag keys (comma-separated, e.g. mangrove_restoration,environmental_education)"},
-				&cli.StringFlag{Name: "from-github", Usage: "import from GitHub repo (owner/repo or URL)"},
-				&cli.StringFlag{Name: "github-token", Usage: "GitHub personal access token (for private repos)", Sources: cli.EnvVars("GITHUB_TOKEN")},
-			},
-			Action: runActivityCreate,
+func cmdWatch() *cli.Command {
+	return &cli.Command{
+		Name:  "watch",
+		Usage: "stream live changes to Hypercerts records from a Jetstream instance",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "url", Value: jetstream.DefaultURL, Usage: "Jetstream subscribe endpoint", Sources: cli.EnvVars("HC_JETSTREAM_URL")},
+			&cli.StringFlag{Name: "did", Usage: "only these repos (comma-separated handles or DIDs)"},
+			&cli.StringFlag{Name: "collection", Usage: "only these collections (comma-separated NSIDs, prefixes like org.hypercerts.context.*, or names like evaluations)"},
+			&cli.DurationFlag{Name: "since", Usage: "replay events from this long ago, e.g. 1h"},
+			&cli.BoolFlag{Name: "json", Usage: "output events as JSON lines"},
 		},
-		{
-			Name:      "edit",
-			Usage:     "edit an existing activity",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "title", Usage: "new title"},
-				&cli.StringFlag{Name: "short-description", Usage: "new short description (max 300 graphemes)"},
-				&cli.StringFlag{Name: "description", Usage: "new longer description"},
-				&cli.StringFlag{Name: "start-date", Usage: "new start date"},
-				&cli.StringFlag{Name: "end-date", Usage: "new end date"},
-				&cli.StringFlag{Name: "work-scope", Usage: "new work scope"},
-				&cli.StringFlag{Name: "work-scope-cel", Usage: "CEL work scope tag keys (comma-separated)"},
-				&cli.StringFlag{Name: "image", Usage: "new image URI"},
-				&cli.StringFlag{Name: "link-contributor", Usage: "replace a contributor identity with an inline DID (selects which contributor if multiple)"},
-			},
-			Action: runActivityEdit,
+		Action: runWatch,
+	}
+}
+
+func cmdConfig() *cli.Command {
+	accountFlag := func(usage string) cli.Flag {
+		return &cli.StringFlag{Name: "account", Usage: usage}
+	}
+	return &cli.Command{
+		Name:  "config",
+		Usage: "manage defaults in the config file (~/.config/hc/config.toml)",
+		Commands: []*cli.Command{
+			{
+				Name:      "get",
+				Usage:     "print the effective value of a setting",
+				ArgsUsage: "<key>",
+				Flags:     []cli.Flag{accountFlag("resolve for this handle or DID (default: logged-in account)")},
+				Action:    runConfigGet,
+			},
+			{
+				Name:      "set",
+				Usage:     "set a global default, or one for an account with --account",
+				ArgsUsage: "<key> <value>",
+				Flags:     []cli.Flag{accountFlag("set in this handle or DID's section")},
+				Action:    runConfigSet,
+			},
+			{
+				Name:      "unset",
+				Usage:     "remove a setting",
+				ArgsUsage: "<key>",
+				Flags:     []cli.Flag{accountFlag("remove from this handle or DID's section")},
+				Action:    runConfigUnset,
+			},
+			{
+				Name:    "list",
+				Aliases: []string{"ls"},
+				Usage:   "list settings with their effective values and sources",
+				Flags: []cli.Flag{
+					accountFlag("resolve for this handle or DID (default: logged-in account)"),
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+				},
+				Action: runConfigList,
+			},
 		},
-		{
-			Name:  "delete",
-			Usage: "delete activity and linked records",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "activity ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runActivityDelete,
+	}
+}
+
+func cmdCompletion() *cli.Command {
+	return &cli.Command{
+		Name:      "completion",
+		Usage:     "print a shell completion script (bash, zsh, or fish)",
+		ArgsUsage: "<bash|zsh|fish>",
+		Description: `Completes commands, flags, and record IDs, with titles shown as
+descriptions where the shell supports them. Record lists are cached for a
+few minutes under ~/.cache/hc/records.
+
+  bash: source <(hc completion bash)
+  zsh:  hc completion zsh > "${fpath[1]}/_hc"
+  fish: hc completion fish > ~/.config/fish/completions/hc.fish`,
+		Action: runCompletion,
+	}
+}
+
+func cmdHook() *cli.Command {
+	return &cli.Command{
+		Name:    "hook",
+		Aliases: []string{"hooks"},
+		Usage:   "run commands or webhooks when others' records reference your work",
+		Commands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "add a hook",
+				ArgsUsage: "<name>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "exec", Usage: "shell command to run; the event JSON is on stdin"},
+					&cli.StringFlag{Name: "post", Usage: "URL to POST the event JSON to"},
+					&cli.StringFlag{Name: "collection", Usage: "only these record types (comma-separated NSIDs or names; default: evaluations, acknowledgements, badge awards, funding receipts)"},
+					&cli.StringFlag{Name: "subject", Usage: "only records referencing these (comma-separated activity IDs or AT-URIs)"},
+					&cli.IntFlag{Name: "retries", Value: 3, Usage: "retries for a failed delivery"},
+				},
+				Action: runHookAdd,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list hooks",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+				},
+				Action: runHookList,
+			},
+			{
+				Name:      "rm",
+				Aliases:   []string{"remove"},
+				Usage:     "remove a hook",
+				ArgsUsage: "<name>",
+				Action:    runHookRemove,
+			},
+			{
+				Name:  "run",
+				Usage: "watch for new records and fire matching hooks, resuming where the last run stopped",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "url", Value: jetstream.DefaultURL, Usage: "Jetstream subscribe endpoint", Sources: cli.EnvVars("HC_JETSTREAM_URL")},
+					&cli.DurationFlag{Name: "since", Usage: "replay events from this long ago instead of the saved cursor, e.g. 24h"},
+				},
+				Action: runHookRun,
+			},
 		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list activities",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runActivityList,
+	}
+}
+
+// --- Account ---
+
+func cmdAccount() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "auth session and account management",
+		Commands: []*cli.Command{
+			{
+				Name:  "login",
+				Usage: "create session with PDS",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "username", Aliases: []string{"u"}, Usage: "handle or DID", Required: true, Sources: cli.EnvVars("HYPER_USERNAME", "ATP_USERNAME")},
+					&cli.StringFlag{Name: "password", Aliases: []string{"p"}, Usage: "app password", Required: true, Sources: cli.EnvVars("HYPER_PASSWORD", "ATP_PASSWORD")},
+					&cli.StringFlag{Name: "pds-host", Usage: "override PDS URL", Sources: cli.EnvVars("ATP_PDS_HOST")},
+				},
+				Action: runAccountLogin,
+			},
+			{
+				Name:   "logout",
+				Usage:  "delete current session",
+				Action: runAccountLogout,
+			},
+			{
+				Name:   "status",
+				Usage:  "check auth and account status",
+				Action: runAccountStatus,
+			},
 		},
-		{
-			Name:      "get",
-			Usage:     "get activity details (with backlinked records via Constellation)",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "measurements", Aliases: []string{"m"}, Usage: "show backlinked measurements"},
-				&cli.BoolFlag{Name: "attachments", Aliases: []string{"a"}, Usage: "show backlinked attachments"},
-				&cli.BoolFlag{Name: "evaluations", Aliases: []string{"e"}, Usage: "show backlinked evaluations"},
-				&cli.BoolFlag{Name: "all", Usage: "show all backlinked records"},
-				&cli.BoolFlag{Name: "json", Usage: "output backlinked records as JSON"},
-			},
-			Action: runActivityGet,
+	}
+}
+
+// --- Activity ---
+
+func cmdActivity() *cli.Command {
+	return &cli.Command{
+		Name:  "activity",
+		Usage: "manage hypercert activities",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new activity (hypercert)",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "title", Usage: "activity title"},
+					&cli.StringFlag{Name: "short-description", Usage: "short description (max 300 graphemes)"},
+					&cli.StringFlag{Name: "avatar", Usage: "avatar image URL"},
+					&cli.StringFlag{Name: "banner", Usage: "banner image URL"},
+					&cli.StringFlag{Name: "description", Usage: "longer description text"},
+					&cli.StringFlag{Name: "start-date", Usage: "start date (RFC3339 or YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "end-date", Usage: "end date (RFC3339 or YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "work-scope", Usage: "work scope string"},
+					&cli.StringFlag{Name: "work-scope-cel", Usage: "CEL work scope tag keys (comma-separated, e.g. mangrove_restoration,environmental_education)"},
+					&cli.StringFlag{Name: "from-github", Usage: "import from GitHub repo (owner/repo or URL)"},
+					&cli.StringFlag{Name: "github-token", Usage: "GitHub personal access token (for private repos)", Sources: cli.EnvVars("GITHUB_TOKEN")},
+					&cli.BoolFlag{Name: "deep", Usage: "with --from-github: also import releases as attachments and PR/issue/star/contributor counts as measurements"},
+					&cli.StringFlag{Name: "from-gitlab", Usage: "import from GitLab project (group/project or URL)"},
+					&cli.StringFlag{Name: "gitlab-url", Value: gitlab.DefaultBaseURL, Usage: "GitLab instance URL", Sources: cli.EnvVars("GITLAB_URL")},
+					&cli.StringFlag{Name: "gitlab-token", Usage: "GitLab personal access token (for private projects)", Sources: cli.EnvVars("GITLAB_TOKEN")},
+					&cli.StringFlag{Name: "from-gitea", Usage: "import from Gitea/Forgejo repo (owner/repo or URL)"},
+					&cli.StringFlag{Name: "gitea-url", Usage: "Gitea/Forgejo instance URL, e.g. https://codeberg.org", Sources: cli.EnvVars("GITEA_URL")},
+					&cli.StringFlag{Name: "gitea-token", Usage: "Gitea/Forgejo access token (for private repos)", Sources: cli.EnvVars("GITEA_TOKEN")},
+					&cli.StringFlag{Name: "from-git", Usage: "import from a local git repository path"},
+					&cli.StringFlag{Name: "git-authors", Usage: "CSV file mapping author emails to DIDs (email,did) for --from-git"},
+					&cli.BoolFlag{Name: "include-emails", Usage: "identify contributors without a DID or profile URL by commit email (published in public records)"},
+				}, importWeightFlags()...),
+				Action: runActivityCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit an existing activity",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "title", Usage: "new title"},
+					&cli.StringFlag{Name: "short-description", Usage: "new short description (max 300 graphemes)"},
+					&cli.StringFlag{Name: "description", Usage: "new longer description"},
+					&cli.StringFlag{Name: "start-date", Usage: "new start date"},
+					&cli.StringFlag{Name: "end-date", Usage: "new end date"},
+					&cli.StringFlag{Name: "work-scope", Usage: "new work scope"},
+					&cli.StringFlag{Name: "work-scope-cel", Usage: "CEL work scope tag keys (comma-separated)"},
+					&cli.StringFlag{Name: "image", Usage: "new image URI"},
+					&cli.StringFlag{Name: "link-contributor", Usage: "replace a contributor identity with an inline DID (selects which contributor if multiple)"},
+				},
+				Action: runActivityEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete activity and linked records",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "activity ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runActivityDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list activities",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runActivityList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get activity details (with backlinked records via Constellation)",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "measurements", Aliases: []string{"m"}, Usage: "show backlinked measurements"},
+					&cli.BoolFlag{Name: "attachments", Aliases: []string{"a"}, Usage: "show backlinked attachments"},
+					&cli.BoolFlag{Name: "evaluations", Aliases: []string{"e"}, Usage: "show backlinked evaluations"},
+					&cli.BoolFlag{Name: "all", Usage: "show all backlinked records"},
+					&cli.BoolFlag{Name: "json", Usage: "output backlinked records as JSON"},
+					repoFlag(),
+				},
+				Action: runActivityGet,
+			},
+			{
+				Name:      "contributors",
+				Usage:     "show contributors with weights, shares, and contribution details",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "normalize", Usage: "rewrite weights as percentages summing to 100"},
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runActivityContributors,
+			},
+			{
+				Name:      "sync-github",
+				Usage:     "re-sync contributors and end date of a GitHub-imported activity",
+				ArgsUsage: "<id|at-uri>",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "from-github", Usage: "GitHub repo (owner/repo or URL), if not recorded in the description"},
+					&cli.StringFlag{Name: "github-token", Usage: "GitHub personal access token (for private repos)", Sources: cli.EnvVars("GITHUB_TOKEN")},
+					&cli.BoolFlag{Name: "reweight", Usage: "also recompute existing contributors' weights with --weight-by (default: keep them)"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "show the diff without writing"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				}, importWeightFlags()...),
+				Action: runActivitySyncGitHub,
+			},
+			{
+				Name:      "import-github-org",
+				Usage:     "import every repo of a GitHub organization as an activity",
+				ArgsUsage: "<org>",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "github-token", Usage: "GitHub personal access token (for private repos)", Sources: cli.EnvVars("GITHUB_TOKEN")},
+					&cli.StringFlag{Name: "topic", Usage: "only repos with any of these comma-separated topics"},
+					&cli.StringFlag{Name: "language", Usage: "only repos in any of these comma-separated languages"},
+					&cli.BoolFlag{Name: "include-archived", Usage: "also import archived repos"},
+					&cli.BoolFlag{Name: "include-forks", Usage: "also import forks"},
+					&cli.StringFlag{Name: "collection", Usage: "group the activities into a collection with this title"},
+					&cli.StringFlag{Name: "collection-weight", Value: "commits", Usage: "collection item weights by activity size: commits, contributors, or equal"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "list the selected repos without importing"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				}, importWeightFlags()...),
+				Action: runActivityImportGitHubOrg,
+			},
 		},
-	},
+	}
 }
 
 // --- Contributor ---
 
-var cmdContributor = &cli.Command{
-	Name:    "contributor",
-	Aliases: []string{"contrib"},
-	Usage:   "manage contributor records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new contributor record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "identifier", Usage: "DID or profile URI"},
-				&cli.StringFlag{Name: "name", Usage: "display name (max 100 chars)"},
-				&cli.StringFlag{Name: "image", Usage: "image URL"},
-			},
-			Action: runContributorCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a contributor record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "identifier", Usage: "new identifier"},
-				&cli.StringFlag{Name: "name", Usage: "new display name"},
-				&cli.StringFlag{Name: "image", Usage: "new image URL"},
-			},
-			Action: runContributorEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete contributor record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "contributor ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runContributorDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list contributor records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runContributorList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get contributor details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runContributorGet,
+func cmdContributor() *cli.Command {
+	return &cli.Command{
+		Name:    "contributor",
+		Aliases: []string{"contrib"},
+		Usage:   "manage contributor records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new contributor record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "identifier", Usage: "DID or profile URI"},
+					&cli.StringFlag{Name: "name", Usage: "display name (max 100 chars)"},
+					&cli.StringFlag{Name: "image", Usage: "image URL"},
+				},
+				Action: runContributorCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a contributor record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "identifier", Usage: "new identifier"},
+					&cli.StringFlag{Name: "name", Usage: "new display name"},
+					&cli.StringFlag{Name: "image", Usage: "new image URL"},
+				},
+				Action: runContributorEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete contributor record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "contributor ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runContributorDelete,
+			},
+			{
+				Name:  "dedupe",
+				Usage: "find contributor records that are likely the same person",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runContributorDedupe,
+			},
+			{
+				Name:      "merge",
+				Usage:     "point activities at one contributor record and delete the duplicates",
+				ArgsUsage: "<keep> <drop...>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "dry-run", Usage: "show affected activities without writing"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runContributorMerge,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list contributor records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runContributorList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get contributor details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runContributorGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Contribution ---
 
-var cmdContribution = &cli.Command{
-	Name:  "contribution",
-	Usage: "manage contribution records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new contribution record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "role", Usage: "contribution role (max 100 chars)"},
-				&cli.StringFlag{Name: "description", Usage: "contribution description (max 10000 chars)"},
-				&cli.StringFlag{Name: "start-date", Usage: "start date (YYYY-MM-DD)"},
-				&cli.StringFlag{Name: "end-date", Usage: "end date (YYYY-MM-DD)"},
-			},
-			Action: runContributionCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a contribution record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "role", Usage: "new role"},
-				&cli.StringFlag{Name: "description", Usage: "new description"},
-				&cli.StringFlag{Name: "start-date", Usage: "new start date"},
-				&cli.StringFlag{Name: "end-date", Usage: "new end date"},
-			},
-			Action: runContributionEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete contribution record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "contribution ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runContributionDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list contribution records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runContributionList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get contribution details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runContributionGet,
+func cmdContribution() *cli.Command {
+	return &cli.Command{
+		Name:  "contribution",
+		Usage: "manage contribution records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new contribution record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "role", Usage: "contribution role (max 100 chars)"},
+					&cli.StringFlag{Name: "description", Usage: "contribution description (max 10000 chars)"},
+					&cli.StringFlag{Name: "start-date", Usage: "start date (YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "end-date", Usage: "end date (YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "activity", Usage: "activity ID or AT-URI whose contributor entry links to this contribution"},
+					&cli.StringFlag{Name: "contributor", Usage: "contributor ID, AT-URI, or DID (selects interactively if omitted)"},
+				},
+				Action: runContributionCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a contribution record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "role", Usage: "new role"},
+					&cli.StringFlag{Name: "description", Usage: "new description"},
+					&cli.StringFlag{Name: "start-date", Usage: "new start date"},
+					&cli.StringFlag{Name: "end-date", Usage: "new end date"},
+				},
+				Action: runContributionEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete contribution record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "contribution ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runContributionDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list contribution records",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "activity", Usage: "show who did what on an activity (ID or AT-URI)"},
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runContributionList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get contribution details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runContributionGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Measurement ---
 
-var cmdMeasurement = &cli.Command{
-	Name:    "measurement",
-	Aliases: []string{"meas"},
-	Usage:   "manage measurement records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new measurement record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "activity", Usage: "activity ID or AT-URI to link to"},
-				&cli.StringFlag{Name: "metric", Usage: "metric being measured"},
-				&cli.StringFlag{Name: "unit", Usage: "unit of measurement"},
-				&cli.StringFlag{Name: "value", Usage: "measured value"},
-				&cli.StringFlag{Name: "start-date", Usage: "start date (YYYY-MM-DD or RFC3339)"},
-				&cli.StringFlag{Name: "end-date", Usage: "end date (YYYY-MM-DD or RFC3339)"},
-				&cli.StringFlag{Name: "method-type", Usage: "methodology type"},
-				&cli.StringFlag{Name: "measurer", Usage: "DID of the measurer (can be specified multiple times or comma-separated)"},
-				&cli.StringFlag{Name: "evidence-uri", Usage: "URI to evidence document"},
-			},
-			Action: runMeasurementCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a measurement record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "metric", Usage: "new metric"},
-				&cli.StringFlag{Name: "unit", Usage: "new unit"},
-				&cli.StringFlag{Name: "value", Usage: "new value"},
-				&cli.StringFlag{Name: "start-date", Usage: "new start date"},
-				&cli.StringFlag{Name: "end-date", Usage: "new end date"},
-			},
-			Action: runMeasurementEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete measurement record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "measurement ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runMeasurementDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list measurement records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-				&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
-			},
-			Action: runMeasurementList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get measurement details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runMeasurementGet,
+func cmdMeasurement() *cli.Command {
+	return &cli.Command{
+		Name:    "measurement",
+		Aliases: []string{"meas"},
+		Usage:   "manage measurement records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new measurement record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "activity", Usage: "activity ID or AT-URI to link to"},
+					&cli.StringFlag{Name: "metric", Usage: "metric being measured"},
+					&cli.StringFlag{Name: "unit", Usage: "unit of measurement (normalized, e.g. \"tonnes CO2\" becomes tCO2e)"},
+					&cli.StringFlag{Name: "value", Usage: "measured value"},
+					&cli.StringFlag{Name: "start-date", Usage: "start date (YYYY-MM-DD or RFC3339)"},
+					&cli.StringFlag{Name: "end-date", Usage: "end date (YYYY-MM-DD or RFC3339)"},
+					&cli.StringFlag{Name: "method-type", Usage: "methodology type"},
+					&cli.StringFlag{Name: "measurer", Usage: "DID of the measurer (can be specified multiple times or comma-separated)"},
+					&cli.StringFlag{Name: "evidence-uri", Usage: "URI to evidence document"},
+				},
+				Action: runMeasurementCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a measurement record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "metric", Usage: "new metric"},
+					&cli.StringFlag{Name: "unit", Usage: "new unit"},
+					&cli.StringFlag{Name: "value", Usage: "new value"},
+					&cli.StringFlag{Name: "start-date", Usage: "new start date"},
+					&cli.StringFlag{Name: "end-date", Usage: "new end date"},
+				},
+				Action: runMeasurementEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete measurement record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "measurement ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runMeasurementDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list measurement records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
+					&cli.StringFlag{Name: "convert-to", Usage: "show values converted to this unit (e.g. tCO2e, ha)"},
+					repoFlag(),
+				},
+				Action: runMeasurementList,
+			},
+			{
+				Name:  "stats",
+				Usage: "aggregate measurements by metric, activity, collection, location, or time",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "group-by", Value: "metric", Usage: "comma-separated: metric, activity, collection, location, and one of month, quarter, year"},
+					&cli.StringFlag{Name: "activity", Usage: "only include measurements for this activity ID or AT-URI"},
+					&cli.StringFlag{Name: "metric", Usage: "only include metrics containing this text"},
+					&cli.StringFlag{Name: "from", Usage: "start of the date window (YYYY-MM-DD); periods are prorated"},
+					&cli.StringFlag{Name: "to", Usage: "end of the date window, inclusive (YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "convert-to", Usage: "convert values to this unit; incompatible measurements are skipped"},
+					&cli.StringFlag{Name: "format", Value: "table", Usage: "output format: table, csv, or json"},
+					repoFlag(),
+				},
+				Action: runMeasurementStats,
+			},
+			{
+				Name:      "get",
+				Usage:     "get measurement details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runMeasurementGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Location ---
 
-var cmdLocation = &cli.Command{
-	Name:    "location",
-	Aliases: []string{"loc"},
-	Usage:   "manage location records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new location record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "lat", Usage: "latitude (-90 to 90)"},
-				&cli.StringFlag{Name: "lon", Usage: "longitude (-180 to 180)"},
-				&cli.StringFlag{Name: "name", Usage: "location name (optional)"},
-				&cli.StringFlag{Name: "description", Usage: "location description (optional)"},
-			},
-			Action: runLocationCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a location record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "lat", Usage: "new latitude"},
-				&cli.StringFlag{Name: "lon", Usage: "new longitude"},
-				&cli.StringFlag{Name: "name", Usage: "new name"},
-				&cli.StringFlag{Name: "description", Usage: "new description"},
-			},
-			Action: runLocationEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete location record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "location ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runLocationDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list location records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runLocationList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get location details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runLocationGet,
+func cmdLocation() *cli.Command {
+	return &cli.Command{
+		Name:    "location",
+		Aliases: []string{"loc"},
+		Usage:   "manage location records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new location record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "lat", Usage: "latitude (-90 to 90)"},
+					&cli.StringFlag{Name: "lon", Usage: "longitude (-180 to 180)"},
+					&cli.StringFlag{Name: "name", Usage: "location name (optional)"},
+					&cli.StringFlag{Name: "description", Usage: "location description (optional)"},
+				},
+				Action: runLocationCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a location record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "lat", Usage: "new latitude"},
+					&cli.StringFlag{Name: "lon", Usage: "new longitude"},
+					&cli.StringFlag{Name: "name", Usage: "new name"},
+					&cli.StringFlag{Name: "description", Usage: "new description"},
+				},
+				Action: runLocationEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete location record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "location ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runLocationDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list location records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runLocationList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get location details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runLocationGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Attachment ---
 
-var cmdAttachment = &cli.Command{
-	Name:    "attachment",
-	Aliases: []string{"attach"},
-	Usage:   "manage attachment records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new attachment record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "activity", Usage: "activity ID(s) to link, comma-separated"},
-				&cli.StringFlag{Name: "title", Usage: "attachment title"},
-				&cli.StringFlag{Name: "content-type", Usage: "content type (report, audit, evidence, testimonial, methodology)"},
-				&cli.StringFlag{Name: "uri", Usage: "content URI(s), comma-separated"},
-			},
-			Action: runAttachmentCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit an attachment record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "title", Usage: "new title"},
-				&cli.StringFlag{Name: "content-type", Usage: "new content type"},
-			},
-			Action: runAttachmentEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete attachment record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "attachment ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runAttachmentDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list attachment records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-				&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
-			},
-			Action: runAttachmentList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get attachment details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runAttachmentGet,
+func cmdAttachment() *cli.Command {
+	return &cli.Command{
+		Name:    "attachment",
+		Aliases: []string{"attach"},
+		Usage:   "manage attachment records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new attachment record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "activity", Usage: "activity ID(s) to link, comma-separated"},
+					&cli.StringFlag{Name: "title", Usage: "attachment title"},
+					&cli.StringFlag{Name: "content-type", Usage: "content type (report, audit, evidence, testimonial, methodology)"},
+					&cli.StringFlag{Name: "uri", Usage: "content URI(s), comma-separated"},
+				},
+				Action: runAttachmentCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit an attachment record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "title", Usage: "new title"},
+					&cli.StringFlag{Name: "content-type", Usage: "new content type"},
+				},
+				Action: runAttachmentEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete attachment record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "attachment ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runAttachmentDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list attachment records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
+					repoFlag(),
+				},
+				Action: runAttachmentList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get attachment details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runAttachmentGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Acknowledgement ---
 
-var cmdAcknowledgement = &cli.Command{
-	Name:    "acknowledgement",
-	Aliases: []string{"ack"},
-	Usage:   "manage acknowledgement records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new acknowledgement record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "subject", Usage: "subject AT-URI (required)"},
-				&cli.StringFlag{Name: "context", Usage: "context AT-URI (optional)"},
-				&cli.BoolFlag{Name: "acknowledged", Usage: "acknowledge (default true)"},
-				&cli.BoolFlag{Name: "rejected", Usage: "reject (sets acknowledged=false)"},
-				&cli.StringFlag{Name: "comment", Usage: "optional comment (max 10000 chars)"},
-			},
-			Action: runAcknowledgementCreate,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete acknowledgement record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "acknowledgement ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runAcknowledgementDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list acknowledgement records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runAcknowledgementList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get acknowledgement details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runAcknowledgementGet,
+func cmdAcknowledgement() *cli.Command {
+	return &cli.Command{
+		Name:    "acknowledgement",
+		Aliases: []string{"ack"},
+		Usage:   "manage acknowledgement records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new acknowledgement record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "subject", Usage: "subject AT-URI (required)"},
+					&cli.StringFlag{Name: "context", Usage: "context AT-URI (optional)"},
+					&cli.BoolFlag{Name: "acknowledged", Usage: "acknowledge (default true)"},
+					&cli.BoolFlag{Name: "rejected", Usage: "reject (sets acknowledged=false)"},
+					&cli.StringFlag{Name: "comment", Usage: "optional comment (max 10000 chars)"},
+				},
+				Action: runAcknowledgementCreate,
+			},
+			{
+				Name:  "inbox",
+				Usage: "list records in other repos that reference your work and are not yet acknowledged",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "review", Usage: "acknowledge, reject, or skip each record interactively"},
+					&cli.StringFlag{Name: "ack", Usage: "acknowledge the referencing record at this AT-URI"},
+					&cli.StringFlag{Name: "reject", Usage: "reject the referencing record at this AT-URI"},
+					&cli.StringFlag{Name: "comment", Usage: "comment for --ack or --reject (max 10000 chars)"},
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+				},
+				Action: runAcknowledgementInbox,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete acknowledgement record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "acknowledgement ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runAcknowledgementDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list acknowledgement records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runAcknowledgementList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get acknowledgement details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runAcknowledgementGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Rights ---
 
-var cmdRights = &cli.Command{
-	Name:  "rights",
-	Usage: "manage rights records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new rights record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "name", Usage: "rights name (max 100 chars)"},
-				&cli.StringFlag{Name: "type", Usage: "rights type short ID (max 10 chars, e.g. CC-BY-4.0)"},
-				&cli.StringFlag{Name: "description", Usage: "rights description"},
-				&cli.StringFlag{Name: "attachment", Usage: "attachment URI (legal document)"},
-			},
-			Action: runRightsCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a rights record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "name", Usage: "new rights name"},
-				&cli.StringFlag{Name: "type", Usage: "new rights type"},
-				&cli.StringFlag{Name: "description", Usage: "new description"},
-			},
-			Action: runRightsEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete rights record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "rights ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runRightsDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list rights records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runRightsList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get rights details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runRightsGet,
+func cmdRights() *cli.Command {
+	return &cli.Command{
+		Name:  "rights",
+		Usage: "manage rights records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new rights record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "license", Usage: "SPDX license ID from the catalog (e.g. CC-BY-4.0); fills name, type, description, and attachment"},
+					&cli.StringFlag{Name: "name", Usage: "rights name (max 100 chars)"},
+					&cli.StringFlag{Name: "type", Usage: "rights type short ID (max 10 chars, e.g. CC-BY-4.0)"},
+					&cli.StringFlag{Name: "description", Usage: "rights description"},
+					&cli.StringFlag{Name: "attachment", Usage: "attachment URI (legal document)"},
+				},
+				Action: runRightsCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a rights record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "name", Usage: "new rights name"},
+					&cli.StringFlag{Name: "type", Usage: "new rights type"},
+					&cli.StringFlag{Name: "description", Usage: "new description"},
+				},
+				Action: runRightsEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete rights record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "rights ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runRightsDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list rights records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runRightsList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get rights details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runRightsGet,
+			},
+			{
+				Name:      "licenses",
+				Usage:     "list the bundled SPDX and Creative Commons license catalog",
+				ArgsUsage: "[query]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+				},
+				Action: runRightsLicenses,
+			},
 		},
-	},
+	}
 }
 
 // --- Evaluation ---
 
-var cmdEvaluation = &cli.Command{
-	Name:    "evaluation",
-	Aliases: []string{"eval"},
-	Usage:   "manage evaluation records",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new evaluation record",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "summary", Usage: "evaluation summary"},
-			},
-			Action: runEvaluationCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit an evaluation record",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "summary", Usage: "new summary"},
-			},
-			Action: runEvaluationEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete evaluation record(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "evaluation ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runEvaluationDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list evaluation records",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runEvaluationList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get evaluation details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runEvaluationGet,
+func cmdEvaluation() *cli.Command {
+	return &cli.Command{
+		Name:    "evaluation",
+		Aliases: []string{"eval"},
+		Usage:   "manage evaluation records",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new evaluation record",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "summary", Usage: "evaluation summary"},
+				},
+				Action: runEvaluationCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit an evaluation record",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "summary", Usage: "new summary"},
+				},
+				Action: runEvaluationEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete evaluation record(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "evaluation ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runEvaluationDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list evaluation records",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runEvaluationList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get evaluation details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runEvaluationGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Collection ---
 
-var cmdCollection = &cli.Command{
-	Name:    "collection",
-	Aliases: []string{"coll"},
-	Usage:   "manage collection records (project groupings)",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new collection",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "title", Usage: "collection title"},
-				&cli.StringFlag{Name: "type", Usage: "collection type (e.g. project, favorites)"},
-				&cli.StringFlag{Name: "short-description", Usage: "short description (max 300 graphemes)"},
-				&cli.StringFlag{Name: "avatar", Usage: "avatar image URL"},
-				&cli.StringFlag{Name: "banner", Usage: "banner image URL"},
-			},
-			Action: runCollectionCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a collection",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "title", Usage: "new title"},
-				&cli.StringFlag{Name: "type", Usage: "new type"},
-				&cli.StringFlag{Name: "short-description", Usage: "new short description (max 300 graphemes)"},
-				&cli.StringFlag{Name: "avatar", Usage: "new avatar image URL"},
-				&cli.StringFlag{Name: "banner", Usage: "new banner image URL"},
-			},
-			Action: runCollectionEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete collection(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "collection ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runCollectionDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list collections",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-			},
-			Action: runCollectionList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get collection details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runCollectionGet,
+func cmdCollection() *cli.Command {
+	return &cli.Command{
+		Name:    "collection",
+		Aliases: []string{"coll"},
+		Usage:   "manage collection records (project groupings)",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new collection",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "title", Usage: "collection title"},
+					&cli.StringFlag{Name: "type", Usage: "collection type (e.g. project, favorites)"},
+					&cli.StringFlag{Name: "short-description", Usage: "short description (max 300 graphemes)"},
+					&cli.StringFlag{Name: "avatar", Usage: "avatar image URL"},
+					&cli.StringFlag{Name: "banner", Usage: "banner image URL"},
+				},
+				Action: runCollectionCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a collection",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "title", Usage: "new title"},
+					&cli.StringFlag{Name: "type", Usage: "new type"},
+					&cli.StringFlag{Name: "short-description", Usage: "new short description (max 300 graphemes)"},
+					&cli.StringFlag{Name: "avatar", Usage: "new avatar image URL"},
+					&cli.StringFlag{Name: "banner", Usage: "new banner image URL"},
+				},
+				Action: runCollectionEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete collection(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "collection ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runCollectionDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list collections",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runCollectionList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get collection details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runCollectionGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Funding ---
 
-var cmdFunding = &cli.Command{
-	Name:    "funding",
-	Aliases: []string{"fund"},
-	Usage:   "manage funding receipts",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new funding receipt",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "from", Usage: "sender DID"},
-				&cli.StringFlag{Name: "to", Usage: "recipient (DID or name)"},
-				&cli.StringFlag{Name: "amount", Usage: "amount"},
-				&cli.StringFlag{Name: "currency", Usage: "currency (USD, EUR, ETH)"},
-				&cli.StringFlag{Name: "rail", Usage: "payment rail (bank_transfer, credit_card, onchain, cash)"},
-				&cli.StringFlag{Name: "network", Usage: "payment network (arbitrum, ethereum, sepa, visa)"},
-				&cli.StringFlag{Name: "tx-id", Usage: "transaction ID"},
-				&cli.StringFlag{Name: "for", Usage: "activity ID to link to"},
-				&cli.StringFlag{Name: "notes", Usage: "additional notes"},
-			},
-			Action: runFundingCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a funding receipt",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runFundingEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete funding receipt(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "funding receipt ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runFundingDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list funding receipts",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-				&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
-			},
-			Action: runFundingList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get funding receipt details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runFundingGet,
+func cmdFunding() *cli.Command {
+	return &cli.Command{
+		Name:    "funding",
+		Aliases: []string{"fund"},
+		Usage:   "manage funding receipts",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new funding receipt",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "from", Usage: "sender DID"},
+					&cli.StringFlag{Name: "to", Usage: "recipient (DID or name)"},
+					&cli.StringFlag{Name: "amount", Usage: "amount"},
+					&cli.StringFlag{Name: "currency", Usage: "currency (USD, EUR, ETH)"},
+					&cli.StringFlag{Name: "rail", Usage: "payment rail (bank_transfer, credit_card, onchain, cash)"},
+					&cli.StringFlag{Name: "network", Usage: "payment network (arbitrum, ethereum, sepa, visa)"},
+					&cli.StringFlag{Name: "tx-id", Usage: "transaction ID"},
+					&cli.StringFlag{Name: "for", Usage: "activity ID to link to"},
+					&cli.StringFlag{Name: "notes", Usage: "additional notes"},
+				},
+				Action: runFundingCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a funding receipt",
+				ArgsUsage: "<id|at-uri>",
+				Action:    runFundingEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete funding receipt(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "funding receipt ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runFundingDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list funding receipts",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					&cli.StringFlag{Name: "activity", Usage: "filter by activity ID or AT-URI"},
+					repoFlag(),
+				},
+				Action: runFundingList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get funding receipt details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runFundingGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Work Scope ---
 
-var cmdWorkScope = &cli.Command{
-	Name:    "workscope",
-	Aliases: []string{"ws"},
-	Usage:   "manage work scope tags",
-	Commands: []*cli.Command{
-		{
-			Name:  "create",
-			Usage: "create a new work scope tag",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "key", Usage: "lowercase, underscore-separated key (e.g. climate_action)"},
-				&cli.StringFlag{Name: "name", Usage: "human-readable name"},
-				&cli.StringFlag{Name: "category", Usage: "category: topic, language, domain, method, tag"},
-				&cli.StringFlag{Name: "description", Usage: "description"},
-				&cli.StringFlag{Name: "parent", Usage: "parent tag ID for hierarchy"},
-			},
-			Action: runWorkScopeCreate,
-		},
-		{
-			Name:      "edit",
-			Usage:     "edit a work scope tag",
-			ArgsUsage: "<id|at-uri>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "key", Usage: "new key"},
-				&cli.StringFlag{Name: "name", Usage: "new name"},
-				&cli.StringFlag{Name: "category", Usage: "new category"},
-				&cli.StringFlag{Name: "description", Usage: "new description"},
-			},
-			Action: runWorkScopeEdit,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete work scope tag(s)",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "id", Usage: "work scope tag ID, or select interactively"},
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
-			},
-			Action: runWorkScopeDelete,
-		},
-		{
-			Name:    "ls",
-			Aliases: []string{"list"},
-			Usage:   "list work scope tags",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
-				&cli.StringFlag{Name: "category", Usage: "filter by category"},
-			},
-			Action: runWorkScopeList,
-		},
-		{
-			Name:      "get",
-			Usage:     "get work scope tag details",
-			ArgsUsage: "<id|at-uri>",
-			Action:    runWorkScopeGet,
+func cmdWorkScope() *cli.Command {
+	return &cli.Command{
+		Name:    "workscope",
+		Aliases: []string{"ws"},
+		Usage:   "manage work scope tags",
+		Commands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "create a new work scope tag",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "key", Usage: "lowercase, underscore-separated key (e.g. climate_action)"},
+					&cli.StringFlag{Name: "name", Usage: "human-readable name"},
+					&cli.StringFlag{Name: "category", Usage: "category: topic, language, domain, method, tag"},
+					&cli.StringFlag{Name: "description", Usage: "description"},
+					&cli.StringFlag{Name: "parent", Usage: "parent tag ID for hierarchy"},
+				},
+				Action: runWorkScopeCreate,
+			},
+			{
+				Name:      "edit",
+				Usage:     "edit a work scope tag",
+				ArgsUsage: "<id|at-uri>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "key", Usage: "new key"},
+					&cli.StringFlag{Name: "name", Usage: "new name"},
+					&cli.StringFlag{Name: "category", Usage: "new category"},
+					&cli.StringFlag{Name: "description", Usage: "new description"},
+				},
+				Action: runWorkScopeEdit,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete work scope tag(s)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "work scope tag ID, or select interactively"},
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runWorkScopeDelete,
+			},
+			{
+				Name:    "ls",
+				Aliases: []string{"list"},
+				Usage:   "list work scope tags",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					&cli.StringFlag{Name: "category", Usage: "filter by category"},
+					repoFlag(),
+				},
+				Action: runWorkScopeList,
+			},
+			{
+				Name:      "get",
+				Usage:     "get work scope tag details",
+				ArgsUsage: "<id|at-uri>",
+				Flags:     []cli.Flag{repoFlag()},
+				Action:    runWorkScopeGet,
+			},
 		},
-	},
+	}
 }
 
 // --- Badge ---
 
-var cmdBadge = &cli.Command{
-	Name:  "badge",
-	Usage: "manage badge definitions, awards, and responses",
-	Commands: []*cli.Command{
-		{
-			Name:  "definition",
-			Usage: "manage badge definitions",
-			Commands: []*cli.Command{
-				{
-					Name:  "create",
-					Usage: "create a new badge definition",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "title", Usage: "badge title (max 256 chars)"},
-						&cli.StringFlag{Name: "type", Usage: "badge type (max 100 chars)"},
-						&cli.StringFlag{Name: "description", Usage: "description (max 5000 chars)"},
+func cmdBadge() *cli.Command {
+	return &cli.Command{
+		Name:  "badge",
+		Usage: "manage badge definitions, awards, and responses",
+		Commands: []*cli.Command{
+			{
+				Name:  "inbox",
+				Usage: "list badges awarded to you by others, and accept or reject them",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "pending", Usage: "only show awards you have not responded to"},
+					&cli.BoolFlag{Name: "accept", Usage: "accept selected pending awards"},
+					&cli.BoolFlag{Name: "reject", Usage: "reject selected pending awards"},
+					&cli.BoolFlag{Name: "all", Usage: "with --accept or --reject, respond to every pending award without prompting"},
+					&cli.IntFlag{Name: "batch-size", Value: 50, Usage: "responses written per request"},
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+				},
+				Action: runBadgeInbox,
+			},
+			{
+				Name:  "definition",
+				Usage: "manage badge definitions",
+				Commands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "create a new badge definition",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "title", Usage: "badge title (max 256 chars)"},
+							&cli.StringFlag{Name: "type", Usage: "badge type (max 100 chars)"},
+							&cli.StringFlag{Name: "description", Usage: "description (max 5000 chars)"},
+						},
+						Action: runBadgeDefinitionCreate,
+					},
+					{
+						Name:    "ls",
+						Aliases: []string{"list"},
+						Usage:   "list badge definitions",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+							repoFlag(),
+						},
+						Action: runBadgeDefinitionList,
 					},
-					Action: runBadgeDefinitionCreate,
-				},
-				{
-					Name:    "ls",
-					Aliases: []string{"list"},
-					Usage:   "list badge definitions",
-					Flags: []cli.Flag{
-						&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					{
+						Name:      "get",
+						Usage:     "get badge definition details",
+						ArgsUsage: "<id|at-uri>",
+						Flags:     []cli.Flag{repoFlag()},
+						Action:    runBadgeDefinitionGet,
 					},
-					Action: runBadgeDefinitionList,
-				},
-				{
-					Name:      "get",
-					Usage:     "get badge definition details",
-					ArgsUsage: "<id|at-uri>",
-					Action:    runBadgeDefinitionGet,
-				},
-				{
-					Name:  "delete",
-					Usage: "delete badge definition(s)",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "id", Usage: "badge definition ID, or select interactively"},
-						&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+					{
+						Name:  "delete",
+						Usage: "delete badge definition(s)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "id", Usage: "badge definition ID, or select interactively"},
+							&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+						},
+						Action: runBadgeDefinitionDelete,
 					},
-					Action: runBadgeDefinitionDelete,
 				},
 			},
-		},
-		{
-			Name:  "award",
-			Usage: "manage badge awards",
-			Commands: []*cli.Command{
-				{
-					Name:  "create",
-					Usage: "create a new badge award",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "badge", Usage: "badge definition AT-URI"},
-						&cli.StringFlag{Name: "subject", Usage: "recipient DID or AT-URI"},
-						&cli.StringFlag{Name: "note", Usage: "optional note (max 500 chars)"},
-						&cli.StringFlag{Name: "url", Usage: "optional URL (max 2048 chars)"},
+			{
+				Name:  "award",
+				Usage: "manage badge awards",
+				Commands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "create a new badge award",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "badge", Usage: "badge definition AT-URI"},
+							&cli.StringFlag{Name: "subject", Usage: "recipient DID or AT-URI"},
+							&cli.StringFlag{Name: "note", Usage: "optional note (max 500 chars)"},
+							&cli.StringFlag{Name: "url", Usage: "optional URL (max 2048 chars)"},
+						},
+						Action: runBadgeAwardCreate,
+					},
+					{
+						Name:  "bulk",
+						Usage: "award a badge to every recipient in a CSV file",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "badge", Usage: "badge definition ID or AT-URI, or select interactively"},
+							&cli.StringFlag{Name: "recipients", Usage: "CSV file: handle or DID, optional note, optional url per row"},
+							&cli.StringFlag{Name: "note", Usage: "note for rows without one (max 500 chars)"},
+							&cli.StringFlag{Name: "url", Usage: "URL for rows without one (max 2048 chars)"},
+							&cli.IntFlag{Name: "batch-size", Value: 50, Usage: "awards written per request"},
+							&cli.BoolFlag{Name: "dry-run", Usage: "show who would be awarded without writing records"},
+						},
+						Action: runBadgeAwardBulk,
+					},
+					{
+						Name:    "ls",
+						Aliases: []string{"list"},
+						Usage:   "list badge awards",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+							repoFlag(),
+						},
+						Action: runBadgeAwardList,
 					},
-					Action: runBadgeAwardCreate,
-				},
-				{
-					Name:    "ls",
-					Aliases: []string{"list"},
-					Usage:   "list badge awards",
-					Flags: []cli.Flag{
-						&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					{
+						Name:      "get",
+						Usage:     "get badge award details",
+						ArgsUsage: "<id|at-uri>",
+						Flags:     []cli.Flag{repoFlag()},
+						Action:    runBadgeAwardGet,
 					},
-					Action: runBadgeAwardList,
-				},
-				{
-					Name:      "get",
-					Usage:     "get badge award details",
-					ArgsUsage: "<id|at-uri>",
-					Action:    runBadgeAwardGet,
-				},
-				{
-					Name:  "delete",
-					Usage: "delete badge award(s)",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "id", Usage: "badge award ID, or select interactively"},
-						&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+					{
+						Name:  "delete",
+						Usage: "delete badge award(s)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "id", Usage: "badge award ID, or select interactively"},
+							&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+						},
+						Action: runBadgeAwardDelete,
 					},
-					Action: runBadgeAwardDelete,
 				},
 			},
-		},
-		{
-			Name:  "response",
-			Usage: "manage badge responses",
-			Commands: []*cli.Command{
-				{
-					Name:  "create",
-					Usage: "create a new badge response",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "badge-award", Usage: "badge award AT-URI"},
-						&cli.StringFlag{Name: "response", Usage: "accepted or rejected"},
-						&cli.StringFlag{Name: "weight", Usage: "optional weight (max 50 chars)"},
+			{
+				Name:  "response",
+				Usage: "manage badge responses",
+				Commands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "create a new badge response",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "badge-award", Usage: "badge award AT-URI"},
+							&cli.StringFlag{Name: "response", Usage: "accepted or rejected"},
+							&cli.StringFlag{Name: "weight", Usage: "optional weight (max 50 chars)"},
+						},
+						Action: runBadgeResponseCreate,
+					},
+					{
+						Name:    "ls",
+						Aliases: []string{"list"},
+						Usage:   "list badge responses",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+							repoFlag(),
+						},
+						Action: runBadgeResponseList,
 					},
-					Action: runBadgeResponseCreate,
-				},
-				{
-					Name:    "ls",
-					Aliases: []string{"list"},
-					Usage:   "list badge responses",
-					Flags: []cli.Flag{
-						&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					{
+						Name:      "get",
+						Usage:     "get badge response details",
+						ArgsUsage: "<id|at-uri>",
+						Flags:     []cli.Flag{repoFlag()},
+						Action:    runBadgeResponseGet,
 					},
-					Action: runBadgeResponseList,
-				},
-				{
-					Name:      "get",
-					Usage:     "get badge response details",
-					ArgsUsage: "<id|at-uri>",
-					Action:    runBadgeResponseGet,
-				},
-				{
-					Name:  "delete",
-					Usage: "delete badge response(s)",
-					Flags: []cli.Flag{
-						&cli.StringFlag{Name: "id", Usage: "badge response ID, or select interactively"},
-						&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+					{
+						Name:  "delete",
+						Usage: "delete badge response(s)",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "id", Usage: "badge response ID, or select interactively"},
+							&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+						},
+						Action: runBadgeResponseDelete,
 					},
-					Action: runBadgeResponseDelete,
 				},
 			},
 		},
-	},
+	}
 }
 
-var cmdProfile = &cli.Command{
-	Name:  "profile",
-	Usage: "manage actor profile (singleton record)",
-	Commands: []*cli.Command{
-		{
-			Name:  "set",
-			Usage: "create or update profile",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "display-name", Usage: "display name (max 64 graphemes)"},
-				&cli.StringFlag{Name: "description", Usage: "profile description (max 256 graphemes)"},
-				&cli.StringFlag{Name: "pronouns", Usage: "pronouns (max 20 graphemes)"},
-				&cli.StringFlag{Name: "website", Usage: "website URI"},
-			},
-			Action: runProfileSet,
-		},
-		{
-			Name:  "get",
-			Usage: "get profile details",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+func cmdProfile() *cli.Command {
+	return &cli.Command{
+		Name:  "profile",
+		Usage: "manage actor profile (singleton record)",
+		Commands: []*cli.Command{
+			{
+				Name:  "set",
+				Usage: "create or update profile",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "display-name", Usage: "display name (max 64 graphemes)"},
+					&cli.StringFlag{Name: "description", Usage: "profile description (max 256 graphemes)"},
+					&cli.StringFlag{Name: "pronouns", Usage: "pronouns (max 20 graphemes)"},
+					&cli.StringFlag{Name: "website", Usage: "website URI"},
+				},
+				Action: runProfileSet,
 			},
-			Action: runProfileGet,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete profile",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+			{
+				Name:  "get",
+				Usage: "get profile details",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runProfileGet,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete profile",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runProfileDelete,
 			},
-			Action: runProfileDelete,
 		},
-	},
+	}
 }
 
-var cmdOrganization = &cli.Command{
-	Name:    "organization",
-	Aliases: []string{"org"},
-	Usage:   "manage organization metadata (singleton record)",
-	Commands: []*cli.Command{
-		{
-			Name:  "set",
-			Usage: "create or update organization",
-			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "type", Usage: "organization type(s), comma-separated (e.g., nonprofit,ngo)"},
-				&cli.StringFlag{Name: "founded-date", Usage: "founded date (YYYY-MM-DD)"},
-				&cli.StringFlag{Name: "url", Usage: "organization URL"},
-				&cli.StringFlag{Name: "url-label", Usage: "label for the URL"},
-			},
-			Action: runOrganizationSet,
-		},
-		{
-			Name:  "get",
-			Usage: "get organization details",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+func cmdOrganization() *cli.Command {
+	return &cli.Command{
+		Name:    "organization",
+		Aliases: []string{"org"},
+		Usage:   "manage organization metadata (singleton record)",
+		Commands: []*cli.Command{
+			{
+				Name:  "set",
+				Usage: "create or update organization",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "type", Usage: "organization type(s), comma-separated (e.g., nonprofit,ngo)"},
+					&cli.StringFlag{Name: "founded-date", Usage: "founded date (YYYY-MM-DD)"},
+					&cli.StringFlag{Name: "url", Usage: "organization URL"},
+					&cli.StringFlag{Name: "url-label", Usage: "label for the URL"},
+				},
+				Action: runOrganizationSet,
 			},
-			Action: runOrganizationGet,
-		},
-		{
-			Name:  "delete",
-			Usage: "delete organization",
-			Flags: []cli.Flag{
-				&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+			{
+				Name:  "get",
+				Usage: "get organization details",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "json", Usage: "output as JSON"},
+					repoFlag(),
+				},
+				Action: runOrganizationGet,
+			},
+			{
+				Name:  "delete",
+				Usage: "delete organization",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Usage: "skip confirmation"},
+				},
+				Action: runOrganizationDelete,
 			},
-			Action: runOrganizationDelete,
 		},
-	},
+	}
 }
@@ -0,0 +1,666 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// serveResource maps a REST path segment to a record collection and the
+// command whose `ls --json` output lists it.
+type serveResource struct {
+	Name       string   // path segment, e.g. "activities"
+	Collection string   // record NSID
+	List       []string // command path of the ls command, nil to list raw records
+}
+
+var serveResources = []serveResource{
+	{"activities", atproto.CollectionActivity, []string{"activity", "ls"}},
+	{"contributors", atproto.CollectionContributorInfo, []string{"contributor", "ls"}},
+	{"contributions", atproto.CollectionContribution, []string{"contribution", "ls"}},
+	{"measurements", atproto.CollectionMeasurement, []string{"measurement", "ls"}},
+	{"locations", atproto.CollectionLocation, []string{"location", "ls"}},
+	{"attachments", atproto.CollectionAttachment, []string{"attachment", "ls"}},
+	{"acknowledgements", atproto.CollectionAcknowledgement, []string{"acknowledgement", "ls"}},
+	{"rights", atproto.CollectionRights, []string{"rights", "ls"}},
+	{"evaluations", atproto.CollectionEvaluation, []string{"evaluation", "ls"}},
+	{"collections", atproto.CollectionCollection, []string{"collection", "ls"}},
+	{"funding-receipts", atproto.CollectionFundingReceipt, []string{"funding", "ls"}},
+	{"workscopes", atproto.CollectionWorkScopeTag, []string{"workscope", "ls"}},
+	{"badge-definitions", atproto.CollectionBadgeDefinition, []string{"badge", "definition", "ls"}},
+	{"badge-awards", atproto.CollectionBadgeAward, []string{"badge", "award", "ls"}},
+	{"badge-responses", atproto.CollectionBadgeResponse, []string{"badge", "response", "ls"}},
+	{"profiles", atproto.CollectionActorProfile, nil},
+	{"organizations", atproto.CollectionActorOrganization, nil},
+}
+
+// serveReport is a read-only report backed by a command with JSON output.
+type serveReport struct {
+	Name  string
+	Usage string
+	Path  []string // command path
+	JSON  []string // flags that select JSON output
+}
+
+var serveReports = []serveReport{
+	{"doctor", "referential integrity check (see hc doctor)", []string{"doctor"}, []string{"--json"}},
+	{"measurement-stats", "aggregated measurements (see hc measurement stats)", []string{"measurement", "stats"}, []string{"--format", "json"}},
+}
+
+func findServeResource(name string) (serveResource, bool) {
+	for _, r := range serveResources {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return serveResource{}, false
+}
+
+// apiServer serves the REST API. Reads run the CLI's own commands in-process
+// with JSON output; writes go through internal/atproto.
+type apiServer struct {
+	cmd    *cli.Command // hc serve, for global flags and identity lookups
+	client *atclient.APIClient
+	token  string
+	log    io.Writer
+}
+
+// apiError is an error with the HTTP status it should be reported as.
+type apiError struct {
+	Status int
+	Err    error
+}
+
+func (e *apiError) Error() string { return e.Err.Error() }
+
+func badRequest(format string, args ...any) error {
+	return &apiError{Status: http.StatusBadRequest, Err: fmt.Errorf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &apiError{Status: http.StatusNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+// errorStatus picks the HTTP status for an error from a handler.
+func errorStatus(err error) int {
+	var ae *apiError
+	if errors.As(err, &ae) {
+		return ae.Status
+	}
+	if atproto.IsNotFound(err) {
+		return http.StatusNotFound
+	}
+	var xe *atclient.APIError
+	if errors.As(err, &xe) {
+		switch {
+		case xe.Name == "InvalidSwap":
+			return http.StatusConflict
+		case xe.StatusCode == http.StatusBadRequest:
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusBadGateway
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// handlerFunc is an API handler; a returned error becomes a JSON error body.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (s *apiServer) handle(h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h(w, r); err != nil {
+			status := errorStatus(err)
+			fmt.Fprintf(s.log, "%s %s: %d %v\n", r.Method, r.URL.Path, status, err)
+			writeJSON(w, status, map[string]string{"error": err.Error()})
+		}
+	}
+}
+
+// authorize requires "Authorization: Bearer <token>" on every request.
+func (s *apiServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing or invalid bearer token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *apiServer) routes() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("GET /v1/reports/{report}", s.handle(s.handleReport))
+	api.HandleFunc("GET /v1/activities/{rkey}/contributors", s.handle(s.handleActivityContributors))
+	api.HandleFunc("GET /v1/{type}", s.handle(s.handleList))
+	api.HandleFunc("POST /v1/{type}", s.handle(s.handleCreate))
+	api.HandleFunc("GET /v1/{type}/{rkey}", s.handle(s.handleGet))
+	api.HandleFunc("PUT /v1/{type}/{rkey}", s.handle(s.handlePut))
+	api.HandleFunc("PATCH /v1/{type}/{rkey}", s.handle(s.handlePut))
+	api.HandleFunc("DELETE /v1/{type}/{rkey}", s.handle(s.handleDelete))
+	api.HandleFunc("GET /v1/{type}/{rkey}/backlinks", s.handle(s.handleBacklinks))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, openAPISpec())
+	})
+	mux.Handle("/v1/", s.authorize(api))
+	return mux
+}
+
+// runCommand runs an hc command in-process with the server's session and
+// returns its output. Global settings were applied when the server started,
+// so the root Before hook is skipped.
+func (s *apiServer) runCommand(ctx context.Context, args []string) ([]byte, error) {
+	var buf bytes.Buffer
+	app := BuildApp(&buf)
+	app.Before = nil
+	argv := append([]string{"hc", "--plc-host", s.cmd.Root().String("plc-host")}, args...)
+	if err := app.Run(withClient(ctx, s.client), argv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCommandJSON runs a command with JSON output and relays it.
+func (s *apiServer) writeCommandJSON(w http.ResponseWriter, r *http.Request, args []string) error {
+	out, err := s.runCommand(r.Context(), args)
+	if err != nil {
+		return err
+	}
+	out = bytes.TrimSpace(out)
+	if string(out) == "null" {
+		out = []byte("[]")
+	}
+	if !json.Valid(out) {
+		return fmt.Errorf("command %q did not produce JSON", strings.Join(args, " "))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+// queryFlags turns query parameters into flags of the command at path,
+// rejecting parameters the command does not accept.
+func queryFlags(path []string, q url.Values) ([]string, error) {
+	c := BuildApp(io.Discard)
+	for _, name := range path {
+		if c = c.Command(name); c == nil {
+			return nil, fmt.Errorf("unknown command %q", strings.Join(path, " "))
+		}
+	}
+	accepted := map[string]bool{}
+	for _, f := range c.Flags {
+		for _, n := range f.Names() {
+			accepted[n] = true
+		}
+	}
+
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		if !accepted[k] || k == "json" || k == "format" {
+			return nil, badRequest("unknown query parameter %q", k)
+		}
+		for _, v := range q[k] {
+			args = append(args, "--"+k+"="+v)
+		}
+	}
+	return args, nil
+}
+
+func (s *apiServer) resource(r *http.Request) (serveResource, error) {
+	res, ok := findServeResource(r.PathValue("type"))
+	if !ok {
+		return res, notFound("unknown record type %q", r.PathValue("type"))
+	}
+	return res, nil
+}
+
+// repoClient returns the client and DID for ?repo=, or the session's account.
+func (s *apiServer) repoClient(ctx context.Context, r *http.Request) (*atclient.APIClient, string, error) {
+	repo := r.URL.Query().Get("repo")
+	if repo == "" {
+		return s.client, s.client.AccountDID.String(), nil
+	}
+	ident, err := resolveIdent(ctx, s.cmd, repo)
+	if err != nil {
+		return nil, "", badRequest("failed to resolve repo %s: %v", repo, err)
+	}
+	client, err := publicClient(ident)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, ident.DID.String(), nil
+}
+
+func (s *apiServer) handleList(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	if res.List == nil {
+		client, did, err := s.repoClient(r.Context(), r)
+		if err != nil {
+			return err
+		}
+		entries, err := atproto.ListAllRecords(r.Context(), client, did, res.Collection)
+		if err != nil {
+			return err
+		}
+		out := []map[string]any{}
+		for _, e := range entries {
+			out = append(out, map[string]any{"uri": e.URI, "cid": e.CID, "value": e.Value})
+		}
+		writeJSON(w, http.StatusOK, out)
+		return nil
+	}
+	flags, err := queryFlags(res.List, r.URL.Query())
+	if err != nil {
+		return err
+	}
+	return s.writeCommandJSON(w, r, slices.Concat(res.List, []string{"--json"}, flags))
+}
+
+func (s *apiServer) handleReport(w http.ResponseWriter, r *http.Request) error {
+	for _, rep := range serveReports {
+		if rep.Name != r.PathValue("report") {
+			continue
+		}
+		flags, err := queryFlags(rep.Path, r.URL.Query())
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(flags, func(f string) bool { return strings.HasPrefix(f, "--fix") }) {
+			return badRequest("reports are read-only")
+		}
+		return s.writeCommandJSON(w, r, slices.Concat(rep.Path, rep.JSON, flags))
+	}
+	return notFound("unknown report %q", r.PathValue("report"))
+}
+
+func (s *apiServer) handleActivityContributors(w http.ResponseWriter, r *http.Request) error {
+	flags, err := queryFlags([]string{"activity", "contributors"}, r.URL.Query())
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(flags, func(f string) bool { return strings.HasPrefix(f, "--normalize") }) {
+		return badRequest("use PATCH /v1/activities/{rkey} to change weights")
+	}
+	return s.writeCommandJSON(w, r, append([]string{"activity", "contributors", r.PathValue("rkey"), "--json"}, flags...))
+}
+
+func (s *apiServer) handleGet(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	client, did, err := s.repoClient(r.Context(), r)
+	if err != nil {
+		return err
+	}
+	rkey := r.PathValue("rkey")
+	value, cid, err := atproto.GetRecord(r.Context(), client, did, res.Collection, rkey)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"uri":   fmt.Sprintf("at://%s/%s/%s", did, res.Collection, rkey),
+		"cid":   cid,
+		"value": value,
+	})
+	return nil
+}
+
+// readRecordBody decodes a JSON object request body as a record of collection.
+func readRecordBody(w http.ResponseWriter, r *http.Request, collection string) (map[string]any, error) {
+	var record map[string]any
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err := dec.Decode(&record); err != nil {
+		return nil, badRequest("request body must be a JSON object: %v", err)
+	}
+	if record == nil {
+		return nil, badRequest("request body must be a JSON object")
+	}
+	if t, ok := record["$type"]; ok && t != collection {
+		return nil, badRequest("$type %v does not match %s", t, collection)
+	}
+	return record, nil
+}
+
+// apiDateFields hold dates; YYYY-MM-DD input is stored as midnight UTC, as
+// the create and edit commands do.
+var apiDateFields = []string{"startDate", "endDate", "occurredAt"}
+
+// prepareRecord normalizes and checks a record before it is written, like the
+// create and edit commands: dates are normalized, strongRefs must carry an
+// AT-URI and a CID, and measurements need a metric, a unit, and a numeric
+// value. Warnings about accepted input, such as unknown units, go to notes.
+func prepareRecord(notes io.Writer, collection string, record map[string]any) error {
+	for _, key := range apiDateFields {
+		v, ok := record[key]
+		if !ok {
+			continue
+		}
+		date, _ := v.(string)
+		date = normalizeDate(strings.TrimSpace(date))
+		if _, err := time.Parse(time.RFC3339, date); err != nil {
+			return badRequest("%s must be a YYYY-MM-DD date or RFC 3339 timestamp, got %v", key, v)
+		}
+		record[key] = date
+	}
+	if err := checkStrongRefs("", record); err != nil {
+		return err
+	}
+	if collection != atproto.CollectionMeasurement {
+		return nil
+	}
+	if v, ok := record["value"].(float64); ok {
+		record["value"] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	for _, key := range []string{"metric", "unit", "value"} {
+		if strings.TrimSpace(mapStr(record, key)) == "" {
+			return badRequest("measurement %s is required", key)
+		}
+	}
+	unit, value, err := normalizeMeasurement(notes, mapStr(record, "unit"), mapStr(record, "value"))
+	if err != nil {
+		return badRequest("invalid value %q: %v", mapStr(record, "value"), err)
+	}
+	record["unit"], record["value"] = unit, value
+	return nil
+}
+
+// checkStrongRefs reports the first strongRef (an object with a cid) in v
+// whose uri is not an AT-URI or whose cid is not a CID. path names v in errors.
+func checkStrongRefs(path string, v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := v["cid"]; ok {
+			if _, err := syntax.ParseATURI(mapStr(v, "uri")); err != nil {
+				return badRequest("%s: strongRef uri must be an AT-URI: %v", path, err)
+			}
+			if _, err := syntax.ParseCID(mapStr(v, "cid")); err != nil {
+				return badRequest("%s: invalid strongRef cid: %v", path, err)
+			}
+			return nil
+		}
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			field := k
+			if path != "" {
+				field = path + "." + k
+			}
+			if err := checkStrongRefs(field, v[k]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, e := range v {
+			if err := checkStrongRefs(fmt.Sprintf("%s[%d]", path, i), e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeResult writes a record write's uri and cid, with any input warnings.
+func writeResult(w http.ResponseWriter, status int, uri, cid string, notes *bytes.Buffer) {
+	result := map[string]string{"uri": uri, "cid": cid}
+	if n := strings.TrimSpace(notes.String()); n != "" {
+		result["warning"] = n
+	}
+	writeJSON(w, status, result)
+}
+
+func (s *apiServer) handleCreate(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	if r.URL.Query().Get("repo") != "" {
+		return badRequest("records can only be written to the server's own account")
+	}
+	record, err := readRecordBody(w, r, res.Collection)
+	if err != nil {
+		return err
+	}
+	record["$type"] = res.Collection
+	if _, ok := record["createdAt"]; !ok {
+		record["createdAt"] = time.Now().UTC().Format(time.RFC3339)
+	}
+	var notes bytes.Buffer
+	if err := prepareRecord(&notes, res.Collection, record); err != nil {
+		return err
+	}
+	uri, cid, err := atproto.CreateRecord(r.Context(), s.client, res.Collection, record)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.log, "created %s\n", uri)
+	writeResult(w, http.StatusCreated, uri, cid, &notes)
+	return nil
+}
+
+// mergeRecord applies a PATCH body: top-level keys replace the record's,
+// and null removes a key.
+func mergeRecord(record, patch map[string]any) map[string]any {
+	merged := make(map[string]any, len(record)+len(patch))
+	for k, v := range record {
+		merged[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// handlePut replaces (PUT) or merges into (PATCH) a record. The write is
+// guarded by the If-Match CID when given, else by the CID that was read.
+func (s *apiServer) handlePut(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	if r.URL.Query().Get("repo") != "" {
+		return badRequest("records can only be written to the server's own account")
+	}
+	body, err := readRecordBody(w, r, res.Collection)
+	if err != nil {
+		return err
+	}
+	ctx := r.Context()
+	did := s.client.AccountDID.String()
+	rkey := r.PathValue("rkey")
+
+	current, cid, err := atproto.GetRecord(ctx, s.client, did, res.Collection, rkey)
+	if err != nil {
+		return err
+	}
+	record := body
+	if r.Method == http.MethodPatch {
+		record = mergeRecord(current, body)
+	} else if _, ok := record["createdAt"]; !ok && current["createdAt"] != nil {
+		record["createdAt"] = current["createdAt"]
+	}
+	record["$type"] = res.Collection
+	var notes bytes.Buffer
+	if err := prepareRecord(&notes, res.Collection, record); err != nil {
+		return err
+	}
+	if m := strings.Trim(r.Header.Get("If-Match"), `"`); m != "" {
+		cid = m
+	}
+
+	uri, newCID, err := atproto.PutRecord(ctx, s.client, did, res.Collection, rkey, record, &cid)
+	if err != nil {
+		return err
+	}
+	// Keep activity contributor strongRefs current, as the edit commands do.
+	if res.Collection == atproto.CollectionContributorInfo || res.Collection == atproto.CollectionContribution {
+		syncActivityContributorRefs(ctx, s.client, s.log, did, uri, newCID)
+	}
+	fmt.Fprintf(s.log, "updated %s\n", uri)
+	writeResult(w, http.StatusOK, uri, newCID, &notes)
+	return nil
+}
+
+func (s *apiServer) handleDelete(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	if r.URL.Query().Get("repo") != "" {
+		return badRequest("records can only be written to the server's own account")
+	}
+	did := s.client.AccountDID.String()
+	rkey := r.PathValue("rkey")
+	if _, _, err := atproto.GetRecord(r.Context(), s.client, did, res.Collection, rkey); err != nil {
+		return err
+	}
+	uri := fmt.Sprintf("at://%s/%s/%s", did, res.Collection, rkey)
+	if res.Collection == atproto.CollectionActivity {
+		// Like hc activity delete, also delete linked measurements,
+		// attachments, and evaluations; deleteActivity logs what it removed.
+		if err := deleteActivity(r.Context(), s.client, s.log, did, uri, true); err != nil {
+			return err
+		}
+	} else {
+		if err := atproto.DeleteRecord(r.Context(), s.client, did, res.Collection, rkey); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.log, "deleted %s\n", uri)
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleBacklinks returns the Constellation backlink summary for a record, or
+// the linking records for one ?collection= and ?path=.
+func (s *apiServer) handleBacklinks(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resource(r)
+	if err != nil {
+		return err
+	}
+	did := s.client.AccountDID.String()
+	q := r.URL.Query()
+	if repo := q.Get("repo"); repo != "" {
+		ident, err := resolveIdent(r.Context(), s.cmd, repo)
+		if err != nil {
+			return badRequest("failed to resolve repo %s: %v", repo, err)
+		}
+		did = ident.DID.String()
+	}
+	target := fmt.Sprintf("at://%s/%s/%s", did, res.Collection, r.PathValue("rkey"))
+	if _, err := syntax.ParseATURI(target); err != nil {
+		return badRequest("invalid record key: %v", err)
+	}
+
+	if coll, path := q.Get("collection"), q.Get("path"); coll != "" || path != "" {
+		if coll == "" || path == "" {
+			return badRequest("collection and path must be given together")
+		}
+		records, err := atproto.GetAllBacklinkRecords(r.Context(), target, coll, path)
+		if err != nil {
+			return err
+		}
+		out := []string{}
+		for _, lr := range records {
+			out = append(out, lr.Ref().URI())
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"target": target, "records": out})
+		return nil
+	}
+
+	summary, err := atproto.GetAllBacklinks(r.Context(), target)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"target": target, "links": summary.Links})
+	return nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func runServe(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	client, err := requireAuth(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	token := cmd.String("token")
+	if token == "" {
+		if token, err = generateToken(); err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		fmt.Fprintf(w, "Generated API token (set --token or HC_API_TOKEN to keep it stable):\n  %s\n", token)
+	}
+
+	s := &apiServer{cmd: cmd, client: client, token: token, log: w}
+	srv := &http.Server{
+		Addr:              cmd.String("addr"),
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	fmt.Fprintf(w, "\033[32m✓\033[0m Serving %s on %s (OpenAPI: /openapi.json)\n", client.AccountDID, srv.Addr)
+	select {
+	case err := <-errc:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown failed: %w", err)
+	}
+	fmt.Fprintln(w, "Server stopped")
+	return nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// openAPISpec describes the hc serve API as an OpenAPI 3.1 document, built
+// from serveResources and serveReports so it cannot drift from the routes.
+func openAPISpec() map[string]any {
+	ref := func(name string) map[string]any {
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	jsonContent := func(schema map[string]any) map[string]any {
+		return map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+	response := func(desc string, schema map[string]any) map[string]any {
+		return map[string]any{"description": desc, "content": jsonContent(schema)}
+	}
+	errorResponses := func(codes ...string) map[string]any {
+		descs := map[string]string{
+			"400": "Invalid request",
+			"404": "Record or type not found",
+			"409": "Record changed since it was read (CID mismatch)",
+			"502": "PDS or backlink index request failed",
+		}
+		out := map[string]any{"401": map[string]any{"$ref": "#/components/responses/Unauthorized"}}
+		for _, c := range codes {
+			out[c] = response(descs[c], ref("Error"))
+		}
+		return out
+	}
+	withResponses := func(ok map[string]any, codes ...string) map[string]any {
+		out := errorResponses(codes...)
+		for k, v := range ok {
+			out[k] = v
+		}
+		return out
+	}
+	param := func(name, in, desc string, required bool) map[string]any {
+		return map[string]any{"name": name, "in": in, "description": desc, "required": required, "schema": map[string]any{"type": "string"}}
+	}
+	repoParam := param("repo", "query", "handle or DID of another account's repo (read-only)", false)
+	rkeyParam := param("rkey", "path", "record key", true)
+
+	writeChecks := "YYYY-MM-DD dates are stored as midnight UTC, strongRefs must have an AT-URI and CID, and measurements need a metric, unit, and numeric value (units are normalized)."
+	paths := map[string]any{}
+	for _, res := range serveResources {
+		listDesc := "Lists records as returned by `hc " + strings.Join(res.List, " ") + " --json`. Other query parameters map to that command's flags."
+		if res.List == nil {
+			listDesc = "Lists raw records."
+		}
+		deleteDesc := "Deletes the record."
+		if res.Collection == atproto.CollectionActivity {
+			deleteDesc = "Deletes the activity and its linked measurements, attachments, and evaluations, as `hc activity delete` does."
+		}
+		paths["/v1/"+res.Name] = map[string]any{
+			"get": map[string]any{
+				"summary":     "List " + res.Name,
+				"description": listDesc,
+				"operationId": "list_" + res.Name,
+				"parameters":  []any{repoParam},
+				"responses":   withResponses(map[string]any{"200": response("Records", map[string]any{"type": "array", "items": map[string]any{"type": "object"}})}, "400", "502"),
+			},
+			"post": map[string]any{
+				"summary":     "Create a " + res.Collection + " record",
+				"description": "$type is set automatically; createdAt defaults to now. " + writeChecks,
+				"operationId": "create_" + res.Name,
+				"requestBody": map[string]any{"required": true, "content": jsonContent(ref("Record"))},
+				"responses":   withResponses(map[string]any{"201": response("Created", ref("WriteResult"))}, "400", "502"),
+			},
+		}
+		paths["/v1/"+res.Name+"/{rkey}"] = map[string]any{
+			"parameters": []any{rkeyParam},
+			"get": map[string]any{
+				"summary":     "Get a " + res.Collection + " record",
+				"operationId": "get_" + res.Name,
+				"parameters":  []any{repoParam},
+				"responses":   withResponses(map[string]any{"200": response("Record", ref("RecordEntry"))}, "404", "502"),
+			},
+			"put": map[string]any{
+				"summary":     "Replace a record",
+				"description": "Guarded by the If-Match CID when given, otherwise by the CID read just before writing. " + writeChecks,
+				"operationId": "replace_" + res.Name,
+				"parameters":  []any{param("If-Match", "header", "expected current CID", false)},
+				"requestBody": map[string]any{"required": true, "content": jsonContent(ref("Record"))},
+				"responses":   withResponses(map[string]any{"200": response("Updated", ref("WriteResult"))}, "400", "404", "409", "502"),
+			},
+			"patch": map[string]any{
+				"summary":     "Edit a record",
+				"description": "Top-level fields in the body replace the record's; null removes a field. " + writeChecks,
+				"operationId": "edit_" + res.Name,
+				"parameters":  []any{param("If-Match", "header", "expected current CID", false)},
+				"requestBody": map[string]any{"required": true, "content": jsonContent(ref("Record"))},
+				"responses":   withResponses(map[string]any{"200": response("Updated", ref("WriteResult"))}, "400", "404", "409", "502"),
+			},
+			"delete": map[string]any{
+				"summary":     "Delete a record",
+				"description": deleteDesc,
+				"operationId": "delete_" + res.Name,
+				"responses":   withResponses(map[string]any{"204": map[string]any{"description": "Deleted"}}, "404", "502"),
+			},
+		}
+		paths["/v1/"+res.Name+"/{rkey}/backlinks"] = map[string]any{
+			"get": map[string]any{
+				"summary":     "Records linking to this record",
+				"description": "Backlink counts from the Constellation index, or the linking records for one collection and path.",
+				"operationId": "backlinks_" + res.Name,
+				"parameters": []any{
+					rkeyParam, repoParam,
+					param("collection", "query", "linking collection NSID", false),
+					param("path", "query", "link path, e.g. .subject.uri", false),
+				},
+				"responses": withResponses(map[string]any{"200": response("Backlinks", map[string]any{"type": "object"})}, "400", "502"),
+			},
+		}
+	}
+
+	paths["/v1/activities/{rkey}/contributors"] = map[string]any{
+		"get": map[string]any{
+			"summary":     "Activity contributors with weights and shares",
+			"description": "As returned by `hc activity contributors --json`.",
+			"operationId": "activity_contributors",
+			"parameters":  []any{rkeyParam, repoParam},
+			"responses":   withResponses(map[string]any{"200": response("Contributors", map[string]any{"type": "array", "items": map[string]any{"type": "object"}})}, "404", "502"),
+		},
+	}
+	for _, rep := range serveReports {
+		paths["/v1/reports/"+rep.Name] = map[string]any{
+			"get": map[string]any{
+				"summary":     strings.ToUpper(rep.Usage[:1]) + rep.Usage[1:],
+				"description": "Query parameters map to the flags of `hc " + strings.Join(rep.Path, " ") + "`.",
+				"operationId": "report_" + strings.ReplaceAll(rep.Name, "-", "_"),
+				"parameters":  []any{repoParam},
+				"responses":   withResponses(map[string]any{"200": response("Report", map[string]any{})}, "400", "502"),
+			},
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "Hypercerts CLI API",
+			"version":     Version,
+			"description": "REST access to the Hypercerts records of the account hc serve is logged in as.",
+		},
+		"security": []any{map[string]any{"bearer": []any{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearer": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+			"responses": map[string]any{
+				"Unauthorized": response("Missing or invalid bearer token", ref("Error")),
+			},
+			"schemas": map[string]any{
+				"Record": map[string]any{"type": "object", "description": "Record value as defined by its lexicon", "additionalProperties": true},
+				"RecordEntry": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"uri":   map[string]any{"type": "string"},
+						"cid":   map[string]any{"type": "string"},
+						"value": ref("Record"),
+					},
+				},
+				"WriteResult": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"uri":     map[string]any{"type": "string"},
+						"cid":     map[string]any{"type": "string"},
+						"warning": map[string]any{"type": "string", "description": "accepted input that may need attention, e.g. an unknown unit"},
+					},
+				},
+				"Error": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"error": map[string]any{"type": "string"}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+)
+
+// newTestAPIServer returns an API server whose session points at a fake PDS
+// holding one activity with one measurement. Created records are captured in
+// created and deleted record keys in deleted.
+func newTestAPIServer(t *testing.T, created *[]map[string]any, deleted *[]string) *httptest.Server {
+	t.Helper()
+	activityURI := "at://did:plc:test/org.hypercerts.claim.activity/a1"
+	pds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xrpc/com.atproto.repo.listRecords":
+			records := []any{}
+			switch r.URL.Query().Get("collection") {
+			case atproto.CollectionActivity:
+				records = append(records, map[string]any{
+					"uri":   activityURI,
+					"cid":   "bafya1",
+					"value": map[string]any{"$type": atproto.CollectionActivity, "title": "Reforestation"},
+				})
+			case atproto.CollectionMeasurement:
+				records = append(records, map[string]any{
+					"uri":   "at://did:plc:test/org.hypercerts.context.measurement/m1",
+					"cid":   "bafym1",
+					"value": map[string]any{"$type": atproto.CollectionMeasurement, "subjects": []any{map[string]any{"uri": activityURI, "cid": "bafya1"}}},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"records": records})
+		case "/xrpc/com.atproto.repo.getRecord":
+			if r.URL.Query().Get("rkey") != "a1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "RecordNotFound", "message": "not found"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"uri": activityURI, "cid": "bafya1", "value": map[string]any{"title": "Reforestation"}})
+		case "/xrpc/com.atproto.repo.createRecord":
+			var in struct {
+				Record map[string]any `json:"record"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			*created = append(*created, in.Record)
+			_ = json.NewEncoder(w).Encode(map[string]any{"uri": "at://did:plc:test/org.hypercerts.context.measurement/m1", "cid": "bafym1"})
+		case "/xrpc/com.atproto.repo.deleteRecord":
+			var in struct {
+				Rkey string `json:"rkey"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			*deleted = append(*deleted, in.Rkey)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(pds.Close)
+
+	client := atclient.NewAPIClient(pds.URL)
+	did := syntax.DID("did:plc:test")
+	client.AccountDID = &did
+
+	s := &apiServer{cmd: BuildApp(io.Discard), client: client, token: "secret", log: io.Discard}
+	srv := httptest.NewServer(s.routes())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doAPI(t *testing.T, method, rawURL, token, body string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, rawURL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func TestServeAPI(t *testing.T) {
+	var created []map[string]any
+	var deleted []string
+	srv := newTestAPIServer(t, &created, &deleted)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"no token", "GET", "/v1/activities", "", "", http.StatusUnauthorized, "bearer token"},
+		{"wrong token", "GET", "/v1/activities", "nope", "", http.StatusUnauthorized, "bearer token"},
+		{"openapi is public", "GET", "/openapi.json", "", "", http.StatusOK, `"openapi":"3.1.0"`},
+		{"list via activity ls", "GET", "/v1/activities", "secret", "", http.StatusOK, `"title": "Reforestation"`},
+		{"unknown type", "GET", "/v1/widgets", "secret", "", http.StatusNotFound, "unknown record type"},
+		{"unknown query parameter", "GET", "/v1/activities?color=red", "secret", "", http.StatusBadRequest, "unknown query parameter"},
+		{"unknown report", "GET", "/v1/reports/nope", "secret", "", http.StatusNotFound, "unknown report"},
+		{"doctor fix is refused", "GET", "/v1/reports/doctor?fix=true", "secret", "", http.StatusBadRequest, "read-only"},
+		{"create", "POST", "/v1/measurements", "secret", `{"metric":"trees planted","unit":"trees","value":"120"}`, http.StatusCreated, `"cid":"bafym1"`},
+		{"create with wrong $type", "POST", "/v1/measurements", "secret", `{"$type":"org.hypercerts.claim.activity"}`, http.StatusBadRequest, "does not match"},
+		{"create with non-object body", "POST", "/v1/measurements", "secret", `[1,2]`, http.StatusBadRequest, "JSON object"},
+		{"create in another repo", "POST", "/v1/measurements?repo=someone.example", "secret", `{}`, http.StatusBadRequest, "own account"},
+		{"create normalizes measurement", "POST", "/v1/measurements", "secret", `{"metric":"area","unit":"hectares","value":"1,500","endDate":"2025-12-31"}`, http.StatusCreated, `"cid":"bafym1"`},
+		{"create without unit", "POST", "/v1/measurements", "secret", `{"metric":"trees planted","value":"1"}`, http.StatusBadRequest, "unit is required"},
+		{"create with bad value", "POST", "/v1/measurements", "secret", `{"metric":"m","unit":"trees","value":"lots"}`, http.StatusBadRequest, "invalid value"},
+		{"create with bad date", "POST", "/v1/measurements", "secret", `{"metric":"m","unit":"trees","value":"1","startDate":"last spring"}`, http.StatusBadRequest, "startDate must be"},
+		{"create with bad strongRef", "POST", "/v1/measurements", "secret", `{"metric":"m","unit":"trees","value":"1","subjects":[{"uri":"https://example.com","cid":"bafya1234"}]}`, http.StatusBadRequest, "subjects[0]: strongRef uri"},
+		{"delete activity", "DELETE", "/v1/activities/a1", "secret", "", http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := doAPI(t, tt.method, srv.URL+tt.path, tt.token, tt.body)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", status, tt.wantStatus, body)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %q", body, tt.wantBody)
+			}
+		})
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("created %d records, want 2", len(created))
+	}
+	if created[0]["$type"] != atproto.CollectionMeasurement || created[0]["createdAt"] == nil || created[0]["metric"] != "trees planted" {
+		t.Errorf("created record = %v", created[0])
+	}
+	if m := created[1]; m["unit"] != "ha" || m["value"] != "1500" || m["endDate"] != "2025-12-31T00:00:00Z" {
+		t.Errorf("normalized measurement = %v", m)
+	}
+	// Deleting an activity also deletes its measurement, as hc activity delete does.
+	if strings.Join(deleted, ",") != "m1,a1" {
+		t.Errorf("deleted = %v, want m1,a1", deleted)
+	}
+}
+
+func TestQueryFlags(t *testing.T) {
+	got, err := queryFlags([]string{"measurement", "stats"}, url.Values{"group-by": {"metric,year"}, "from": {"2025-01-01"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "--from=2025-01-01 --group-by=metric,year" {
+		t.Errorf("queryFlags() = %v", got)
+	}
+	for _, q := range []url.Values{{"nope": {"1"}}, {"format": {"csv"}}} {
+		if _, err := queryFlags([]string{"measurement", "stats"}, q); err == nil {
+			t.Errorf("queryFlags(%v) expected error", q)
+		}
+	}
+}
+
+func TestMergeRecord(t *testing.T) {
+	record := map[string]any{"title": "Old", "shortDescription": "s", "endDate": "2025-01-01T00:00:00Z"}
+	got := mergeRecord(record, map[string]any{"title": "New", "endDate": nil, "locations": []any{}})
+	if got["title"] != "New" || got["shortDescription"] != "s" || got["locations"] == nil {
+		t.Errorf("mergeRecord() = %v", got)
+	}
+	if _, ok := got["endDate"]; ok {
+		t.Errorf("null should remove endDate: %v", got)
+	}
+	if record["title"] != "Old" {
+		t.Errorf("mergeRecord modified its input")
+	}
+}
+
+func TestOpenAPISpecCoversResources(t *testing.T) {
+	paths, _ := openAPISpec()["paths"].(map[string]any)
+	for _, res := range serveResources {
+		for _, p := range []string{"/v1/" + res.Name, "/v1/" + res.Name + "/{rkey}", "/v1/" + res.Name + "/{rkey}/backlinks"} {
+			if paths[p] == nil {
+				t.Errorf("OpenAPI document missing %s", p)
+			}
+		}
+	}
+	for _, rep := range serveReports {
+		if paths["/v1/reports/"+rep.Name] == nil {
+			t.Errorf("OpenAPI document missing report %s", rep.Name)
+		}
+	}
+	if _, err := json.Marshal(openAPISpec()); err != nil {
+		t.Errorf("OpenAPI document does not marshal: %v", err)
+	}
+}
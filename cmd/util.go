@@ -16,8 +16,20 @@ import (
 	"github.com/GainForest/hypercerts-cli/internal/atproto"
 )
 
+// clientKey is the context key for a pre-authenticated client.
+type clientKey struct{}
+
+// withClient returns a context whose commands reuse client instead of logging
+// in again. hc serve runs commands in-process this way.
+func withClient(ctx context.Context, client *atclient.APIClient) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
 // requireAuth authenticates and returns an API client, or a user-friendly error.
 func requireAuth(ctx context.Context, cmd *cli.Command) (*atclient.APIClient, error) {
+	if client, ok := ctx.Value(clientKey{}).(*atclient.APIClient); ok {
+		return client, nil
+	}
 	client, err := atproto.LoginOrLoad(
 		ctx,
 		cmd.Root().String("username"),
@@ -34,6 +46,79 @@ func requireAuth(ctx context.Context, cmd *cli.Command) (*atclient.APIClient, er
 	return client, nil
 }
 
+// requireReadClient returns an API client and repo DID for read-only commands.
+// The repo comes from --repo, or from the authority of an AT-URI argument; when
+// neither is given it falls back to the logged-in account. Foreign repos are read
+// unauthenticated from the PDS listed in their DID document.
+func requireReadClient(ctx context.Context, cmd *cli.Command, arg string) (*atclient.APIClient, string, error) {
+	repo := cmd.String("repo")
+	if repo == "" && strings.HasPrefix(arg, "at://") {
+		aturi, err := syntax.ParseATURI(arg)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid URI: %w", err)
+		}
+		repo = aturi.Authority().String()
+	}
+	if repo == "" {
+		client, err := requireAuth(ctx, cmd)
+		if err != nil {
+			return nil, "", err
+		}
+		return client, client.AccountDID.String(), nil
+	}
+
+	ident, err := resolveIdent(ctx, cmd, repo)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve repo %s: %w", repo, err)
+	}
+	client, err := publicClient(ident)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, ident.DID.String(), nil
+}
+
+// publicClient returns an unauthenticated API client for the identity's PDS.
+func publicClient(ident *identity.Identity) (*atclient.APIClient, error) {
+	host := ident.PDSEndpoint()
+	if host == "" {
+		return nil, fmt.Errorf("no PDS endpoint for identity %s", ident.DID)
+	}
+	return atproto.NewAPIClient(host, userAgentString()), nil
+}
+
+// getRecordsAnyRepo fetches records that may live in other accounts' repos,
+// reading each repo from the PDS in its DID document. Results are in the same
+// order as refs.
+func getRecordsAnyRepo(ctx context.Context, cmd *cli.Command, refs []atproto.RecordRef) []atproto.FetchResult {
+	results := make([]atproto.FetchResult, len(refs))
+	byRepo := map[string][]int{}
+	for i, ref := range refs {
+		byRepo[ref.DID] = append(byRepo[ref.DID], i)
+	}
+	for repo, idx := range byRepo {
+		ident, err := resolveIdent(ctx, cmd, repo)
+		var client *atclient.APIClient
+		if err == nil {
+			client, err = publicClient(ident)
+		}
+		if err != nil {
+			for _, i := range idx {
+				results[i] = atproto.FetchResult{Ref: refs[i], Err: fmt.Errorf("failed to resolve repo %s: %w", repo, err)}
+			}
+			continue
+		}
+		group := make([]atproto.RecordRef, len(idx))
+		for j, i := range idx {
+			group[j] = refs[i]
+		}
+		for j, res := range atproto.GetRecords(ctx, client, group) {
+			results[idx[j]] = res
+		}
+	}
+	return results
+}
+
 // configDirectory returns an identity directory for unauthenticated reads.
 func configDirectory(cmd *cli.Command) identity.Directory {
 	return atproto.ConfigDirectory(cmd.Root().String("plc-host"), Version)
@@ -89,12 +174,17 @@ func normalizeDate(s string) string {
 	if s == "" {
 		return ""
 	}
-	if len(s) == 10 && s[4] == '-' && s[7] == '-' {
+	if isDateOnly(s) {
 		return s + "T00:00:00Z"
 	}
 	return s
 }
 
+// isDateOnly reports whether s is a YYYY-MM-DD date without a time.
+func isDateOnly(s string) bool {
+	return len(s) == 10 && s[4] == '-' && s[7] == '-'
+}
+
 // buildStrongRef builds a strongRef object from URI and CID.
 func buildStrongRef(uri, cid string) map[string]any {
 	return map[string]any{
@@ -155,12 +245,11 @@ func runSimpleGet(ctx context.Context, cmd *cli.Command, collection, typeName st
 		return fmt.Errorf("usage: hc %s get <id|at-uri>", typeName)
 	}
 
-	client, err := requireAuth(ctx, cmd)
+	client, did, err := requireReadClient(ctx, cmd, arg)
 	if err != nil {
 		return err
 	}
 
-	did := client.AccountDID.String()
 	uri := resolveRecordURI(did, collection, arg)
 
 	aturi, err := syntax.ParseATURI(uri)
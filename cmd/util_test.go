@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -284,3 +286,11 @@ func TestParseLocationCoords(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireReadClient_invalidURI(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExecuteWithOutput([]string{"hc", "measurement", "get", "at://not a uri"}, &buf)
+	if err == nil || !strings.Contains(err.Error(), "invalid URI") {
+		t.Errorf("expected invalid URI error, got: %v", err)
+	}
+}
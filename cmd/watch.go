@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/GainForest/hypercerts-cli/internal/jetstream"
+)
+
+// watchCollections are the collection prefixes hc watch subscribes to.
+var watchCollections = []string{"org.hypercerts.*", "app.certified.*"}
+
+// parseWatchCollections turns comma-separated NSIDs, NSID prefixes ending in
+// ".*", or serve resource names (e.g. "evaluations") into Jetstream filters.
+// Empty input means all Hypercerts collections.
+func parseWatchCollections(s string) ([]string, error) {
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if res, ok := findServeResource(c); ok {
+			c = res.Collection
+		}
+		if !strings.HasPrefix(c, "org.hypercerts.") && !strings.HasPrefix(c, "app.certified.") {
+			return nil, fmt.Errorf("unknown collection %q (expected an org.hypercerts.* or app.certified.* NSID, or a name such as evaluations)", c)
+		}
+		out = append(out, c)
+	}
+	if len(out) == 0 {
+		return watchCollections, nil
+	}
+	return out, nil
+}
+
+// watchEvent is the JSON line printed for each event with --json.
+type watchEvent struct {
+	Time       string         `json:"time"`
+	TimeUS     int64          `json:"time_us"`
+	DID        string         `json:"did"`
+	Operation  string         `json:"operation"`
+	Collection string         `json:"collection"`
+	Rkey       string         `json:"rkey"`
+	URI        string         `json:"uri"`
+	CID        string         `json:"cid,omitempty"`
+	Record     map[string]any `json:"record,omitempty"`
+}
+
+func newWatchEvent(e jetstream.Event) watchEvent {
+	return watchEvent{
+		Time:       e.Time().UTC().Format(time.RFC3339Nano),
+		TimeUS:     e.TimeUS,
+		DID:        e.DID,
+		Operation:  e.Commit.Operation,
+		Collection: e.Commit.Collection,
+		Rkey:       e.Commit.RKey,
+		URI:        e.URI(),
+		CID:        e.Commit.CID,
+		Record:     e.Commit.Record,
+	}
+}
+
+// printWatchEvent writes one human-readable event line.
+func printWatchEvent(w io.Writer, e jetstream.Event) {
+	color := "32" // create
+	switch e.Commit.Operation {
+	case jetstream.OpUpdate:
+		color = "33"
+	case jetstream.OpDelete:
+		color = "31"
+	}
+	line := fmt.Sprintf("%s  \033[%sm%-6s\033[0m  %-44s %s/%s",
+		e.Time().Local().Format("2006-01-02 15:04:05"), color, e.Commit.Operation,
+		e.Commit.Collection, e.DID, e.Commit.RKey)
+	if label := recordLabel(e.Commit.Record); label != "" {
+		line += "  " + truncate(label, 60)
+	}
+	fmt.Fprintln(w, line)
+}
+
+// watchDIDs resolves comma-separated handles or DIDs.
+func watchDIDs(ctx context.Context, cmd *cli.Command, s string) ([]string, error) {
+	var dids []string
+	for _, arg := range strings.Split(s, ",") {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+		ident, err := resolveIdent(ctx, cmd, arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", arg, err)
+		}
+		dids = append(dids, ident.DID.String())
+	}
+	return dids, nil
+}
+
+func runWatch(ctx context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	collections, err := parseWatchCollections(cmd.String("collection"))
+	if err != nil {
+		return err
+	}
+	dids, err := watchDIDs(ctx, cmd, cmd.String("did"))
+	if err != nil {
+		return err
+	}
+	js := &jetstream.Client{URL: cmd.String("url"), Collections: collections, DIDs: dids}
+	if d := cmd.Duration("since"); d > 0 {
+		js.Cursor = time.Now().Add(-d).UnixMicro()
+	}
+	endpoint, err := js.SubscribeURL()
+	if err != nil {
+		return err
+	}
+	js.OnReconnect = func(err error, wait time.Duration) {
+		// stderr, so reconnects never interleave with the event stream.
+		fmt.Fprintf(os.Stderr, "Warning: %v; reconnecting in %s\n", err, wait)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	asJSON := cmd.Bool("json")
+	enc := json.NewEncoder(w)
+	if !asJSON {
+		fmt.Fprintf(w, "Watching %s (Ctrl-C to stop)\n", endpoint)
+	}
+	return js.Subscribe(ctx, func(e jetstream.Event) error {
+		if asJSON {
+			return enc.Encode(newWatchEvent(e))
+		}
+		printWatchEvent(w, e)
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/GainForest/hypercerts-cli/internal/atproto"
+	"github.com/GainForest/hypercerts-cli/internal/jetstream"
+)
+
+func TestParseWatchCollections(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"", watchCollections, false},
+		{"evaluations, acknowledgements", []string{atproto.CollectionEvaluation, atproto.CollectionAcknowledgement}, false},
+		{"org.hypercerts.context.*", []string{"org.hypercerts.context.*"}, false},
+		{"app.bsky.feed.post", nil, true},
+		{"widgets", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWatchCollections(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseWatchCollections(%q) err = %v", tt.in, err)
+			continue
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("parseWatchCollections(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPrintWatchEvent(t *testing.T) {
+	var buf bytes.Buffer
+	printWatchEvent(&buf, jetstream.Event{
+		DID:    "did:plc:a",
+		TimeUS: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC).UnixMicro(),
+		Kind:   "commit",
+		Commit: &jetstream.Commit{Operation: "create", Collection: atproto.CollectionEvaluation, RKey: "e1", Record: map[string]any{"summary": "x", "title": "Audit 2025"}},
+	})
+	out := buf.String()
+	for _, want := range []string{"create", atproto.CollectionEvaluation, "did:plc:a/e1", "Audit 2025"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q missing %q", out, want)
+		}
+	}
+}
+
+func TestWatchJSON(t *testing.T) {
+	frames := []string{
+		`{"did":"did:plc:a","time_us":100,"kind":"commit","commit":{"operation":"create","collection":"org.hypercerts.context.evaluation","rkey":"e1","cid":"bafye1","record":{"summary":"Good"}}}`,
+		`{"did":"did:plc:a","time_us":200,"kind":"commit","commit":{"operation":"create","collection":"org.hypercerts.claim.activity","rkey":"a1"}}`,
+	}
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, f := range frames {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(f))
+		}
+		_, _, _ = conn.ReadMessage() // hold the connection until the client leaves
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := BuildApp(&buf).Run(ctx, []string{"hc", "watch", "--url", srv.URL, "--collection", "evaluations", "--json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("output = %q, want one evaluation event", buf.String())
+	}
+	var ev watchEvent
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.URI != "at://did:plc:a/org.hypercerts.context.evaluation/e1" || ev.Operation != "create" || ev.CID != "bafye1" || ev.Record["summary"] != "Good" {
+		t.Errorf("event = %+v", ev)
+	}
+}
@@ -3,11 +3,13 @@ module github.com/GainForest/hypercerts-cli
 go 1.25.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/adrg/xdg v0.5.3
 	github.com/bluesky-social/indigo v0.0.0-20260202181658-ea3d39eec464
 	github.com/charmbracelet/bubbletea v1.3.6
 	github.com/charmbracelet/huh v0.8.0
 	github.com/charmbracelet/lipgloss v1.1.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
 	github.com/urfave/cli/v3 v3.6.2
 	golang.org/x/term v0.39.0
@@ -101,6 +101,7 @@ func ConfigDirectory(plcHost, version string) identity.Directory {
 		bdir, ok := cdir.Inner.(*identity.BaseDirectory)
 		if ok {
 			bdir.UserAgent = fmt.Sprintf("hc/%s", version)
+			bdir.HTTPClient = *HTTPClient
 			if plcHost != "" {
 				bdir.PLCURL = plcHost
 			}
@@ -112,14 +113,14 @@ func ConfigDirectory(plcHost, version string) identity.Directory {
 // Login authenticates with a PDS using username and password.
 func Login(ctx context.Context, username, password, pdsHost, plcHost, version string) (*atclient.APIClient, error) {
 	if pdsHost != "" {
-		return atclient.LoginWithPasswordHost(ctx, pdsHost, username, password, "", authRefreshCallback)
+		return withRetries(atclient.LoginWithPasswordHost(ctx, pdsHost, username, password, "", authRefreshCallback))
 	}
 	atid, err := syntax.ParseAtIdentifier(username)
 	if err != nil {
 		return nil, fmt.Errorf("invalid username: %w", err)
 	}
 	dir := ConfigDirectory(plcHost, version)
-	return atclient.LoginWithPassword(ctx, dir, atid, password, "", authRefreshCallback)
+	return withRetries(atclient.LoginWithPassword(ctx, dir, atid, password, "", authRefreshCallback))
 }
 
 // LoadAuthClient loads an auth client from the saved session.
@@ -134,12 +135,13 @@ func LoadAuthClient(ctx context.Context, plcHost, version string) (*atclient.API
 		AccountDID:   sess.DID,
 		Host:         sess.PDS,
 	}, authRefreshCallback)
+	client.Client = HTTPClient
 	_, err = comatproto.ServerGetSession(ctx, client)
 	if err == nil {
 		return client, nil
 	}
 	dir := ConfigDirectory(plcHost, version)
-	return atclient.LoginWithPassword(ctx, dir, sess.DID.AtIdentifier(), sess.Password, "", authRefreshCallback)
+	return withRetries(atclient.LoginWithPassword(ctx, dir, sess.DID.AtIdentifier(), sess.Password, "", authRefreshCallback))
 }
 
 // LoginOrLoad checks for username/password first, then falls back to loading a saved session.
@@ -150,7 +152,16 @@ func LoginOrLoad(ctx context.Context, username, password, plcHost, version strin
 		if err != nil {
 			return nil, err
 		}
-		return atclient.LoginWithPassword(ctx, dir, atid, password, "", nil)
+		return withRetries(atclient.LoginWithPassword(ctx, dir, atid, password, "", nil))
 	}
 	return LoadAuthClient(ctx, plcHost, version)
 }
+
+// withRetries switches a freshly created client over to the shared retrying HTTPClient.
+func withRetries(client *atclient.APIClient, err error) (*atclient.APIClient, error) {
+	if err != nil {
+		return nil, err
+	}
+	client.Client = HTTPClient
+	return client, nil
+}
@@ -3,6 +3,7 @@ package atproto
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -53,6 +54,38 @@ func CreateRecordWithRkey(ctx context.Context, client *atclient.APIClient, colle
 	return resp.Uri, resp.Cid, nil
 }
 
+// CreateRecords creates several records in one applyWrites call. The writes
+// are atomic: either all records are created or none are.
+// Returns the new record URIs in input order.
+func CreateRecords(ctx context.Context, client *atclient.APIClient, collection string, records []map[string]any) ([]string, error) {
+	validate := false
+	input := &agnostic.RepoApplyWrites_Input{
+		Repo:     client.AccountDID.String(),
+		Validate: &validate,
+	}
+	for _, record := range records {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		value := json.RawMessage(raw)
+		input.Writes = append(input.Writes, &agnostic.RepoApplyWrites_Input_Writes_Elem{
+			RepoApplyWrites_Create: &agnostic.RepoApplyWrites_Create{Collection: collection, Value: &value},
+		})
+	}
+	resp, err := agnostic.RepoApplyWrites(ctx, client, input)
+	if err != nil {
+		return nil, err
+	}
+	uris := make([]string, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r.RepoApplyWrites_CreateResult != nil {
+			uris = append(uris, r.RepoApplyWrites_CreateResult.Uri)
+		}
+	}
+	return uris, nil
+}
+
 // GetRecord fetches a single record by DID, collection, and record key.
 // Returns the record value, CID, and error.
 func GetRecord(ctx context.Context, client *atclient.APIClient, did, collection, rkey string) (map[string]any, string, error) {
@@ -75,7 +108,7 @@ func GetRecord(ctx context.Context, client *atclient.APIClient, did, collection,
 }
 
 // PutRecord updates an existing record with optimistic concurrency via swapCID.
-func PutRecord(ctx context.Context, client *atclient.APIClient, did, collection, rkey string, record map[string]any, swapCID *string) (string, error) {
+func PutRecord(ctx context.Context, client *atclient.APIClient, did, collection, rkey string, record map[string]any, swapCID *string) (uri, cid string, err error) {
 	validate := false
 	resp, err := agnostic.RepoPutRecord(ctx, client, &agnostic.RepoPutRecord_Input{
 		Collection: collection,
@@ -86,9 +119,9 @@ func PutRecord(ctx context.Context, client *atclient.APIClient, did, collection,
 		SwapRecord: swapCID,
 	})
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return resp.Uri, nil
+	return resp.Uri, resp.Cid, nil
 }
 
 // DeleteRecord deletes a record by DID, collection, and record key.
@@ -133,6 +166,19 @@ func ListAllRecords(ctx context.Context, client *atclient.APIClient, did, collec
 	return entries, nil
 }
 
+// IsNotFound reports whether err is a PDS error for a record or repo that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *atclient.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Name {
+	case "RecordNotFound", "RepoNotFound":
+		return true
+	}
+	return apiErr.StatusCode == 404
+}
+
 // ExtractRkey extracts the record key (last segment) from an AT-URI.
 func ExtractRkey(uri string) string {
 	aturi, err := syntax.ParseATURI(uri)
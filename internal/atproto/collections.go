@@ -40,3 +40,25 @@ const (
 	// Location (unchanged)
 	CollectionLocation = "app.certified.location"
 )
+
+// HypercertsCollections lists every record collection managed by the CLI.
+var HypercertsCollections = []string{
+	CollectionActivity,
+	CollectionContributorInfo,
+	CollectionContribution,
+	CollectionRights,
+	CollectionMeasurement,
+	CollectionAttachment,
+	CollectionEvaluation,
+	CollectionAcknowledgement,
+	CollectionCollection,
+	CollectionFundingReceipt,
+	CollectionWorkScopeTag,
+	CollectionWorkScopeCel,
+	CollectionBadgeDefinition,
+	CollectionBadgeAward,
+	CollectionBadgeResponse,
+	CollectionActorProfile,
+	CollectionActorOrganization,
+	CollectionLocation,
+}
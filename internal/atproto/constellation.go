@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 )
 
-const ConstellationBase = "https://constellation.microcosm.blue"
+// DefaultConstellationBase is the public Constellation backlink index.
+const DefaultConstellationBase = "https://constellation.microcosm.blue"
+
+// ConstellationBase is the backlink index queried by GetAllBacklinks and GetBacklinks.
+var ConstellationBase = DefaultConstellationBase
 
 // BacklinksSummary is the response from /links/all.
 type BacklinksSummary struct {
@@ -44,15 +47,12 @@ func GetAllBacklinks(ctx context.Context, target string) (*BacklinksSummary, err
 	q.Set("target", target)
 	u.RawQuery = q.Encode()
 
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("constellation request failed: %w", err)
 	}
@@ -84,15 +84,12 @@ func GetBacklinks(ctx context.Context, target, collection, path string, cursor s
 	}
 	u.RawQuery = q.Encode()
 
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("constellation request failed: %w", err)
 	}
@@ -0,0 +1,81 @@
+package atproto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+)
+
+// DefaultFetchWorkers is the default number of concurrent record fetches.
+const DefaultFetchWorkers = 8
+
+// FetchWorkers bounds the number of in-flight requests made by GetRecords.
+var FetchWorkers = DefaultFetchWorkers
+
+// RecordRef identifies a single record by repo DID, collection, and record key.
+type RecordRef struct {
+	DID        string
+	Collection string
+	Rkey       string
+}
+
+// URI returns the AT-URI for the referenced record.
+func (r RecordRef) URI() string {
+	return fmt.Sprintf("at://%s/%s/%s", r.DID, r.Collection, r.Rkey)
+}
+
+// ParseRecordRef parses an AT-URI into a RecordRef.
+func ParseRecordRef(uri string) (RecordRef, error) {
+	aturi, err := syntax.ParseATURI(uri)
+	if err != nil {
+		return RecordRef{}, err
+	}
+	return RecordRef{
+		DID:        aturi.Authority().String(),
+		Collection: aturi.Collection().String(),
+		Rkey:       aturi.RecordKey().String(),
+	}, nil
+}
+
+// Ref returns the RecordRef for a backlinking record.
+func (lr LinkingRecord) Ref() RecordRef {
+	return RecordRef{DID: lr.DID, Collection: lr.Collection, Rkey: lr.Rkey}
+}
+
+// FetchResult is the outcome of fetching one record. Err is set if that record failed.
+type FetchResult struct {
+	Ref   RecordRef
+	Value map[string]any
+	CID   string
+	Err   error
+}
+
+// GetRecords fetches records concurrently, with at most FetchWorkers requests in flight.
+// Results are returned in the same order as refs.
+func GetRecords(ctx context.Context, client *atclient.APIClient, refs []RecordRef) []FetchResult {
+	results := make([]FetchResult, len(refs))
+	workers := min(max(FetchWorkers, 1), len(refs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ref := refs[i]
+				value, cid, err := GetRecord(ctx, client, ref.DID, ref.Collection, ref.Rkey)
+				results[i] = FetchResult{Ref: ref, Value: value, CID: cid, Err: err}
+			}
+		}()
+	}
+	for i := range refs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
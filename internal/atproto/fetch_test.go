@@ -0,0 +1,94 @@
+package atproto
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetRecords(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		rkey := r.URL.Query().Get("rkey")
+		if rkey == "missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "RecordNotFound", "message": "not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"uri":   "at://" + r.URL.Query().Get("repo") + "/" + r.URL.Query().Get("collection") + "/" + rkey,
+			"cid":   "cid-" + rkey,
+			"value": map[string]any{"rkey": rkey},
+		})
+	}))
+	defer srv.Close()
+
+	prev := FetchWorkers
+	FetchWorkers = 2
+	defer func() { FetchWorkers = prev }()
+
+	refs := []RecordRef{
+		{DID: "did:plc:a", Collection: CollectionMeasurement, Rkey: "r1"},
+		{DID: "did:plc:a", Collection: CollectionMeasurement, Rkey: "missing"},
+		{DID: "did:plc:b", Collection: CollectionMeasurement, Rkey: "r3"},
+		{DID: "did:plc:b", Collection: CollectionMeasurement, Rkey: "r4"},
+		{DID: "did:plc:b", Collection: CollectionMeasurement, Rkey: "r5"},
+	}
+	results := GetRecords(t.Context(), NewAPIClient(srv.URL, ""), refs)
+
+	if len(results) != len(refs) {
+		t.Fatalf("got %d results, want %d", len(results), len(refs))
+	}
+	for i, res := range results {
+		if res.Ref != refs[i] {
+			t.Errorf("result %d: ref %v, want %v", i, res.Ref, refs[i])
+		}
+		if refs[i].Rkey == "missing" {
+			if res.Err == nil {
+				t.Errorf("result %d: expected error", i)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("result %d: unexpected error %v", i, res.Err)
+			continue
+		}
+		if res.CID != "cid-"+refs[i].Rkey || res.Value["rkey"] != refs[i].Rkey {
+			t.Errorf("result %d: got cid %q value %v", i, res.CID, res.Value)
+		}
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency %d exceeds worker limit 2", p)
+	}
+}
+
+func TestParseRecordRef(t *testing.T) {
+	ref, err := ParseRecordRef("at://did:plc:abc/org.hypercerts.claim.activity/3k2abc")
+	if err != nil {
+		t.Fatalf("ParseRecordRef: %v", err)
+	}
+	want := RecordRef{DID: "did:plc:abc", Collection: "org.hypercerts.claim.activity", Rkey: "3k2abc"}
+	if ref != want {
+		t.Errorf("got %v, want %v", ref, want)
+	}
+	if ref.URI() != "at://did:plc:abc/org.hypercerts.claim.activity/3k2abc" {
+		t.Errorf("URI() = %q", ref.URI())
+	}
+
+	if _, err := ParseRecordRef("not-a-uri"); err == nil {
+		t.Error("expected error for invalid URI")
+	}
+}
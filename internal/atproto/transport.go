@@ -0,0 +1,288 @@
+package atproto
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bluesky-social/indigo/atproto/atclient"
+)
+
+// RetryConfig controls how outbound HTTP calls are retried.
+type RetryConfig struct {
+	MaxRetries int           // retries after the first attempt (0 disables retries)
+	BaseDelay  time.Duration // first backoff delay, doubled on each retry
+	MaxDelay   time.Duration // longest single wait; rate-limit resets beyond this are not waited for
+	Timeout    time.Duration // per-attempt timeout (0 disables)
+}
+
+// DefaultRetryConfig is used until SetRetryConfig is called.
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+	Timeout:    30 * time.Second,
+}
+
+// RetryTransport is an http.RoundTripper that retries rate-limited (429) and
+// server error responses with exponential backoff and full jitter. It honors the
+// ratelimit-reset and Retry-After headers, and pauses before the next request
+// once a ratelimit-remaining of 0 has been seen.
+//
+// 429 responses, and 503 responses that carry a rate-limit or Retry-After
+// header, are retried for every method since the server did not process the
+// request. Other 5xx responses (including a bare 503, after which the write
+// may already have been applied) and network errors are only retried for GET
+// and HEAD, so record creation is never duplicated.
+type RetryTransport struct {
+	Base   http.RoundTripper
+	Config RetryConfig // set at construction; change it later with SetConfig
+
+	// sleep waits for d or until ctx is done. Overridden in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// NewRetryTransport wraps base (http.DefaultTransport if nil) with retries.
+func NewRetryTransport(base http.RoundTripper, cfg RetryConfig) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{Base: base, Config: cfg, sleep: sleepCtx}
+}
+
+var sharedTransport = NewRetryTransport(nil, DefaultRetryConfig)
+
+// HTTPClient is the shared client for PDS and Constellation calls.
+var HTTPClient = &http.Client{Transport: sharedTransport}
+
+// SetRetryConfig replaces the retry settings used by HTTPClient.
+func SetRetryConfig(cfg RetryConfig) {
+	sharedTransport.SetConfig(cfg)
+}
+
+// SetConfig replaces the retry settings. It is safe to call while requests
+// are in flight; each request uses the settings current when it started.
+func (t *RetryTransport) SetConfig(cfg RetryConfig) {
+	t.mu.Lock()
+	t.Config = cfg
+	t.mu.Unlock()
+}
+
+func (t *RetryTransport) config() RetryConfig {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.Config
+}
+
+// NewAPIClient creates an unauthenticated API client for host that uses HTTPClient.
+func NewAPIClient(host, userAgent string) *atclient.APIClient {
+	c := atclient.NewAPIClient(host)
+	c.Client = HTTPClient
+	if userAgent != "" {
+		c.Headers.Set("User-Agent", userAgent)
+	}
+	return c
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	cfg := t.config()
+	for attempt := 0; ; attempt++ {
+		if err := t.waitForRateLimit(ctx, cfg); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.roundTripOnce(attemptReq, cfg)
+		if err == nil {
+			t.noteRateLimit(resp)
+		}
+
+		wait, retry := shouldRetry(cfg, req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTripOnce performs a single attempt, bounded by cfg.Timeout. The
+// timeout stays in force until the response body is closed.
+func (t *RetryTransport) roundTripOnce(req *http.Request, cfg RetryConfig) (*http.Response, error) {
+	if cfg.Timeout <= 0 {
+		return t.Base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), cfg.Timeout)
+	resp, err := t.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// shouldRetry reports whether the attempt should be retried and how long to wait first.
+func shouldRetry(cfg RetryConfig, req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= cfg.MaxRetries {
+		return 0, false
+	}
+	if req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	if err != nil {
+		return backoff(cfg, attempt), idempotent
+	}
+
+	wait, limited := rateLimitWait(resp.Header, time.Now())
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode == http.StatusServiceUnavailable && limited:
+		// A rate-limited 503 was rejected before processing.
+	case resp.StatusCode >= 500 && idempotent:
+	default:
+		return 0, false
+	}
+
+	if limited {
+		if wait > cfg.MaxDelay {
+			return 0, false
+		}
+		return wait, true
+	}
+	return backoff(cfg, attempt), true
+}
+
+// backoff returns a full-jitter exponential delay for the given attempt.
+func backoff(cfg RetryConfig, attempt int) time.Duration {
+	limit := cfg.BaseDelay << attempt
+	if limit <= 0 || limit > cfg.MaxDelay {
+		limit = cfg.MaxDelay
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit) + 1
+}
+
+// noteRateLimit records an exhausted rate-limit window so the next request waits for it.
+func (t *RetryTransport) noteRateLimit(resp *http.Response) {
+	if resp.Header.Get("ratelimit-remaining") != "0" {
+		return
+	}
+	reset, ok := parseRateLimitReset(resp.Header.Get("ratelimit-reset"))
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	if reset.After(t.blockedUntil) {
+		t.blockedUntil = reset
+	}
+	t.mu.Unlock()
+}
+
+// waitForRateLimit pauses until a previously exhausted window resets, up to MaxDelay.
+func (t *RetryTransport) waitForRateLimit(ctx context.Context, cfg RetryConfig) error {
+	t.mu.Lock()
+	wait := time.Until(t.blockedUntil)
+	t.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	if wait > cfg.MaxDelay {
+		wait = cfg.MaxDelay
+	}
+	return t.sleep(ctx, wait)
+}
+
+// rateLimitWait derives a wait from ratelimit-reset (unix seconds) or Retry-After.
+func rateLimitWait(h http.Header, now time.Time) (time.Duration, bool) {
+	if reset, ok := parseRateLimitReset(h.Get("ratelimit-reset")); ok {
+		return max(reset.Sub(now), 0), true
+	}
+	if ra := h.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(max(secs, 0)) * time.Second, true
+		}
+		if at, err := http.ParseTime(ra); err == nil {
+			return max(at.Sub(now), 0), true
+		}
+	}
+	return 0, false
+}
+
+func parseRateLimitReset(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
+// rewindRequest clones req with a fresh body for a retry.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases a per-attempt context once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,258 @@
+package atproto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestTransport returns a RetryTransport that records waits instead of sleeping.
+func newTestTransport(cfg RetryConfig, waits *[]time.Duration) *RetryTransport {
+	rt := NewRetryTransport(nil, cfg)
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return rt
+}
+
+var testRetryConfig = RetryConfig{
+	MaxRetries: 3,
+	BaseDelay:  10 * time.Millisecond,
+	MaxDelay:   time.Minute,
+	Timeout:    5 * time.Second,
+}
+
+func TestRetryTransport_retriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want 200", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls: got %d, want 3", calls.Load())
+	}
+	if len(waits) != 2 {
+		t.Fatalf("waits: got %d, want 2", len(waits))
+	}
+	for i, w := range waits {
+		if limit := testRetryConfig.BaseDelay << i; w <= 0 || w > limit {
+			t.Errorf("wait %d: got %v, want in (0, %v]", i, w, limit)
+		}
+	}
+}
+
+func TestRetryTransport_givesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status: got %d, want 503", resp.StatusCode)
+	}
+	if got, want := calls.Load(), int32(testRetryConfig.MaxRetries+1); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_postNotRetriedOnServerError(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+			resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+			if err != nil {
+				t.Fatalf("Post: %v", err)
+			}
+			resp.Body.Close()
+
+			if calls.Load() != 1 {
+				t.Errorf("calls: got %d, want 1", calls.Load())
+			}
+		})
+	}
+}
+
+func TestRetryTransport_postRetriedOnRateLimitedUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want 200", resp.StatusCode)
+	}
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Errorf("waits: got %v, want [1s]", waits)
+	}
+}
+
+func TestRetryTransport_postRetriedOnRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want 200", resp.StatusCode)
+	}
+	if len(waits) != 1 || waits[0] != 2*time.Second {
+		t.Errorf("waits: got %v, want [2s]", waits)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"a":1}` {
+		t.Errorf("retried body: got %q", bodies)
+	}
+}
+
+func TestRetryTransport_rateLimitResetBeyondMaxDelay(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		reset := time.Now().Add(time.Hour).Unix()
+		w.Header().Set("ratelimit-reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status: got %d, want 429", resp.StatusCode)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls: got %d, want 1", calls.Load())
+	}
+}
+
+func TestRetryTransport_waitsForExhaustedWindow(t *testing.T) {
+	reset := time.Now().Add(20 * time.Second).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ratelimit-remaining", "0")
+		w.Header().Set("ratelimit-reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: newTestTransport(testRetryConfig, &waits)}
+	for range 2 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(waits) != 1 {
+		t.Fatalf("waits: got %v, want one pause before the second request", waits)
+	}
+	if waits[0] <= 0 || waits[0] > 20*time.Second {
+		t.Errorf("pause: got %v, want in (0, 20s]", waits[0])
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"ratelimit_reset", map[string]string{"ratelimit-reset": "1700000030"}, 30 * time.Second, true},
+		{"reset_in_past", map[string]string{"ratelimit-reset": "1699999990"}, 0, true},
+		{"retry_after_seconds", map[string]string{"Retry-After": "5"}, 5 * time.Second, true},
+		{"retry_after_date", map[string]string{"Retry-After": now.Add(time.Minute).UTC().Format(http.TimeFormat)}, time.Minute, true},
+		{"none", map[string]string{}, 0, false},
+		{"garbage", map[string]string{"ratelimit-reset": "soon"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := rateLimitWait(h, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("rateLimitWait() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
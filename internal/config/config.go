@@ -0,0 +1,240 @@
+// Package config reads and writes the hc config file, ~/.config/hc/config.toml
+// (or $XDG_CONFIG_HOME/hc/config.toml). Top-level keys are global defaults;
+// [accounts."<handle or DID>"] sections override them for one account:
+//
+//	output = "json"
+//	currency = "USD"
+//
+//	[accounts."alice.example.com"]
+//	activity = "3lbxyz"
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/adrg/xdg"
+	"github.com/bluesky-social/indigo/atproto/syntax"
+)
+
+const fileName = "hc/config.toml"
+
+// accountsKey is the table holding per-account sections.
+const accountsKey = "accounts"
+
+// Key describes a setting.
+type Key struct {
+	Name     string
+	Usage    string
+	Validate func(string) error // nil accepts any non-empty value
+}
+
+// Keys are the settings the config file may hold, in display order.
+var Keys = []Key{
+	{"output", "default output format for list commands: table or json", oneOf("table", "json")},
+	{"pds-host", "PDS URL used by account login", httpURL},
+	{"plc-host", "PLC directory URL", httpURL},
+	{"backlink-url", "Constellation backlink index URL", httpURL},
+	{"activity", "activity ID or AT-URI used when create commands are not given one", nil},
+	{"currency", "currency for funding receipts, e.g. USD", nil},
+	{"measurer", "DID recorded as the measurer of new measurements", did},
+}
+
+// LookupKey returns the Key named name.
+func LookupKey(name string) (Key, bool) {
+	i := slices.IndexFunc(Keys, func(k Key) bool { return k.Name == name })
+	if i < 0 {
+		return Key{}, false
+	}
+	return Keys[i], true
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(s string) error {
+		if !slices.Contains(values, s) {
+			return fmt.Errorf("must be one of %s", strings.Join(values, ", "))
+		}
+		return nil
+	}
+}
+
+func httpURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an http(s) URL")
+	}
+	return nil
+}
+
+func did(s string) error {
+	if _, err := syntax.ParseDID(s); err != nil {
+		return errors.New("must be a DID")
+	}
+	return nil
+}
+
+// Validate checks value against the rules for key.
+func Validate(key, value string) error {
+	k, ok := LookupKey(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if value == "" {
+		return fmt.Errorf("%s: value must not be empty", key)
+	}
+	if k.Validate != nil {
+		if err := k.Validate(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Config is the parsed config file.
+type Config struct {
+	Global   map[string]string
+	Accounts map[string]map[string]string // keyed by handle or DID
+}
+
+// Path returns the config file location, creating its directory if needed.
+func Path() (string, error) {
+	return xdg.ConfigFile(fileName)
+}
+
+// Load reads the config file. A missing file yields an empty Config.
+func Load() (*Config, error) {
+	cfg := &Config{Global: map[string]string{}, Accounts: map[string]map[string]string{}}
+	path, err := xdg.SearchConfigFile(fileName)
+	if err != nil {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := cfg.parse(data); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) parse(data []byte) error {
+	var raw map[string]any
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return err
+	}
+	for k, v := range raw {
+		if k == accountsKey {
+			accounts, ok := v.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s must be a table of account sections", accountsKey)
+			}
+			for account, section := range accounts {
+				values, ok := section.(map[string]any)
+				if !ok {
+					return fmt.Errorf("accounts.%q must be a table", account)
+				}
+				c.Accounts[account] = map[string]string{}
+				for sk, sv := range values {
+					s, ok := sv.(string)
+					if !ok {
+						return fmt.Errorf("accounts.%q.%s must be a string", account, sk)
+					}
+					c.Accounts[account][sk] = s
+				}
+			}
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", k)
+		}
+		c.Global[k] = s
+	}
+	return nil
+}
+
+// Save writes the config file.
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := c.encode()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (c *Config) encode() ([]byte, error) {
+	raw := map[string]any{}
+	for k, v := range c.Global {
+		raw[k] = v
+	}
+	accounts := map[string]any{}
+	for account, values := range c.Accounts {
+		if len(values) > 0 {
+			accounts[account] = values
+		}
+	}
+	if len(accounts) > 0 {
+		raw[accountsKey] = accounts
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Get returns the value of key for the first of accounts with a section
+// setting it, falling back to the global value. The second result names
+// where the value came from: "account <name>" or "global".
+func (c *Config) Get(key string, accounts ...string) (value, source string, ok bool) {
+	for _, a := range accounts {
+		if v, ok := c.Accounts[a][key]; ok && v != "" {
+			return v, "account " + a, true
+		}
+	}
+	if v, ok := c.Global[key]; ok && v != "" {
+		return v, "global", true
+	}
+	return "", "", false
+}
+
+// Set validates and stores value for key, globally or in account's section.
+func (c *Config) Set(key, value, account string) error {
+	if err := Validate(key, value); err != nil {
+		return err
+	}
+	if account == "" {
+		c.Global[key] = value
+		return nil
+	}
+	if c.Accounts[account] == nil {
+		c.Accounts[account] = map[string]string{}
+	}
+	c.Accounts[account][key] = value
+	return nil
+}
+
+// Unset removes key globally or from account's section. It reports whether
+// the key was set.
+func (c *Config) Unset(key, account string) bool {
+	values := c.Global
+	if account != "" {
+		values = c.Accounts[account]
+	}
+	if _, ok := values[key]; !ok {
+		return false
+	}
+	delete(values, key)
+	return true
+}
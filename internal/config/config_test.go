@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+func setupTestXDG(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	return dir
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	setupTestXDG(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cfg.Get("output"); ok {
+		t.Error("empty config should have no values")
+	}
+}
+
+func TestLoadAndGet(t *testing.T) {
+	dir := setupTestXDG(t)
+	data := `
+output = "json"
+currency = "USD"
+
+[accounts."alice.example.com"]
+currency = "EUR"
+
+[accounts."did:plc:bob"]
+activity = "3lbxyz"
+`
+	if err := os.MkdirAll(filepath.Join(dir, "hc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "hc", "config.toml"), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key        string
+		accounts   []string
+		want       string
+		wantSource string
+	}{
+		{"currency", nil, "USD", "global"},
+		{"currency", []string{"alice.example.com", "did:plc:alice"}, "EUR", "account alice.example.com"},
+		{"currency", []string{"bob.example.com", "did:plc:bob"}, "USD", "global"},
+		{"activity", []string{"bob.example.com", "did:plc:bob"}, "3lbxyz", "account did:plc:bob"},
+		{"activity", nil, "", ""},
+	}
+	for _, tt := range tests {
+		got, source, _ := cfg.Get(tt.key, tt.accounts...)
+		if got != tt.want || source != tt.wantSource {
+			t.Errorf("Get(%q, %v) = %q from %q, want %q from %q", tt.key, tt.accounts, got, source, tt.want, tt.wantSource)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := setupTestXDG(t)
+	if err := os.MkdirAll(filepath.Join(dir, "hc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range []string{"output = ", "output = 3", "accounts = \"x\""} {
+		if err := os.WriteFile(filepath.Join(dir, "hc", "config.toml"), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(); err == nil {
+			t.Errorf("Load(%q) expected error", data)
+		}
+	}
+}
+
+func TestSetSaveRoundTrip(t *testing.T) {
+	setupTestXDG(t)
+	cfg, _ := Load()
+
+	for _, bad := range [][2]string{{"output", "yaml"}, {"pds-host", "pds.example.com"}, {"measurer", "alice"}, {"color", "red"}, {"currency", ""}} {
+		if err := cfg.Set(bad[0], bad[1], ""); err == nil {
+			t.Errorf("Set(%q, %q) expected error", bad[0], bad[1])
+		}
+	}
+	if err := cfg.Set("output", "json", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("measurer", "did:plc:m", "alice.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	path, _ := Path()
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `[accounts."alice.example.com"]`) {
+		t.Errorf("saved config = %s", data)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _, _ := loaded.Get("measurer", "alice.example.com"); v != "did:plc:m" {
+		t.Errorf("measurer = %q after round trip", v)
+	}
+	if !loaded.Unset("output", "") || loaded.Unset("output", "") {
+		t.Error("Unset should report whether the key was set")
+	}
+}
@@ -0,0 +1,178 @@
+// Package gitea imports repos from Gitea and Forgejo instances (including
+// Codeberg) via the /api/v1 REST API.
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// pageSize is the default maximum page size on Gitea and Forgejo.
+const pageSize = 50
+
+// Client imports repos from a Gitea or Forgejo instance. It implements source.Importer.
+type Client struct {
+	BaseURL    string       // instance root, e.g. https://codeberg.org
+	Token      string       // optional access token
+	HTTPClient *http.Client // http.DefaultClient if nil
+}
+
+// New returns a client for the instance at baseURL. token is optional.
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token}
+}
+
+var _ source.Importer = (*Client)(nil)
+
+// Name implements source.Importer.
+func (c *Client) Name() string { return "Gitea" }
+
+// ParseRepo accepts "owner/repo" or a repo URL on the instance.
+func (c *Client) ParseRepo(input string) (string, error) {
+	path, err := source.RepoPath(input, c.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		// Web URLs may point inside the repo, e.g. /owner/repo/src/branch/main.
+		path = parts[0] + "/" + parts[1]
+	}
+	return path, nil
+}
+
+func (c *Client) apiURL() string {
+	return strings.TrimRight(c.BaseURL, "/") + "/api/v1"
+}
+
+func (c *Client) header() http.Header {
+	h := http.Header{}
+	if c.Token != "" {
+		h.Set("Authorization", "token "+c.Token)
+	}
+	return h
+}
+
+// FetchRepo fetches repo metadata from GET /repos/{owner}/{repo}.
+func (c *Client) FetchRepo(ctx context.Context, path string) (*source.RepoInfo, error) {
+	if c.BaseURL == "" {
+		return nil, fmt.Errorf("no Gitea base URL configured")
+	}
+	var apiResp struct {
+		Name        string   `json:"name"`
+		FullName    string   `json:"full_name"`
+		Description string   `json:"description"`
+		HTMLURL     string   `json:"html_url"`
+		CreatedAt   string   `json:"created_at"`
+		UpdatedAt   string   `json:"updated_at"`
+		Language    string   `json:"language"`
+		Topics      []string `json:"topics"`
+		Licenses    []string `json:"licenses"`
+		AvatarURL   string   `json:"avatar_url"`
+		StarsCount  int      `json:"stars_count"`
+		Owner       struct {
+			Login     string `json:"login"`
+			AvatarURL string `json:"avatar_url"`
+		} `json:"owner"`
+	}
+	if _, err := source.GetJSON(ctx, c.HTTPClient, c.apiURL()+"/repos/"+path, c.header(), &apiResp); err != nil {
+		return nil, err
+	}
+
+	info := &source.RepoInfo{
+		Source:      c.Name(),
+		Owner:       apiResp.Owner.Login,
+		Name:        apiResp.Name,
+		FullName:    apiResp.FullName,
+		Description: apiResp.Description,
+		HTMLURL:     apiResp.HTMLURL,
+		CreatedAt:   apiResp.CreatedAt,
+		PushedAt:    apiResp.UpdatedAt,
+		Language:    apiResp.Language,
+		Topics:      apiResp.Topics,
+		AvatarURL:   apiResp.AvatarURL,
+		Stars:       apiResp.StarsCount,
+	}
+	if info.AvatarURL == "" {
+		info.AvatarURL = apiResp.Owner.AvatarURL
+	}
+	if len(apiResp.Licenses) > 0 {
+		info.License = apiResp.Licenses[0]
+	}
+	return info, nil
+}
+
+// FetchContributors tallies commits from GET /repos/{owner}/{repo}/commits,
+// since Gitea has no contributors endpoint. Commits linked to an account are
+// grouped by login; the rest by author email.
+func (c *Client) FetchContributors(ctx context.Context, path string) ([]source.Contributor, error) {
+	if c.BaseURL == "" {
+		return nil, fmt.Errorf("no Gitea base URL configured")
+	}
+	byKey := map[string]*source.Contributor{}
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("%s/repos/%s/commits?limit=%d&page=%d&stat=false&verification=false&files=false",
+			c.apiURL(), path, pageSize, page)
+		var commits []struct {
+			Commit struct {
+				Author struct {
+					Name  string `json:"name"`
+					Email string `json:"email"`
+				} `json:"author"`
+			} `json:"commit"`
+			Author *struct {
+				Login     string `json:"login"`
+				HTMLURL   string `json:"html_url"`
+				AvatarURL string `json:"avatar_url"`
+			} `json:"author"`
+		}
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &commits)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cm := range commits {
+			var key string
+			contrib := source.Contributor{Login: cm.Commit.Author.Name, Email: strings.ToLower(cm.Commit.Author.Email)}
+			if a := cm.Author; a != nil && a.Login != "" {
+				key = "user:" + a.Login
+				contrib.Login = a.Login
+				contrib.HTMLURL = a.HTMLURL
+				if contrib.HTMLURL == "" {
+					contrib.HTMLURL = strings.TrimRight(c.BaseURL, "/") + "/" + a.Login
+				}
+				contrib.AvatarURL = a.AvatarURL
+			} else {
+				key = "email:" + contrib.Email
+			}
+			if existing := byKey[key]; existing != nil {
+				existing.Contributions++
+				continue
+			}
+			contrib.Contributions = 1
+			byKey[key] = &contrib
+		}
+
+		hasMore := header.Get("X-HasMore")
+		if len(commits) < pageSize || hasMore == "false" || (hasMore == "" && !source.HasNextLink(header.Get("Link"))) {
+			break
+		}
+	}
+
+	all := make([]source.Contributor, 0, len(byKey))
+	for _, c := range byKey {
+		all = append(all, *c)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Contributions != all[j].Contributions {
+			return all[i].Contributions > all[j].Contributions
+		}
+		return all[i].Login < all[j].Login
+	})
+	return all, nil
+}
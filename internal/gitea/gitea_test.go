@@ -0,0 +1,98 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRepo(t *testing.T) {
+	c := New("https://codeberg.org", "")
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"forest/monitor", "forest/monitor", false},
+		{"https://codeberg.org/forest/monitor", "forest/monitor", false},
+		{"https://codeberg.org/forest/monitor/src/branch/main", "forest/monitor", false},
+		{"https://example.org/forest/monitor", "", true},
+		{"monitor", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := c.ParseRepo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRepo(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientFetch(t *testing.T) {
+	var gotAuth string
+	// 50 commits by alice on page 1, then 2 by bob (no account) and 1 more by alice.
+	commit := func(name, email, login string) string {
+		author := "null"
+		if login != "" {
+			author = fmt.Sprintf(`{"login":%q,"avatar_url":"https://a/%s"}`, login, login)
+		}
+		return fmt.Sprintf(`{"commit":{"author":{"name":%q,"email":%q}},"author":%s}`, name, email, author)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/api/v1/repos/forest/monitor":
+			fmt.Fprint(w, `{"name":"monitor","full_name":"forest/monitor","html_url":"https://codeberg.org/forest/monitor",
+				"updated_at":"2026-01-05T00:00:00Z","language":"Rust","licenses":["MIT"],"owner":{"login":"forest","avatar_url":"https://a/o"}}`)
+		case "/api/v1/repos/forest/monitor/commits":
+			var items []string
+			switch r.URL.Query().Get("page") {
+			case "1":
+				for range pageSize {
+					items = append(items, commit("Alice", "alice@example.org", "alice"))
+				}
+				w.Header().Set("X-HasMore", "true")
+			case "2":
+				items = append(items, commit("Bob", "Bob@Example.org", ""), commit("Bob", "bob@example.org", ""), commit("Alice", "alice@example.org", "alice"))
+				w.Header().Set("X-HasMore", "false")
+			}
+			fmt.Fprint(w, "["+strings.Join(items, ",")+"]")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	info, err := c.FetchRepo(context.Background(), "forest/monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "Gitea" || info.License != "MIT" || info.PushedAt != "2026-01-05T00:00:00Z" || info.AvatarURL != "https://a/o" {
+		t.Errorf("FetchRepo() = %+v", info)
+	}
+	if gotAuth != "token tok" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+
+	contribs, err := c.FetchContributors(context.Background(), "forest/monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contribs) != 2 {
+		t.Fatalf("got %d contributors %+v, want 2", len(contribs), contribs)
+	}
+	if a := contribs[0]; a.Login != "alice" || a.Contributions != 51 || a.Identifier() != srv.URL+"/alice" {
+		t.Errorf("alice = %+v", a)
+	}
+	if b := contribs[1]; b.EmailIdentifier() != "mailto:bob@example.org" || b.Contributions != 2 {
+		t.Errorf("bob = %+v", b)
+	}
+}
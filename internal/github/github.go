@@ -2,35 +2,64 @@ package github
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// RepoInfo and Contributor are the shared importer types.
+type (
+	RepoInfo    = source.RepoInfo
+	Contributor = source.Contributor
 )
 
-// RepoInfo holds parsed GitHub repo metadata.
-type RepoInfo struct {
-	Owner       string   // e.g. "GainForest"
-	Name        string   // e.g. "hypercerts-cli"
-	FullName    string   // e.g. "GainForest/hypercerts-cli"
-	Description string   // repo description
-	HTMLURL     string   // e.g. "https://github.com/GainForest/hypercerts-cli"
-	CreatedAt   string   // RFC3339 timestamp
-	PushedAt    string   // RFC3339 timestamp
-	Language    string   // primary language
-	Topics      []string // repo topics
-	License     string   // SPDX ID (e.g. "MIT"), empty if none
-	AvatarURL   string   // owner avatar URL
-}
-
-// Contributor holds a GitHub contributor with commit count.
-type Contributor struct {
-	Login         string // GitHub username
-	HTMLURL       string // profile URL
-	AvatarURL     string // avatar URL
-	Contributions int    // commit count — used directly as proportional weight
+// DefaultBaseURL is the public GitHub REST API.
+const DefaultBaseURL = "https://api.github.com"
+
+// Client imports repos from GitHub. It implements source.Importer.
+type Client struct {
+	BaseURL    string       // API root, DefaultBaseURL if empty (GitHub Enterprise: https://host/api/v3)
+	Token      string       // optional personal access token
+	HTTPClient *http.Client // http.DefaultClient if nil
+}
+
+// New returns a client for api.github.com. token is optional (empty string = unauthenticated).
+func New(token string) *Client {
+	return &Client{BaseURL: DefaultBaseURL, Token: token}
+}
+
+var _ source.Importer = (*Client)(nil)
+
+// Name implements source.Importer.
+func (c *Client) Name() string { return "GitHub" }
+
+// ParseRepo implements source.Importer using the package-level ParseRepo.
+func (c *Client) ParseRepo(input string) (string, error) {
+	owner, repo, err := ParseRepo(input)
+	if err != nil {
+		return "", err
+	}
+	return owner + "/" + repo, nil
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+func (c *Client) header() http.Header {
+	h := http.Header{}
+	if c.Token != "" {
+		h.Set("Authorization", "Bearer "+c.Token)
+	}
+	return h
 }
 
 // ParseRepo parses "owner/repo", "https://github.com/owner/repo", or
@@ -86,166 +115,273 @@ func ParseRepo(input string) (owner, repo string, err error) {
 	return owner, repo, nil
 }
 
-// FetchRepo fetches repo metadata from GET https://api.github.com/repos/{owner}/{repo}.
-// token is optional (empty string = unauthenticated).
-// Returns error on non-200 status (include status code in error message).
-func FetchRepo(ctx context.Context, owner, repo, token string) (*RepoInfo, error) {
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
-
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("User-Agent", "hc-cli")
-	if token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("github request failed: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("github returned %d", resp.StatusCode)
-	}
+// apiRepo is a repository as returned by the GitHub REST API.
+type apiRepo struct {
+	Name        string   `json:"name"`
+	FullName    string   `json:"full_name"`
+	Description string   `json:"description"`
+	HTMLURL     string   `json:"html_url"`
+	CreatedAt   string   `json:"created_at"`
+	PushedAt    string   `json:"pushed_at"`
+	Language    string   `json:"language"`
+	Topics      []string `json:"topics"`
+	Stars       int      `json:"stargazers_count"`
+	Archived    bool     `json:"archived"`
+	Fork        bool     `json:"fork"`
+	License     *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+	Owner struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"owner"`
+}
 
-	// Parse the GitHub API response
-	var apiResp struct {
-		Name        string   `json:"name"`
-		FullName    string   `json:"full_name"`
-		Description string   `json:"description"`
-		HTMLURL     string   `json:"html_url"`
-		CreatedAt   string   `json:"created_at"`
-		PushedAt    string   `json:"pushed_at"`
-		Language    string   `json:"language"`
-		Topics      []string `json:"topics"`
-		License     *struct {
-			SPDXID string `json:"spdx_id"`
-		} `json:"license"`
-		Owner struct {
-			Login     string `json:"login"`
-			AvatarURL string `json:"avatar_url"`
-		} `json:"owner"`
+func (r apiRepo) info(sourceName string) *RepoInfo {
+	info := &RepoInfo{
+		Source:      sourceName,
+		Owner:       r.Owner.Login,
+		Name:        r.Name,
+		FullName:    r.FullName,
+		Description: r.Description,
+		HTMLURL:     r.HTMLURL,
+		CreatedAt:   r.CreatedAt,
+		PushedAt:    r.PushedAt,
+		Language:    r.Language,
+		Topics:      r.Topics,
+		AvatarURL:   r.Owner.AvatarURL,
+		Stars:       r.Stars,
+		Archived:    r.Archived,
+		Fork:        r.Fork,
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode repo: %w", err)
+	if r.License != nil {
+		info.License = r.License.SPDXID
 	}
+	return info
+}
 
-	info := &RepoInfo{
-		Owner:       apiResp.Owner.Login,
-		Name:        apiResp.Name,
-		FullName:    apiResp.FullName,
-		Description: apiResp.Description,
-		HTMLURL:     apiResp.HTMLURL,
-		CreatedAt:   apiResp.CreatedAt,
-		PushedAt:    apiResp.PushedAt,
-		Language:    apiResp.Language,
-		Topics:      apiResp.Topics,
-		AvatarURL:   apiResp.Owner.AvatarURL,
+// FetchRepo fetches repo metadata from GET /repos/{owner}/{repo}.
+func (c *Client) FetchRepo(ctx context.Context, path string) (*RepoInfo, error) {
+	var apiResp apiRepo
+	if _, err := source.GetJSON(ctx, c.HTTPClient, c.baseURL()+"/repos/"+path, c.header(), &apiResp); err != nil {
+		return nil, err
 	}
+	return apiResp.info(c.Name()), nil
+}
 
-	if apiResp.License != nil {
-		info.License = apiResp.License.SPDXID
+// FetchOrgRepos lists the repos of an organization from GET /orgs/{org}/repos,
+// falling back to GET /users/{user}/repos when owner is a user account.
+func (c *Client) FetchOrgRepos(ctx context.Context, owner string) ([]*RepoInfo, error) {
+	repos, err := c.fetchRepoList(ctx, "/orgs/"+url.PathEscape(owner)+"/repos?type=all")
+	var se *source.StatusError
+	if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+		repos, err = c.fetchRepoList(ctx, "/users/"+url.PathEscape(owner)+"/repos?type=owner")
 	}
-
-	return info, nil
+	return repos, err
 }
 
-// FetchContributors fetches contributors from GET https://api.github.com/repos/{owner}/{repo}/contributors.
-// Paginates (100 per page) until all contributors are fetched.
-// token is optional.
-func FetchContributors(ctx context.Context, owner, repo, token string) ([]Contributor, error) {
-	var all []Contributor
-	page := 1
-
-	for {
-		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contributors?per_page=100&page=%d", owner, repo, page)
-
-		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		req, err := http.NewRequestWithContext(reqCtx, "GET", apiURL, nil)
+func (c *Client) fetchRepoList(ctx context.Context, endpoint string) ([]*RepoInfo, error) {
+	var all []*RepoInfo
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("%s%s&per_page=100&page=%d", c.baseURL(), endpoint, page)
+		var repos []apiRepo
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &repos)
 		if err != nil {
-			cancel()
 			return nil, err
 		}
-
-		req.Header.Set("User-Agent", "hc-cli")
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
+		for _, r := range repos {
+			all = append(all, r.info(c.Name()))
 		}
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			cancel()
-			return nil, fmt.Errorf("github request failed: %w", err)
+		if len(repos) < 100 || !source.HasNextLink(header.Get("Link")) {
+			break
 		}
+	}
+	return all, nil
+}
 
-		if resp.StatusCode != 200 {
-			_ = resp.Body.Close()
-			cancel()
-			return nil, fmt.Errorf("github returned %d", resp.StatusCode)
-		}
+// FetchContributors fetches contributors from GET /repos/{owner}/{repo}/contributors.
+// Paginates (100 per page) until all contributors are fetched.
+func (c *Client) FetchContributors(ctx context.Context, path string) ([]Contributor, error) {
+	var all []Contributor
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("%s/repos/%s/contributors?per_page=100&page=%d", c.baseURL(), path, page)
 
 		var pageContribs []struct {
 			Login         string `json:"login"`
 			HTMLURL       string `json:"html_url"`
 			AvatarURL     string `json:"avatar_url"`
+			Type          string `json:"type"`
 			Contributions int    `json:"contributions"`
 		}
-
-		if err := json.NewDecoder(resp.Body).Decode(&pageContribs); err != nil {
-			_ = resp.Body.Close()
-			cancel()
-			return nil, fmt.Errorf("failed to decode contributors: %w", err)
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &pageContribs)
+		if err != nil {
+			return nil, err
 		}
-		_ = resp.Body.Close()
-		cancel()
 
 		// Convert to our type
-		for _, c := range pageContribs {
+		for _, pc := range pageContribs {
 			all = append(all, Contributor{
-				Login:         c.Login,
-				HTMLURL:       c.HTMLURL,
-				AvatarURL:     c.AvatarURL,
-				Contributions: c.Contributions,
+				Login:         pc.Login,
+				HTMLURL:       pc.HTMLURL,
+				AvatarURL:     pc.AvatarURL,
+				Contributions: pc.Contributions,
+				Bot:           pc.Type == "Bot",
 			})
 		}
 
-		// If we got fewer than 100, we're done
-		if len(pageContribs) < 100 {
+		// If we got fewer than 100, or there is no next page, we're done
+		if len(pageContribs) < 100 || !source.HasNextLink(header.Get("Link")) {
 			break
 		}
+	}
 
-		// Check Link header for next page
-		linkHeader := resp.Header.Get("Link")
-		if !hasNextPage(linkHeader) {
+	return all, nil
+}
+
+// Release is a published GitHub release.
+type Release struct {
+	TagName     string
+	Name        string
+	Body        string // release notes (markdown)
+	HTMLURL     string
+	PublishedAt string // RFC3339 timestamp
+	Prerelease  bool
+	Assets      []ReleaseAsset
+}
+
+// ReleaseAsset is a file attached to a release.
+type ReleaseAsset struct {
+	Name string
+	URL  string // browser download URL
+}
+
+// FetchReleases fetches published releases from GET /repos/{owner}/{repo}/releases,
+// newest first. Drafts are skipped.
+func (c *Client) FetchReleases(ctx context.Context, path string) ([]Release, error) {
+	var all []Release
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("%s/repos/%s/releases?per_page=100&page=%d", c.baseURL(), path, page)
+		var pageReleases []struct {
+			TagName     string `json:"tag_name"`
+			Name        string `json:"name"`
+			Body        string `json:"body"`
+			HTMLURL     string `json:"html_url"`
+			PublishedAt string `json:"published_at"`
+			Draft       bool   `json:"draft"`
+			Prerelease  bool   `json:"prerelease"`
+			Assets      []struct {
+				Name               string `json:"name"`
+				BrowserDownloadURL string `json:"browser_download_url"`
+			} `json:"assets"`
+		}
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &pageReleases)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range pageReleases {
+			if r.Draft {
+				continue
+			}
+			rel := Release{
+				TagName:     r.TagName,
+				Name:        r.Name,
+				Body:        r.Body,
+				HTMLURL:     r.HTMLURL,
+				PublishedAt: r.PublishedAt,
+				Prerelease:  r.Prerelease,
+			}
+			for _, a := range r.Assets {
+				rel.Assets = append(rel.Assets, ReleaseAsset{Name: a.Name, URL: a.BrowserDownloadURL})
+			}
+			all = append(all, rel)
+		}
+		if len(pageReleases) < 100 || !source.HasNextLink(header.Get("Link")) {
 			break
 		}
-
-		page++
 	}
-
 	return all, nil
 }
 
-// hasNextPage checks if the Link header contains rel="next"
-func hasNextPage(linkHeader string) bool {
-	if linkHeader == "" {
-		return false
+// CountIssues returns the number of issues and pull requests matching a
+// search query, e.g. "repo:owner/name is:pr is:merged merged:2025-01-01..2025-12-31".
+func (c *Client) CountIssues(ctx context.Context, query string) (int, error) {
+	apiURL := c.baseURL() + "/search/issues?per_page=1&q=" + url.QueryEscape(query)
+	var resp struct {
+		TotalCount int `json:"total_count"`
+	}
+	if _, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &resp); err != nil {
+		return 0, err
+	}
+	return resp.TotalCount, nil
+}
+
+// statsRetries bounds polling while GitHub computes contributor statistics.
+var statsRetries, statsDelay = 5, 2 * time.Second
+
+// FetchAdditions returns lines added per login from GET /repos/{owner}/{repo}/stats/contributors.
+// GitHub answers 202 while it computes the statistics, so the request is retried.
+func (c *Client) FetchAdditions(ctx context.Context, path string) (map[string]int, error) {
+	var stats []struct {
+		Author *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Weeks []struct {
+			Additions int `json:"a"`
+		} `json:"weeks"`
+	}
+	for attempt := 0; ; attempt++ {
+		_, err := source.GetJSON(ctx, c.HTTPClient, c.baseURL()+"/repos/"+path+"/stats/contributors", c.header(), &stats)
+		var se *source.StatusError
+		if errors.As(err, &se) && se.StatusCode == http.StatusAccepted && attempt < statsRetries {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(statsDelay):
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	additions := map[string]int{}
+	for _, s := range stats {
+		if s.Author == nil {
+			continue
+		}
+		for _, wk := range s.Weeks {
+			additions[s.Author.Login] += wk.Additions
+		}
 	}
+	return additions, nil
+}
 
-	// Link header format: <url>; rel="next", <url>; rel="last"
-	links := strings.Split(linkHeader, ",")
-	for _, link := range links {
-		if strings.Contains(link, `rel="next"`) {
-			return true
+// FetchMergedPRCounts returns merged pull requests per author login, from
+// GET /repos/{owner}/{repo}/pulls?state=closed.
+func (c *Client) FetchMergedPRCounts(ctx context.Context, path string) (map[string]int, error) {
+	counts := map[string]int{}
+	for page := 1; ; page++ {
+		apiURL := fmt.Sprintf("%s/repos/%s/pulls?state=closed&per_page=100&page=%d", c.baseURL(), path, page)
+		var pulls []struct {
+			MergedAt *string `json:"merged_at"`
+			User     *struct {
+				Login string `json:"login"`
+			} `json:"user"`
+		}
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &pulls)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pulls {
+			if p.MergedAt != nil && p.User != nil {
+				counts[p.User.Login]++
+			}
+		}
+		if len(pulls) < 100 || !source.HasNextLink(header.Get("Link")) {
+			break
 		}
 	}
-	return false
+	return counts, nil
 }
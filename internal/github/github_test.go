@@ -1,7 +1,13 @@
 package github
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseRepo(t *testing.T) {
@@ -145,3 +151,187 @@ func TestParseRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestClientFetch(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch {
+		case r.URL.Path == "/repos/GainForest/hypercerts-cli":
+			fmt.Fprint(w, `{"name":"hypercerts-cli","full_name":"GainForest/hypercerts-cli","html_url":"https://github.com/GainForest/hypercerts-cli",
+				"pushed_at":"2026-03-09T12:00:00Z","language":"Go","license":{"spdx_id":"MIT"},"owner":{"login":"GainForest","avatar_url":"https://a/1"}}`)
+		case r.URL.Path == "/repos/GainForest/hypercerts-cli/contributors":
+			page := r.URL.Query().Get("page")
+			var items []string
+			for i := range 100 {
+				if page == "2" && i == 2 {
+					break
+				}
+				items = append(items, fmt.Sprintf(`{"login":"u%s-%d","html_url":"https://github.com/u%s-%d","contributions":%d}`, page, i, page, i, 200-i))
+			}
+			if page == "1" {
+				w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next", <`+r.URL.Path+`?page=2>; rel="last"`)
+			}
+			fmt.Fprint(w, "["+strings.Join(items, ",")+"]")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseURL: srv.URL, Token: "tok"}
+	info, err := c.FetchRepo(context.Background(), "GainForest/hypercerts-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "GitHub" || info.License != "MIT" || info.PushedAt != "2026-03-09T12:00:00Z" || info.Owner != "GainForest" {
+		t.Errorf("FetchRepo() = %+v", info)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+
+	contribs, err := c.FetchContributors(context.Background(), "GainForest/hypercerts-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contribs) != 102 {
+		t.Fatalf("got %d contributors, want 102", len(contribs))
+	}
+	if contribs[0].Identifier() != "https://github.com/u1-0" || contribs[0].Contributions != 200 {
+		t.Errorf("first contributor = %+v", contribs[0])
+	}
+
+	if _, err := c.FetchRepo(context.Background(), "GainForest/missing"); err == nil {
+		t.Error("expected error for 404")
+	}
+}
+
+func TestClientReleasesAndSearch(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/releases":
+			fmt.Fprint(w, `[
+				{"tag_name":"v1.1.0","name":"","body":"Fixes","html_url":"https://github.com/o/r/releases/v1.1.0","published_at":"2026-02-01T00:00:00Z",
+				 "assets":[{"name":"hc.tar.gz","browser_download_url":"https://github.com/o/r/releases/download/v1.1.0/hc.tar.gz"}]},
+				{"tag_name":"v1.2.0-draft","draft":true},
+				{"tag_name":"v1.0.0","name":"First","prerelease":true}
+			]`)
+		case "/search/issues":
+			gotQuery = r.URL.Query().Get("q")
+			fmt.Fprint(w, `{"total_count":42,"items":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseURL: srv.URL}
+	releases, err := c.FetchReleases(context.Background(), "o/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("got %d releases, want 2 (draft skipped)", len(releases))
+	}
+	if r := releases[0]; r.TagName != "v1.1.0" || len(r.Assets) != 1 || r.Assets[0].Name != "hc.tar.gz" {
+		t.Errorf("release = %+v", r)
+	}
+	if !releases[1].Prerelease {
+		t.Errorf("expected prerelease flag")
+	}
+
+	n, err := c.CountIssues(context.Background(), "repo:o/r is:pr is:merged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 42 || gotQuery != "repo:o/r is:pr is:merged" {
+		t.Errorf("CountIssues() = %d, query %q", n, gotQuery)
+	}
+}
+
+func TestClientContributorWeights(t *testing.T) {
+	defer func(d time.Duration) { statsDelay = d }(statsDelay)
+	statsDelay = time.Millisecond
+
+	statsCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/stats/contributors":
+			statsCalls++
+			if statsCalls == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprint(w, `[
+				{"author":{"login":"alice"},"weeks":[{"a":100},{"a":20}]},
+				{"author":null,"weeks":[{"a":5}]},
+				{"author":{"login":"bob"},"weeks":[{"a":7}]}
+			]`)
+		case "/repos/o/r/pulls":
+			fmt.Fprint(w, `[
+				{"merged_at":"2026-01-01T00:00:00Z","user":{"login":"alice"}},
+				{"merged_at":null,"user":{"login":"bob"}},
+				{"merged_at":"2026-01-02T00:00:00Z","user":{"login":"alice"}},
+				{"merged_at":"2026-01-03T00:00:00Z","user":{"login":"bob"}}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseURL: srv.URL}
+	additions, err := c.FetchAdditions(context.Background(), "o/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statsCalls != 2 || len(additions) != 2 || additions["alice"] != 120 || additions["bob"] != 7 {
+		t.Errorf("FetchAdditions() = %v after %d calls", additions, statsCalls)
+	}
+
+	prs, err := c.FetchMergedPRCounts(context.Background(), "o/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prs["alice"] != 2 || prs["bob"] != 1 {
+		t.Errorf("FetchMergedPRCounts() = %v", prs)
+	}
+}
+
+func TestClientFetchOrgRepos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/someone/repos":
+			http.NotFound(w, r)
+		case "/users/someone/repos":
+			fmt.Fprint(w, `[
+				{"name":"a","full_name":"someone/a","language":"Go","topics":["x"],"archived":true,"owner":{"login":"someone"}},
+				{"name":"b","full_name":"someone/b","fork":true,"license":{"spdx_id":"MIT"},"owner":{"login":"someone"}}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseURL: srv.URL}
+	repos, err := c.FetchOrgRepos(context.Background(), "someone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("got %d repos, want 2", len(repos))
+	}
+	if r := repos[0]; r.FullName != "someone/a" || !r.Archived || r.Source != "GitHub" || r.Language != "Go" {
+		t.Errorf("repo a = %+v", r)
+	}
+	if r := repos[1]; !r.Fork || r.License != "MIT" {
+		t.Errorf("repo b = %+v", r)
+	}
+
+	if _, err := c.FetchOrgRepos(context.Background(), "nobody"); err == nil {
+		t.Error("expected error for unknown owner")
+	}
+}
@@ -0,0 +1,178 @@
+// Package gitlab imports repos from GitLab (gitlab.com or self-hosted) via the v4 REST API.
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/GainForest/hypercerts-cli/internal/source"
+)
+
+// DefaultBaseURL is gitlab.com.
+const DefaultBaseURL = "https://gitlab.com"
+
+// Client imports projects from a GitLab instance. It implements source.Importer.
+type Client struct {
+	BaseURL    string       // instance root, e.g. https://gitlab.example.org; DefaultBaseURL if empty
+	Token      string       // optional personal access token
+	HTTPClient *http.Client // http.DefaultClient if nil
+}
+
+// New returns a client for the instance at baseURL. token is optional.
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token}
+}
+
+var _ source.Importer = (*Client)(nil)
+
+// Name implements source.Importer.
+func (c *Client) Name() string { return "GitLab" }
+
+// ParseRepo accepts "group/project", "group/subgroup/project", or a project URL.
+func (c *Client) ParseRepo(input string) (string, error) {
+	return source.RepoPath(input, c.baseURL())
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+func (c *Client) header() http.Header {
+	h := http.Header{}
+	if c.Token != "" {
+		h.Set("PRIVATE-TOKEN", c.Token)
+	}
+	return h
+}
+
+// projectURL returns the API URL for a project; the path is passed URL-encoded as its ID.
+func (c *Client) projectURL(path string) string {
+	return c.baseURL() + "/api/v4/projects/" + url.PathEscape(path)
+}
+
+// FetchRepo fetches project metadata from GET /projects/:id, plus the primary
+// language from GET /projects/:id/languages.
+func (c *Client) FetchRepo(ctx context.Context, path string) (*source.RepoInfo, error) {
+	var apiResp struct {
+		Name              string   `json:"name"`
+		PathWithNamespace string   `json:"path_with_namespace"`
+		Description       string   `json:"description"`
+		WebURL            string   `json:"web_url"`
+		CreatedAt         string   `json:"created_at"`
+		LastActivityAt    string   `json:"last_activity_at"`
+		Topics            []string `json:"topics"`
+		AvatarURL         string   `json:"avatar_url"`
+		StarCount         int      `json:"star_count"`
+		License           *struct {
+			Key  string `json:"key"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Namespace struct {
+			FullPath  string `json:"full_path"`
+			AvatarURL string `json:"avatar_url"`
+		} `json:"namespace"`
+	}
+	if _, err := source.GetJSON(ctx, c.HTTPClient, c.projectURL(path)+"?license=true", c.header(), &apiResp); err != nil {
+		return nil, err
+	}
+
+	info := &source.RepoInfo{
+		Source:      c.Name(),
+		Owner:       apiResp.Namespace.FullPath,
+		Name:        apiResp.Name,
+		FullName:    apiResp.PathWithNamespace,
+		Description: apiResp.Description,
+		HTMLURL:     apiResp.WebURL,
+		CreatedAt:   apiResp.CreatedAt,
+		PushedAt:    apiResp.LastActivityAt,
+		Topics:      apiResp.Topics,
+		AvatarURL:   apiResp.AvatarURL,
+		Stars:       apiResp.StarCount,
+	}
+	if info.AvatarURL == "" {
+		info.AvatarURL = apiResp.Namespace.AvatarURL
+	}
+	if apiResp.License != nil {
+		info.License = spdxID(apiResp.License.Key, apiResp.License.Name)
+	}
+
+	// Language percentages; the largest share is the primary language.
+	var languages map[string]float64
+	if _, err := source.GetJSON(ctx, c.HTTPClient, c.projectURL(path)+"/languages", c.header(), &languages); err == nil {
+		var best float64
+		for lang, pct := range languages {
+			if pct > best || (pct == best && lang < info.Language) {
+				info.Language, best = lang, pct
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// FetchContributors fetches GET /projects/:id/repository/contributors, following
+// X-Next-Page until all pages are read. GitLab groups contributors by commit
+// email and does not link them to accounts.
+func (c *Client) FetchContributors(ctx context.Context, path string) ([]source.Contributor, error) {
+	var all []source.Contributor
+	page := "1"
+	for page != "" {
+		apiURL := fmt.Sprintf("%s/repository/contributors?per_page=100&order_by=commits&sort=desc&page=%s", c.projectURL(path), page)
+		var pageContribs []struct {
+			Name      string `json:"name"`
+			Email     string `json:"email"`
+			Commits   int    `json:"commits"`
+			Additions int    `json:"additions"`
+		}
+		header, err := source.GetJSON(ctx, c.HTTPClient, apiURL, c.header(), &pageContribs)
+		if err != nil {
+			return nil, err
+		}
+		for _, pc := range pageContribs {
+			all = append(all, source.Contributor{
+				Login:         pc.Name,
+				Email:         pc.Email,
+				Contributions: pc.Commits,
+				Additions:     pc.Additions,
+			})
+		}
+		page = header.Get("X-Next-Page")
+		if len(pageContribs) == 0 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// spdxID maps GitLab's lowercase license keys to SPDX identifiers.
+func spdxID(key, name string) string {
+	known := map[string]string{
+		"mit":          "MIT",
+		"apache-2.0":   "Apache-2.0",
+		"gpl-2.0":      "GPL-2.0",
+		"gpl-3.0":      "GPL-3.0",
+		"agpl-3.0":     "AGPL-3.0",
+		"lgpl-2.1":     "LGPL-2.1",
+		"lgpl-3.0":     "LGPL-3.0",
+		"mpl-2.0":      "MPL-2.0",
+		"bsd-2-clause": "BSD-2-Clause",
+		"bsd-3-clause": "BSD-3-Clause",
+		"unlicense":    "Unlicense",
+		"cc0-1.0":      "CC0-1.0",
+		"epl-2.0":      "EPL-2.0",
+		"isc":          "ISC",
+	}
+	if id, ok := known[strings.ToLower(key)]; ok {
+		return id
+	}
+	if key == "" {
+		return name
+	}
+	return key
+}
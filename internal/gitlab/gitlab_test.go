@@ -0,0 +1,88 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseRepo(t *testing.T) {
+	c := New("https://gitlab.example.org", "")
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"group/project", "group/project", false},
+		{"group/sub/project", "group/sub/project", false},
+		{"https://gitlab.example.org/group/sub/project", "group/sub/project", false},
+		{"https://gitlab.example.org/group/project/-/tree/main", "group/project", false},
+		{"https://gitlab.example.org/group/project.git", "group/project", false},
+		{"https://gitlab.com/group/project", "", true},
+		{"project", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := c.ParseRepo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRepo(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientFetch(t *testing.T) {
+	var gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("PRIVATE-TOKEN")
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/forest%2Ftools%2Fmonitor":
+			if r.URL.Query().Get("license") != "true" {
+				t.Errorf("expected license=true, got %q", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"name":"monitor","path_with_namespace":"forest/tools/monitor","web_url":"https://gitlab.example.org/forest/tools/monitor",
+				"created_at":"2024-01-01T00:00:00Z","last_activity_at":"2026-02-01T00:00:00Z","license":{"key":"apache-2.0","name":"Apache License 2.0"},
+				"namespace":{"full_path":"forest/tools","avatar_url":"https://a/ns"}}`)
+		case "/api/v4/projects/forest%2Ftools%2Fmonitor/languages":
+			fmt.Fprint(w, `{"Python":61.2,"Go":38.8}`)
+		case "/api/v4/projects/forest%2Ftools%2Fmonitor/repository/contributors":
+			switch r.URL.Query().Get("page") {
+			case "1":
+				w.Header().Set("X-Next-Page", "2")
+				fmt.Fprint(w, `[{"name":"Alice","email":"Alice@example.org","commits":12}]`)
+			case "2":
+				w.Header().Set("X-Next-Page", "")
+				fmt.Fprint(w, `[{"name":"Bob","email":"bob@example.org","commits":3}]`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "glpat")
+	info, err := c.FetchRepo(context.Background(), "forest/tools/monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "GitLab" || info.License != "Apache-2.0" || info.Language != "Python" ||
+		info.PushedAt != "2026-02-01T00:00:00Z" || info.AvatarURL != "https://a/ns" {
+		t.Errorf("FetchRepo() = %+v", info)
+	}
+	if gotToken != "glpat" {
+		t.Errorf("PRIVATE-TOKEN = %q", gotToken)
+	}
+
+	contribs, err := c.FetchContributors(context.Background(), "forest/tools/monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contribs) != 2 || contribs[0].EmailIdentifier() != "mailto:alice@example.org" || contribs[1].Contributions != 3 {
+		t.Errorf("FetchContributors() = %+v", contribs)
+	}
+}
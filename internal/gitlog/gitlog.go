@@ -0,0 +1,194 @@
+package gitlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RepoInfo holds metadata about a local git repository.
+type RepoInfo struct {
+	Name        string    // directory name of the work tree
+	Path        string    // absolute path of the work tree
+	RemoteURL   string    // origin URL, empty if none
+	FirstCommit time.Time // author date of the oldest commit
+	LastCommit  time.Time // author date of the newest commit
+	Commits     int       // non-merge commit count
+}
+
+// Author aggregates the commits of one author email.
+type Author struct {
+	Name      string // most recent name used with this email
+	Email     string // lowercased
+	Commits   int
+	Additions int
+	Deletions int
+	First     time.Time
+	Last      time.Time
+}
+
+// Record and field separators in the git log format; they never occur in names or emails.
+const (
+	recordSep = "\x1e"
+	fieldSep  = "\x1f"
+)
+
+// Read runs git log in path and aggregates authors. Merge commits are skipped
+// and .mailmap is honored so aliases collapse onto one email.
+func Read(ctx context.Context, path string) (*RepoInfo, []Author, error) {
+	top, err := git(ctx, path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a git repository: %s", path)
+	}
+	top = strings.TrimSpace(top)
+
+	out, err := git(ctx, top, "log", "--no-merges", "--use-mailmap", "--numstat",
+		"--format=format:"+recordSep+"%aN"+fieldSep+"%aE"+fieldSep+"%aI")
+	if err != nil {
+		return nil, nil, fmt.Errorf("git log failed: %w", err)
+	}
+	authors, err := ParseLog(strings.NewReader(out))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(authors) == 0 {
+		return nil, nil, fmt.Errorf("no commits in %s", top)
+	}
+
+	info := &RepoInfo{Name: filepath.Base(top), Path: top}
+	if remote, err := git(ctx, top, "remote", "get-url", "origin"); err == nil {
+		info.RemoteURL = strings.TrimSpace(remote)
+	}
+	for i, a := range authors {
+		info.Commits += a.Commits
+		if i == 0 || a.First.Before(info.FirstCommit) {
+			info.FirstCommit = a.First
+		}
+		if a.Last.After(info.LastCommit) {
+			info.LastCommit = a.Last
+		}
+	}
+	return info, authors, nil
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ParseLog aggregates the output of git log in Read's format, newest commit
+// first, into authors sorted by commit count (then email).
+func ParseLog(r io.Reader) ([]Author, error) {
+	byEmail := map[string]*Author{}
+	var cur *Author
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if header, ok := strings.CutPrefix(line, recordSep); ok {
+			fields := strings.Split(header, fieldSep)
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("malformed commit header %q", header)
+			}
+			date, err := time.Parse(time.RFC3339, fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("malformed commit date %q", fields[2])
+			}
+			email := strings.ToLower(strings.TrimSpace(fields[1]))
+			cur = byEmail[email]
+			if cur == nil {
+				// Log is newest first, so the first name seen is the latest.
+				cur = &Author{Name: fields[0], Email: email, First: date, Last: date}
+				byEmail[email] = cur
+			}
+			cur.Commits++
+			if date.Before(cur.First) {
+				cur.First = date
+			}
+			if date.After(cur.Last) {
+				cur.Last = date
+			}
+			continue
+		}
+		if cur == nil || line == "" {
+			continue
+		}
+		// numstat: "<added>\t<deleted>\t<path>", "-" for binary files.
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			cur.Additions += n
+		}
+		if n, err := strconv.Atoi(parts[1]); err == nil {
+			cur.Deletions += n
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	authors := make([]Author, 0, len(byEmail))
+	for _, a := range byEmail {
+		authors = append(authors, *a)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Email < authors[j].Email
+	})
+	return authors, nil
+}
+
+// ParseAuthorMap reads "email,identity" lines (CSV, # comments allowed) mapping
+// author emails to identities such as DIDs. Emails are matched case-insensitively.
+func ParseAuthorMap(r io.Reader) (map[string]string, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	m := map[string]string{}
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read author map: %w", err)
+		}
+		if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(row[0]))
+		if email == "email" {
+			continue
+		}
+		if len(row) < 2 || strings.TrimSpace(row[1]) == "" {
+			return nil, fmt.Errorf("author map: no identity for %s", email)
+		}
+		m[email] = strings.TrimSpace(row[1])
+	}
+	return m, nil
+}
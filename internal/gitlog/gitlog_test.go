@@ -0,0 +1,119 @@
+package gitlog
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLog(t *testing.T) {
+	log := strings.Join([]string{
+		recordSep + "Alice Smith" + fieldSep + "Alice@Example.org" + fieldSep + "2025-03-01T10:00:00+00:00",
+		"10\t2\tmain.go",
+		"-\t-\tlogo.png",
+		"",
+		recordSep + "Bob" + fieldSep + "bob@example.org" + fieldSep + "2025-02-01T10:00:00+00:00",
+		"5\t0\tREADME.md",
+		"",
+		recordSep + "alice" + fieldSep + "alice@example.org" + fieldSep + "2025-01-01T10:00:00+00:00",
+		"1\t1\tmain.go",
+	}, "\n")
+
+	authors, err := ParseLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(authors) != 2 {
+		t.Fatalf("got %d authors %+v, want 2", len(authors), authors)
+	}
+	a := authors[0]
+	if a.Email != "alice@example.org" || a.Name != "Alice Smith" || a.Commits != 2 {
+		t.Errorf("alice = %+v", a)
+	}
+	if a.Additions != 11 || a.Deletions != 3 {
+		t.Errorf("alice lines = +%d -%d, want +11 -3", a.Additions, a.Deletions)
+	}
+	if a.First.Format("2006-01-02") != "2025-01-01" || a.Last.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("alice dates = %v..%v", a.First, a.Last)
+	}
+	if b := authors[1]; b.Email != "bob@example.org" || b.Commits != 1 || b.Additions != 5 {
+		t.Errorf("bob = %+v", b)
+	}
+}
+
+func TestParseLogMalformed(t *testing.T) {
+	if _, err := ParseLog(strings.NewReader(recordSep + "no fields")); err == nil {
+		t.Error("expected error for malformed header")
+	}
+}
+
+func TestParseAuthorMap(t *testing.T) {
+	input := `email,did
+# maintainers
+Alice@Example.org, did:plc:alice
+bob@example.org,did:web:bob.example.org
+`
+	m, err := ParseAuthorMap(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 2 || m["alice@example.org"] != "did:plc:alice" || m["bob@example.org"] != "did:web:bob.example.org" {
+		t.Errorf("ParseAuthorMap() = %v", m)
+	}
+
+	if _, err := ParseAuthorMap(strings.NewReader("carol@example.org\n")); err == nil {
+		t.Error("expected error for missing identity")
+	}
+}
+
+func TestRead(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(name, email, date, file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("one\ntwo\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		run(nil, "add", file)
+		run([]string{
+			"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email, "GIT_AUTHOR_DATE=" + date,
+			"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email, "GIT_COMMITTER_DATE=" + date,
+		}, "-c", "commit.gpgsign=false", "commit", "-q", "-m", "add "+file)
+	}
+
+	run(nil, "init", "-q")
+	commit("Alice", "alice@example.org", "2025-01-01T10:00:00Z", "a.txt")
+	commit("Bob", "bob@example.org", "2025-02-01T10:00:00Z", "b.txt")
+	commit("Alice", "alice@example.org", "2025-03-01T10:00:00Z", "c.txt")
+
+	info, authors, err := Read(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Commits != 3 || info.Name != filepath.Base(info.Path) {
+		t.Errorf("info = %+v", info)
+	}
+	if info.FirstCommit.Format("2006-01-02") != "2025-01-01" || info.LastCommit.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("commit range = %v..%v", info.FirstCommit, info.LastCommit)
+	}
+	if len(authors) != 2 || authors[0].Email != "alice@example.org" || authors[0].Commits != 2 || authors[0].Additions != 4 {
+		t.Errorf("authors = %+v", authors)
+	}
+
+	if _, _, err := Read(context.Background(), t.TempDir()); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
@@ -0,0 +1,229 @@
+// Package jetstream subscribes to a Jetstream instance, which relays atproto
+// repo commits from the network firehose as JSON over a websocket.
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultURL is a public Jetstream instance operated by Bluesky.
+const DefaultURL = "wss://jetstream2.us-east.bsky.network/subscribe"
+
+// Commit operations.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// Event is a repo commit. Jetstream's identity and account events are not
+// delivered.
+type Event struct {
+	DID    string  `json:"did"`
+	TimeUS int64   `json:"time_us"` // Jetstream receive time in unix microseconds; the resume cursor
+	Kind   string  `json:"kind"`
+	Commit *Commit `json:"commit,omitempty"`
+}
+
+// Commit is the record operation carried by an Event.
+type Commit struct {
+	Rev        string         `json:"rev"`
+	Operation  string         `json:"operation"`
+	Collection string         `json:"collection"`
+	RKey       string         `json:"rkey"`
+	Record     map[string]any `json:"record,omitempty"` // absent on delete
+	CID        string         `json:"cid,omitempty"`
+}
+
+// URI returns the AT-URI of the record the event changed.
+func (e Event) URI() string {
+	return "at://" + e.DID + "/" + e.Commit.Collection + "/" + e.Commit.RKey
+}
+
+// Time returns the event's receive time.
+func (e Event) Time() time.Time {
+	return time.UnixMicro(e.TimeUS)
+}
+
+// Client subscribes to a Jetstream instance.
+type Client struct {
+	URL string // subscribe endpoint; DefaultURL if empty
+
+	// Collections are NSIDs or prefixes ending in ".*" (e.g.
+	// "org.hypercerts.*"). Empty means all collections.
+	Collections []string
+	// DIDs restricts events to these repos. Empty means all repos.
+	DIDs []string
+	// Cursor is the unix-microsecond time to replay from; 0 starts live.
+	// Subscribe advances it past each delivered event, so it can be
+	// persisted and passed back to resume without reprocessing.
+	Cursor int64
+
+	// OnReconnect, if set, is called when the connection drops and
+	// Subscribe is about to retry after wait.
+	OnReconnect func(err error, wait time.Duration)
+
+	Dialer *websocket.Dialer // websocket.DefaultDialer if nil
+}
+
+// Backoff bounds between reconnects. Variables so tests can shorten them.
+var (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// SubscribeURL returns the endpoint URL with filters and cursor as query parameters.
+func (c *Client) SubscribeURL() (string, error) {
+	base := c.URL
+	if base == "" {
+		base = DefaultURL
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid jetstream URL: %w", err)
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid jetstream URL %q: scheme must be ws or wss", base)
+	}
+	q := u.Query()
+	for _, coll := range c.Collections {
+		q.Add("wantedCollections", coll)
+	}
+	for _, did := range c.DIDs {
+		q.Add("wantedDids", did)
+	}
+	if c.Cursor > 0 {
+		q.Set("cursor", strconv.FormatInt(c.Cursor, 10))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Matches reports whether a commit event passes the client's filters. The
+// server applies the same filters; checking again keeps stand-ins and
+// unfiltered relays honest.
+func (c *Client) Matches(e Event) bool {
+	if e.Kind != "commit" || e.Commit == nil {
+		return false
+	}
+	if len(c.DIDs) > 0 && !slices.Contains(c.DIDs, e.DID) {
+		return false
+	}
+	if len(c.Collections) == 0 {
+		return true
+	}
+	for _, want := range c.Collections {
+		if prefix, ok := strings.CutSuffix(want, "*"); ok {
+			if strings.HasPrefix(e.Commit.Collection, prefix) {
+				return true
+			}
+		} else if e.Commit.Collection == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe streams matching events to fn until ctx is cancelled or fn
+// returns an error. Dropped connections are retried with exponential backoff,
+// resuming from the last delivered event. It returns nil on cancellation.
+func (c *Client) Subscribe(ctx context.Context, fn func(Event) error) error {
+	wait := minBackoff
+	for {
+		delivered, err := c.stream(ctx, fn)
+		if ctx.Err() != nil {
+			return nil
+		}
+		var fnErr *handlerError
+		if errors.As(err, &fnErr) {
+			return fnErr.err
+		}
+		if delivered {
+			wait = minBackoff
+		}
+		if c.OnReconnect != nil {
+			c.OnReconnect(err, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxBackoff)
+	}
+}
+
+// handlerError marks an error returned by the caller's fn, which ends
+// Subscribe instead of triggering a reconnect.
+type handlerError struct{ err error }
+
+func (e *handlerError) Error() string { return e.err.Error() }
+
+// stream runs one connection. delivered reports whether any event reached fn.
+func (c *Client) stream(ctx context.Context, fn func(Event) error) (delivered bool, err error) {
+	endpoint, err := c.SubscribeURL()
+	if err != nil {
+		return false, &handlerError{err}
+	}
+	dialer := c.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	conn, resp, err := dialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		if resp != nil {
+			return false, fmt.Errorf("jetstream connect failed: %s", resp.Status)
+		}
+		return false, fmt.Errorf("jetstream connect failed: %w", err)
+	}
+	defer conn.Close()
+
+	// Unblock ReadMessage when ctx is cancelled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return delivered, fmt.Errorf("jetstream connection lost: %w", err)
+		}
+		var e Event
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue // skip malformed frames rather than dropping the stream
+		}
+		if c.Cursor > 0 && e.TimeUS <= c.Cursor {
+			continue // already delivered before a reconnect
+		}
+		if !c.Matches(e) {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return delivered, &handlerError{err}
+		}
+		delivered = true
+		c.Cursor = e.TimeUS
+	}
+}
@@ -0,0 +1,134 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeJetstream serves frames to each connection, then closes it. It records
+// the query string of every connection.
+func fakeJetstream(t *testing.T, frames ...string) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, f := range frames {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(f)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+const (
+	frameActivity = `{"did":"did:plc:a","time_us":100,"kind":"commit","commit":{"operation":"create","collection":"org.hypercerts.claim.activity","rkey":"a1","cid":"bafy1","record":{"title":"Trees"}}}`
+	framePost     = `{"did":"did:plc:a","time_us":200,"kind":"commit","commit":{"operation":"create","collection":"app.bsky.feed.post","rkey":"p1"}}`
+	frameOtherDID = `{"did":"did:plc:b","time_us":300,"kind":"commit","commit":{"operation":"update","collection":"org.hypercerts.claim.activity","rkey":"b1"}}`
+	frameIdentity = `{"did":"did:plc:a","time_us":400,"kind":"identity"}`
+	frameDelete   = `{"did":"did:plc:a","time_us":500,"kind":"commit","commit":{"operation":"delete","collection":"app.certified.badge.award","rkey":"w1"}}`
+)
+
+func TestSubscribe(t *testing.T) {
+	minBackoff, maxBackoff = time.Millisecond, time.Millisecond
+	srv, queries := fakeJetstream(t, frameActivity, framePost, "not json", frameOtherDID, frameIdentity, frameDelete)
+
+	c := &Client{
+		URL:         srv.URL,
+		Collections: []string{"org.hypercerts.*", "app.certified.*"},
+		DIDs:        []string{"did:plc:a"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reconnects := 0
+	c.OnReconnect = func(error, time.Duration) {
+		reconnects++
+		if reconnects == 2 {
+			cancel()
+		}
+	}
+
+	var got []string
+	err := c.Subscribe(ctx, func(e Event) error {
+		got = append(got, e.Commit.Operation+" "+e.URI())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"create at://did:plc:a/org.hypercerts.claim.activity/a1",
+		"delete at://did:plc:a/app.certified.badge.award/w1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events = %q, want %q (replayed frames must not be redelivered)", got, want)
+	}
+	if c.Cursor != 500 {
+		t.Errorf("Cursor = %d, want 500", c.Cursor)
+	}
+	q := queries()
+	if len(q) < 2 {
+		t.Fatalf("connections = %d, want a reconnect", len(q))
+	}
+	if !strings.Contains(q[0], "wantedCollections=org.hypercerts.%2A") || !strings.Contains(q[0], "wantedDids=did%3Aplc%3Aa") || strings.Contains(q[0], "cursor") {
+		t.Errorf("first query = %s", q[0])
+	}
+	if !strings.Contains(q[1], "cursor=500") {
+		t.Errorf("reconnect query = %s, want cursor=500", q[1])
+	}
+}
+
+func TestSubscribeHandlerError(t *testing.T) {
+	srv, _ := fakeJetstream(t, frameActivity, frameDelete)
+	c := &Client{URL: srv.URL}
+	stop := errors.New("stop")
+	err := c.Subscribe(context.Background(), func(Event) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("Subscribe() = %v, want handler error", err)
+	}
+	if c.Cursor != 0 {
+		t.Errorf("Cursor = %d, a failed event must not advance it", c.Cursor)
+	}
+}
+
+func TestSubscribeURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"", DefaultURL, false},
+		{"http://localhost:6008/subscribe", "ws://localhost:6008/subscribe", false},
+		{"https://example.com/subscribe", "wss://example.com/subscribe", false},
+		{"ftp://example.com", "", true},
+	}
+	for _, tt := range tests {
+		got, err := (&Client{URL: tt.url}).SubscribeURL()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("SubscribeURL(%q) = %q, %v; want %q", tt.url, got, err, tt.want)
+		}
+	}
+}
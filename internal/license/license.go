@@ -0,0 +1,170 @@
+// Package license is a bundled catalog of common SPDX licenses and Creative
+// Commons variants used to fill rights records.
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+// License is a catalog entry.
+type License struct {
+	ID          string `json:"id"`          // SPDX identifier, e.g. "CC-BY-NC-SA-4.0"
+	Type        string `json:"type"`        // rightsType stored in records; max 10 chars
+	Name        string `json:"name"`        // rightsName
+	Description string `json:"description"` // rightsDescription
+	URL         string `json:"url"`         // canonical license text
+}
+
+// MaxTypeLen is the longest rightsType the lexicon accepts.
+const MaxTypeLen = 10
+
+var catalog = []License{
+	// Creative Commons
+	{"CC-BY-4.0", "CC-BY-4.0", "Creative Commons Attribution 4.0 International",
+		"Others may share and adapt the work for any purpose, including commercially, with attribution.",
+		"https://creativecommons.org/licenses/by/4.0/"},
+	{"CC-BY-SA-4.0", "CC-BY-SA-4", "Creative Commons Attribution-ShareAlike 4.0 International",
+		"Others may share and adapt the work for any purpose with attribution; adaptations must use the same license.",
+		"https://creativecommons.org/licenses/by-sa/4.0/"},
+	{"CC-BY-NC-4.0", "CC-BY-NC-4", "Creative Commons Attribution-NonCommercial 4.0 International",
+		"Others may share and adapt the work for non-commercial purposes, with attribution.",
+		"https://creativecommons.org/licenses/by-nc/4.0/"},
+	{"CC-BY-ND-4.0", "CC-BY-ND-4", "Creative Commons Attribution-NoDerivatives 4.0 International",
+		"Others may share the work unmodified for any purpose, with attribution.",
+		"https://creativecommons.org/licenses/by-nd/4.0/"},
+	{"CC-BY-NC-SA-4.0", "CC-BYNCSA4", "Creative Commons Attribution-NonCommercial-ShareAlike 4.0 International",
+		"Others may share and adapt the work for non-commercial purposes with attribution; adaptations must use the same license.",
+		"https://creativecommons.org/licenses/by-nc-sa/4.0/"},
+	{"CC-BY-NC-ND-4.0", "CC-BYNCND4", "Creative Commons Attribution-NonCommercial-NoDerivatives 4.0 International",
+		"Others may share the work unmodified for non-commercial purposes, with attribution.",
+		"https://creativecommons.org/licenses/by-nc-nd/4.0/"},
+	{"CC-BY-3.0", "CC-BY-3.0", "Creative Commons Attribution 3.0 Unported",
+		"Others may share and adapt the work for any purpose, including commercially, with attribution.",
+		"https://creativecommons.org/licenses/by/3.0/"},
+	{"CC-BY-SA-3.0", "CC-BY-SA-3", "Creative Commons Attribution-ShareAlike 3.0 Unported",
+		"Others may share and adapt the work for any purpose with attribution; adaptations must use the same license.",
+		"https://creativecommons.org/licenses/by-sa/3.0/"},
+	{"CC0-1.0", "CC0-1.0", "Creative Commons Zero v1.0 Universal",
+		"Dedicated to the public domain: anyone may use the work for any purpose without conditions.",
+		"https://creativecommons.org/publicdomain/zero/1.0/"},
+
+	// Open data
+	{"ODbL-1.0", "ODbL-1.0", "Open Data Commons Open Database License v1.0",
+		"Others may share and adapt the database with attribution; adapted databases must use the same license.",
+		"https://opendatacommons.org/licenses/odbl/1-0/"},
+	{"ODC-By-1.0", "ODC-By-1.0", "Open Data Commons Attribution License v1.0",
+		"Others may share and adapt the database for any purpose, with attribution.",
+		"https://opendatacommons.org/licenses/by/1-0/"},
+	{"PDDL-1.0", "PDDL-1.0", "Open Data Commons Public Domain Dedication & License 1.0",
+		"Dedicates the database to the public domain: anyone may use it without conditions.",
+		"https://opendatacommons.org/licenses/pddl/1-0/"},
+	{"CDLA-Permissive-2.0", "CDLA-P-2.0", "Community Data License Agreement Permissive 2.0",
+		"Others may use, modify, and share the data for any purpose; the license text must accompany shared data.",
+		"https://cdla.dev/permissive-2-0/"},
+
+	// Software
+	{"MIT", "MIT", "MIT License",
+		"Permissive software license: any use, with the copyright and license notice retained.",
+		"https://opensource.org/license/mit"},
+	{"Apache-2.0", "Apache-2.0", "Apache License 2.0",
+		"Permissive software license with an express patent grant; notices and a change record must be retained.",
+		"https://www.apache.org/licenses/LICENSE-2.0"},
+	{"BSD-2-Clause", "BSD-2", `BSD 2-Clause "Simplified" License`,
+		"Permissive software license: any use, with the copyright and license notice retained.",
+		"https://opensource.org/license/bsd-2-clause"},
+	{"BSD-3-Clause", "BSD-3", `BSD 3-Clause "New" or "Revised" License`,
+		"Permissive software license: any use with notices retained; contributors' names may not be used for endorsement.",
+		"https://opensource.org/license/bsd-3-clause"},
+	{"ISC", "ISC", "ISC License",
+		"Permissive software license: any use, with the copyright and license notice retained.",
+		"https://opensource.org/license/isc-license-txt"},
+	{"MPL-2.0", "MPL-2.0", "Mozilla Public License 2.0",
+		"Weak copyleft: modified files must stay under the MPL; larger works may use other licenses.",
+		"https://www.mozilla.org/en-US/MPL/2.0/"},
+	{"LGPL-3.0-only", "LGPL-3.0", "GNU Lesser General Public License v3.0 only",
+		"Weak copyleft: changes to the library must be shared under the LGPL; linking programs may use other licenses.",
+		"https://www.gnu.org/licenses/lgpl-3.0.html"},
+	{"GPL-3.0-only", "GPL-3.0", "GNU General Public License v3.0 only",
+		"Strong copyleft: distributed derivative works must be released under the GPL v3 with source.",
+		"https://www.gnu.org/licenses/gpl-3.0.html"},
+	{"GPL-3.0-or-later", "GPL-3.0+", "GNU General Public License v3.0 or later",
+		"Strong copyleft: distributed derivative works must be released under the GPL v3 or later with source.",
+		"https://www.gnu.org/licenses/gpl-3.0.html"},
+	{"AGPL-3.0-only", "AGPL-3.0", "GNU Affero General Public License v3.0 only",
+		"Strong copyleft that also requires offering source to users of a modified version over a network.",
+		"https://www.gnu.org/licenses/agpl-3.0.html"},
+	{"EUPL-1.2", "EUPL-1.2", "European Union Public License 1.2",
+		"Copyleft license compatible with several other copyleft licenses; derivative works must be shared.",
+		"https://interoperable-europe.ec.europa.eu/collection/eupl/eupl-text-eupl-12"},
+	{"Unlicense", "Unlicense", "The Unlicense",
+		"Dedicates the work to the public domain: anyone may use it without conditions.",
+		"https://unlicense.org/"},
+}
+
+// All returns every catalog entry.
+func All() []License {
+	return catalog
+}
+
+// Lookup finds a license by SPDX ID or rightsType, ignoring case.
+func Lookup(s string) (License, bool) {
+	s = strings.TrimSpace(s)
+	for _, l := range catalog {
+		if strings.EqualFold(l.ID, s) || strings.EqualFold(l.Type, s) {
+			return l, true
+		}
+	}
+	return License{}, false
+}
+
+// Search returns the entries whose ID or name contains query, ignoring case.
+func Search(query string) []License {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var out []License
+	for _, l := range catalog {
+		if strings.Contains(strings.ToLower(l.ID), q) || strings.Contains(strings.ToLower(l.Name), q) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// Status is the result of checking a rights record against the catalog.
+type Status string
+
+const (
+	StatusOK           Status = "ok"           // matches a catalog entry
+	StatusCustom       Status = "custom"       // type is not in the catalog
+	StatusInconsistent Status = "inconsistent" // fields contradict the catalog
+)
+
+// Check compares a rights record's type, name, and attachment URL (may be
+// empty) with the catalog, returning the matching entry when there is one and
+// the problems found.
+func Check(rightsType, name, url string) (License, Status, []string) {
+	l, ok := Lookup(rightsType)
+	if !ok {
+		// A known license name under an unknown type is a typo, not a custom license.
+		for _, c := range catalog {
+			if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+				return c, StatusInconsistent, []string{fmt.Sprintf("type %q should be %q for %s", rightsType, c.Type, c.ID)}
+			}
+		}
+		return License{}, StatusCustom, []string{fmt.Sprintf("type %q is not in the license catalog", rightsType)}
+	}
+	var problems []string
+	if l.Type != rightsType {
+		problems = append(problems, fmt.Sprintf("type %q should be %q", rightsType, l.Type))
+	}
+	if n := strings.TrimSpace(name); !strings.EqualFold(n, l.Name) && !strings.EqualFold(n, l.ID) && !strings.EqualFold(n, l.Type) {
+		problems = append(problems, fmt.Sprintf("name %q does not match %s (%s)", name, l.ID, l.Name))
+	}
+	if url != "" && strings.TrimSuffix(url, "/") != strings.TrimSuffix(l.URL, "/") {
+		problems = append(problems, fmt.Sprintf("attachment %s is not the %s license text (%s)", url, l.ID, l.URL))
+	}
+	if len(problems) > 0 {
+		return l, StatusInconsistent, problems
+	}
+	return l, StatusOK, nil
+}
@@ -0,0 +1,93 @@
+package license
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	owner := map[string]string{} // lowercased ID or type -> entry ID
+	for _, l := range All() {
+		if len(l.Type) > MaxTypeLen {
+			t.Errorf("%s: type %q is longer than %d chars", l.ID, l.Type, MaxTypeLen)
+		}
+		if l.Name == "" || l.Description == "" || !strings.HasPrefix(l.URL, "https://") {
+			t.Errorf("%s: incomplete entry %+v", l.ID, l)
+		}
+		for _, k := range []string{strings.ToLower(l.ID), strings.ToLower(l.Type)} {
+			if id, ok := owner[k]; ok && id != l.ID {
+				t.Errorf("%s: %q is already used by %s", l.ID, k, id)
+			}
+			owner[k] = l.ID
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"CC-BY-4.0", "CC-BY-4.0"},
+		{"cc-by-nc-sa-4.0", "CC-BY-NC-SA-4.0"},
+		{"CC-BYNCSA4", "CC-BY-NC-SA-4.0"},
+		{" MIT ", "MIT"},
+		{"GPL-3.0+", "GPL-3.0-or-later"},
+		{"proprietary", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			l, ok := Lookup(tt.input)
+			if l.ID != tt.want || ok != (tt.want != "") {
+				t.Errorf("Lookup(%q) = %q, %v; want %q", tt.input, l.ID, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	if got := Search(""); len(got) != len(All()) {
+		t.Errorf("Search(\"\") = %d entries, want all %d", len(got), len(All()))
+	}
+	for _, l := range Search("noncommercial") {
+		if !strings.Contains(l.ID, "-NC") {
+			t.Errorf("Search(noncommercial) returned %s", l.ID)
+		}
+	}
+	if got := Search("no such license"); len(got) != 0 {
+		t.Errorf("Search(no such license) = %v", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		rightsType string
+		rightsName string
+		url        string
+		wantID     string
+		want       Status
+		wantIssue  string
+	}{
+		{"catalog entry", "CC-BY-4.0", "Creative Commons Attribution 4.0 International", "https://creativecommons.org/licenses/by/4.0/", "CC-BY-4.0", StatusOK, ""},
+		{"name is the ID", "MIT", "mit", "", "MIT", StatusOK, ""},
+		{"url without slash", "CC0-1.0", "CC0-1.0", "https://creativecommons.org/publicdomain/zero/1.0", "CC0-1.0", StatusOK, ""},
+		{"custom", "Internal", "Company license", "", "", StatusCustom, "not in the license catalog"},
+		{"name mismatch", "CC-BY-4.0", "Creative Commons Attribution-NonCommercial", "", "CC-BY-4.0", StatusInconsistent, "does not match"},
+		{"url mismatch", "MIT", "MIT License", "https://example.com/license", "MIT", StatusInconsistent, "not the MIT license text"},
+		{"spdx id as type", "cc-by-sa-4.0", "CC-BY-SA-4.0", "", "CC-BY-SA-4.0", StatusInconsistent, `should be "CC-BY-SA-4"`},
+		{"known name, unknown type", "CC BY", "Creative Commons Attribution 4.0 International", "", "CC-BY-4.0", StatusInconsistent, `should be "CC-BY-4.0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, status, problems := Check(tt.rightsType, tt.rightsName, tt.url)
+			if l.ID != tt.wantID || status != tt.want {
+				t.Errorf("Check = %q, %s; want %q, %s", l.ID, status, tt.wantID, tt.want)
+			}
+			joined := strings.Join(problems, "; ")
+			if tt.wantIssue == "" && joined != "" || !strings.Contains(joined, tt.wantIssue) {
+				t.Errorf("problems = %q, want %q", joined, tt.wantIssue)
+			}
+		})
+	}
+}
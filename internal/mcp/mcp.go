@@ -0,0 +1,229 @@
+// Package mcp implements a Model Context Protocol server over stdio: JSON-RPC
+// 2.0 messages, one per line, exposing a fixed set of tools.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ProtocolVersion is the MCP revision this server implements.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions are revisions whose tool messages are compatible.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Handler runs a tool. args has been checked against the tool's required
+// properties. The result is returned to the client as JSON text.
+type Handler func(ctx context.Context, args map[string]any) (any, error)
+
+// Tool is a callable tool with a JSON Schema for its arguments.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+	Handler     Handler
+}
+
+// Server answers MCP requests for Tools.
+type Server struct {
+	Name    string
+	Version string
+	Tools   []Tool
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from in and writes responses to out until in is
+// exhausted or ctx is cancelled. Requests are handled concurrently.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	var mu sync.Mutex
+	enc := json.NewEncoder(out)
+	send := func(resp response) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = enc.Encode(resp)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for sc.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			send(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParseError, "parse error: " + err.Error()}})
+			continue
+		}
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			if req.ID != nil {
+				send(response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{codeInvalidRequest, "invalid request"}})
+			}
+			continue
+		}
+		if req.ID == nil {
+			continue // notification: nothing to answer
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, rerr := s.dispatch(ctx, req)
+			resp := response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rerr}
+			if rerr == nil && result == nil {
+				resp.Result = struct{}{}
+			}
+			send(resp)
+		}()
+	}
+	return sc.Err()
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		version := ProtocolVersion
+		if slices.Contains(supportedVersions, p.ProtocolVersion) {
+			version = p.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+			"serverInfo":      map[string]any{"name": s.Name, "version": s.Version},
+		}, nil
+	case "ping":
+		return nil, nil
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(s.Tools))
+		for _, t := range s.Tools {
+			tools = append(tools, map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			})
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	}
+	return nil, &rpcError{codeMethodNotFound, "method not found: " + req.Method}
+}
+
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{codeInvalidParams, "invalid params: " + err.Error()}
+	}
+	i := slices.IndexFunc(s.Tools, func(t Tool) bool { return t.Name == p.Name })
+	if i < 0 {
+		return nil, &rpcError{codeInvalidParams, "unknown tool: " + p.Name}
+	}
+	tool := s.Tools[i]
+	if p.Arguments == nil {
+		p.Arguments = map[string]any{}
+	}
+	if err := checkRequired(tool.InputSchema, p.Arguments); err != nil {
+		return toolError(err), nil
+	}
+
+	result, err := tool.Handler(ctx, p.Arguments)
+	if err != nil {
+		return toolError(err), nil
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolError(err), nil
+	}
+	out := map[string]any{
+		"content": []any{map[string]any{"type": "text", "text": string(text)}},
+		"isError": false,
+	}
+	if _, ok := result.(map[string]any); ok {
+		out["structuredContent"] = result
+	}
+	return out, nil
+}
+
+// toolError reports a failed call as a tool result, so the model sees it.
+func toolError(err error) map[string]any {
+	return map[string]any{
+		"content": []any{map[string]any{"type": "text", "text": err.Error()}},
+		"isError": true,
+	}
+}
+
+// checkRequired verifies that every required property is present and that
+// string properties hold non-empty strings.
+func checkRequired(schema map[string]any, args map[string]any) error {
+	props, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]string)
+	for _, name := range required {
+		v, ok := args[name]
+		if !ok || v == nil {
+			return fmt.Errorf("missing required argument %q", name)
+		}
+		if prop, _ := props[name].(map[string]any); prop["type"] == "string" {
+			if s, ok := v.(string); !ok || s == "" {
+				return fmt.Errorf("argument %q must be a non-empty string", name)
+			}
+		}
+	}
+	return nil
+}
+
+// StringArg returns the string argument name, or "" if it is absent.
+func StringArg(args map[string]any, name string) (string, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q must be a string", name)
+	}
+	return s, nil
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testServer() *Server {
+	return &Server{
+		Name:    "hc",
+		Version: "test",
+		Tools: []Tool{
+			{
+				Name:        "echo",
+				Description: "echo the text argument",
+				InputSchema: map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"text": map[string]any{"type": "string"}},
+					"required":   []string{"text"},
+				},
+				Handler: func(ctx context.Context, args map[string]any) (any, error) {
+					s, err := StringArg(args, "text")
+					if s == "fail" {
+						return nil, errors.New("echo failed")
+					}
+					return map[string]any{"text": s}, err
+				},
+			},
+		},
+	}
+}
+
+// roundTrip sends one request line and returns the decoded response, or nil
+// when the server wrote nothing.
+func roundTrip(t *testing.T, line string) map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := testServer().Serve(context.Background(), strings.NewReader(line+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() == 0 {
+		return nil
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("response %q: %v", out.String(), err)
+	}
+	return resp
+}
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   string
+		wantError float64 // JSON-RPC error code, 0 for a result
+		wantText  string  // substring of the JSON-encoded result
+	}{
+		{"initialize", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`, 0, `"protocolVersion":"2025-03-26"`},
+		{"initialize unknown version", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`, 0, `"protocolVersion":"` + ProtocolVersion + `"`},
+		{"ping", `{"jsonrpc":"2.0","id":"a","method":"ping"}`, 0, `{}`},
+		{"tools/list", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, 0, `"name":"echo"`},
+		{"call", `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`, 0, `"structuredContent":{"text":"hi"}`},
+		{"call failing", `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{"text":"fail"}}}`, 0, `"isError":true`},
+		{"missing argument", `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"echo"}}`, 0, `missing required argument`},
+		{"wrong argument type", `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"echo","arguments":{"text":3}}}`, 0, `non-empty string`},
+		{"unknown tool", `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"nope"}}`, codeInvalidParams, ""},
+		{"unknown method", `{"jsonrpc":"2.0","id":8,"method":"resources/list"}`, codeMethodNotFound, ""},
+		{"parse error", `{not json`, codeParseError, ""},
+		{"invalid request", `{"jsonrpc":"1.0","id":9,"method":"ping"}`, codeInvalidRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := roundTrip(t, tt.request)
+			if resp == nil {
+				t.Fatal("no response")
+			}
+			if tt.wantError != 0 {
+				e, _ := resp["error"].(map[string]any)
+				if e["code"] != tt.wantError {
+					t.Errorf("error = %v, want code %v", resp["error"], tt.wantError)
+				}
+				return
+			}
+			if resp["error"] != nil {
+				t.Fatalf("unexpected error %v", resp["error"])
+			}
+			result, _ := json.Marshal(resp["result"])
+			if !strings.Contains(string(result), tt.wantText) {
+				t.Errorf("result = %s, want it to contain %s", result, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestServeNotification(t *testing.T) {
+	if resp := roundTrip(t, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp != nil {
+		t.Errorf("notification got response %v", resp)
+	}
+}
@@ -0,0 +1,191 @@
+// Package source defines the interface shared by code-hosting importers
+// (GitHub, GitLab, Gitea/Forgejo) and the types they return.
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestTimeout bounds each API request an importer makes.
+const RequestTimeout = 10 * time.Second
+
+// RepoInfo holds parsed repo metadata.
+type RepoInfo struct {
+	Source      string   // host label used in activity descriptions, e.g. "GitHub"
+	Owner       string   // e.g. "GainForest"
+	Name        string   // e.g. "hypercerts-cli"
+	FullName    string   // e.g. "GainForest/hypercerts-cli"
+	Description string   // repo description
+	HTMLURL     string   // e.g. "https://github.com/GainForest/hypercerts-cli"
+	CreatedAt   string   // RFC3339 timestamp
+	PushedAt    string   // RFC3339 timestamp of the latest push or activity
+	Language    string   // primary language
+	Topics      []string // repo topics
+	License     string   // SPDX ID (e.g. "MIT"), empty if none
+	AvatarURL   string   // owner or project avatar URL
+	Stars       int      // stargazer count
+	Archived    bool     // repo is archived (read-only)
+	Fork        bool     // repo is a fork
+}
+
+// Contributor holds a repo contributor with commit count.
+type Contributor struct {
+	Login         string // username, or author name when the host has no account link
+	HTMLURL       string // profile URL, empty if unknown
+	DID           string // identity DID, e.g. from a git author map; empty if unknown
+	Email         string // commit email, when the host reports one
+	AvatarURL     string // avatar URL
+	Contributions int    // commit count — the default proportional weight
+	Additions     int    // lines added, when the host reports them with contributors
+	Bot           bool   // account is flagged as a bot by the host
+}
+
+// Identifier is the identity stored on contributor records: the DID or
+// profile URL when known, otherwise the login or author name. Contributor
+// records are public, so the commit email is never used; see EmailIdentifier.
+// A name is not unique, so only a linked identifier should be used for matching.
+func (c Contributor) Identifier() string {
+	switch {
+	case c.DID != "":
+		return c.DID
+	case c.HTMLURL != "":
+		return c.HTMLURL
+	}
+	return c.Login
+}
+
+// Linked reports whether c has a DID or profile URL to identify it.
+func (c Contributor) Linked() bool {
+	return c.DID != "" || c.HTMLURL != ""
+}
+
+// EmailIdentifier is like Identifier but uses a mailto: URI for the commit
+// email when c is not linked. Callers must only store it when the user opted
+// in to publishing emails.
+func (c Contributor) EmailIdentifier() string {
+	if !c.Linked() && c.Email != "" {
+		return "mailto:" + strings.ToLower(c.Email)
+	}
+	return c.Identifier()
+}
+
+// Importer reads repo metadata and contributors from a code host.
+type Importer interface {
+	// Name is the host label, e.g. "GitLab".
+	Name() string
+	// ParseRepo turns "owner/repo" or a web URL into the host's repo path.
+	ParseRepo(input string) (string, error)
+	FetchRepo(ctx context.Context, path string) (*RepoInfo, error)
+	FetchContributors(ctx context.Context, path string) ([]Contributor, error)
+}
+
+// StatusError is returned by GetJSON for non-200 responses.
+type StatusError struct {
+	Host       string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned %d", e.Host, e.StatusCode)
+}
+
+// GetJSON GETs rawURL with the given headers and decodes the JSON body into v.
+// It returns the response headers so callers can follow pagination.
+func GetJSON(ctx context.Context, client *http.Client, rawURL string, header http.Header, v any) (http.Header, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "hc-cli")
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", req.URL.Host, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Host: req.URL.Host, StatusCode: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// HasNextLink reports whether an RFC 8288 Link header has rel="next".
+func HasNextLink(linkHeader string) bool {
+	for link := range strings.SplitSeq(linkHeader, ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoPath extracts "owner/repo" (or "group/sub/repo") from input, which is
+// either a bare path or a web URL on baseURL's host. Trailing ".git" and
+// GitLab-style "/-/..." suffixes are dropped.
+func RepoPath(input, baseURL string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("empty repo input")
+	}
+	path := input
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		u, err := url.Parse(input)
+		if err != nil {
+			return "", fmt.Errorf("invalid URL: %w", err)
+		}
+		if base, err := url.Parse(baseURL); err == nil && base.Host != "" && !strings.EqualFold(base.Host, u.Host) {
+			return "", fmt.Errorf("URL host %s does not match %s", u.Host, base.Host)
+		}
+		path = u.Path
+		if base, err := url.Parse(baseURL); err == nil {
+			// Instances served under a subpath, e.g. https://example.org/git.
+			path = strings.TrimPrefix(path, strings.TrimRight(base.Path, "/"))
+		}
+	}
+	path, _, _ = strings.Cut(path, "/-/")
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid repo format: expected owner/repo, got %s", input)
+	}
+	for _, p := range parts {
+		if strings.TrimSpace(p) == "" {
+			return "", fmt.Errorf("invalid repo format: expected owner/repo, got %s", input)
+		}
+	}
+	return path, nil
+}
+
+// BaseFromURL returns scheme://host of a web URL, or "" if input is not a URL.
+func BaseFromURL(input string) string {
+	if !strings.HasPrefix(input, "http://") && !strings.HasPrefix(input, "https://") {
+		return ""
+	}
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,59 @@
+package source
+
+import "testing"
+
+func TestRepoPath(t *testing.T) {
+	tests := []struct {
+		input   string
+		base    string
+		want    string
+		wantErr bool
+	}{
+		{"owner/repo", "https://git.example.org", "owner/repo", false},
+		{"https://git.example.org/owner/repo", "https://git.example.org", "owner/repo", false},
+		{"https://example.org/git/owner/repo", "https://example.org/git", "owner/repo", false},
+		{"https://other.org/owner/repo", "https://git.example.org", "", true},
+		{"owner//repo", "", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := RepoPath(tt.input, tt.base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RepoPath(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepoPath(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasNextLink(t *testing.T) {
+	if !HasNextLink(`<https://x/?page=2>; rel="next", <https://x/?page=5>; rel="last"`) {
+		t.Error("expected next link")
+	}
+	if HasNextLink(`<https://x/?page=1>; rel="prev"`) || HasNextLink("") {
+		t.Error("unexpected next link")
+	}
+}
+
+func TestContributorIdentifier(t *testing.T) {
+	tests := []struct {
+		c         Contributor
+		want      string
+		wantEmail string
+	}{
+		{Contributor{Login: "alice", HTMLURL: "https://github.com/alice", Email: "a@x.org"}, "https://github.com/alice", "https://github.com/alice"},
+		{Contributor{Login: "Bob", Email: "Bob@X.org"}, "Bob", "mailto:bob@x.org"},
+		{Contributor{Login: "carol"}, "carol", "carol"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Identifier(); got != tt.want {
+			t.Errorf("Identifier() = %q, want %q", got, tt.want)
+		}
+		if got := tt.c.EmailIdentifier(); got != tt.wantEmail {
+			t.Errorf("EmailIdentifier() = %q, want %q", got, tt.wantEmail)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dimension groups units that can be converted into one another.
+type Dimension string
+
+const (
+	Mass     Dimension = "mass"
+	CO2e     Dimension = "co2e"
+	Area     Dimension = "area"
+	Length   Dimension = "length"
+	Volume   Dimension = "volume"
+	Energy   Dimension = "energy"
+	Duration Dimension = "time"
+	Labor    Dimension = "labor"
+	Trees    Dimension = "trees"
+	People   Dimension = "people"
+	Count    Dimension = "count"
+	Percent  Dimension = "percent"
+)
+
+// Unit is a registered unit of measurement.
+type Unit struct {
+	Symbol    string    // canonical form stored in records, e.g. "tCO2e"
+	Name      string    // human-readable name
+	Dimension Dimension // units convert only within the same dimension
+	Factor    float64   // multiplier to the dimension's base unit
+	Aliases   []string  // other spellings accepted on input
+}
+
+var registry = []Unit{
+	{"mg", "milligram", Mass, 1e-6, []string{"milligrams"}},
+	{"g", "gram", Mass, 1e-3, []string{"grams"}},
+	{"kg", "kilogram", Mass, 1, []string{"kilograms", "kilo", "kilos"}},
+	{"t", "tonne", Mass, 1e3, []string{"tonne", "tonnes", "metric ton", "metric tons"}},
+	{"lb", "pound", Mass, 0.45359237, []string{"lbs", "pound", "pounds"}},
+
+	{"gCO2e", "grams CO2 equivalent", CO2e, 1e-6, []string{"gCO2", "grams CO2"}},
+	{"kgCO2e", "kilograms CO2 equivalent", CO2e, 1e-3, []string{"kgCO2", "kg CO2", "kilograms CO2"}},
+	{"tCO2e", "tonnes CO2 equivalent", CO2e, 1, []string{
+		"tCO2", "t CO2", "tonnes CO2", "tonne CO2", "tonnes CO2e", "tonne CO2e",
+		"tonnes CO2 equivalent", "metric tons CO2", "metric tons CO2e", "carbon credits",
+	}},
+	{"MtCO2e", "megatonnes CO2 equivalent", CO2e, 1e6, []string{"MtCO2", "megatonnes CO2"}},
+
+	{"m2", "square metre", Area, 1, []string{"m²", "sq m", "square meter", "square meters", "square metre", "square metres"}},
+	{"ha", "hectare", Area, 1e4, []string{"hectare", "hectares"}},
+	{"km2", "square kilometre", Area, 1e6, []string{"km²", "sq km", "square kilometer", "square kilometers", "square kilometre", "square kilometres"}},
+	{"acre", "acre", Area, 4046.8564224, []string{"acres", "ac"}},
+
+	{"cm", "centimetre", Length, 0.01, []string{"centimeter", "centimeters", "centimetre", "centimetres"}},
+	{"m", "metre", Length, 1, []string{"meter", "meters", "metre", "metres"}},
+	{"km", "kilometre", Length, 1e3, []string{"kilometer", "kilometers", "kilometre", "kilometres"}},
+	{"mi", "mile", Length, 1609.344, []string{"mile", "miles"}},
+
+	{"mL", "millilitre", Volume, 1e-3, []string{"ml", "milliliter", "milliliters", "millilitre", "millilitres"}},
+	{"L", "litre", Volume, 1, []string{"liter", "liters", "litre", "litres"}},
+	{"m3", "cubic metre", Volume, 1e3, []string{"m³", "cubic meter", "cubic meters", "cubic metre", "cubic metres"}},
+
+	{"Wh", "watt-hour", Energy, 1e-3, []string{"watt-hours", "watt hours"}},
+	{"kWh", "kilowatt-hour", Energy, 1, []string{"kilowatt-hours", "kilowatt hours"}},
+	{"MWh", "megawatt-hour", Energy, 1e3, []string{"megawatt-hours", "megawatt hours"}},
+	{"GWh", "gigawatt-hour", Energy, 1e6, []string{"gigawatt-hours", "gigawatt hours"}},
+
+	{"min", "minute", Duration, 1.0 / 60, []string{"minute", "minutes", "mins"}},
+	{"h", "hour", Duration, 1, []string{"hr", "hrs", "hour", "hours"}},
+	{"d", "day", Duration, 24, []string{"day", "days"}},
+
+	{"person-hours", "person-hours", Labor, 1, []string{"person-hour", "person hours", "volunteer hours", "work hours", "man-hours"}},
+	{"person-days", "person-days", Labor, 8, []string{"person-day", "person days", "volunteer days", "work days", "man-days"}},
+
+	{"trees", "trees", Trees, 1, []string{"tree", "trees planted"}},
+	{"people", "people", People, 1, []string{"person", "persons", "beneficiaries", "individuals"}},
+	{"count", "count", Count, 1, []string{"items", "units", "pcs", "#"}},
+	{"%", "percent", Percent, 1, []string{"percent", "percentage", "pct"}},
+}
+
+var index = func() map[string]Unit {
+	m := make(map[string]Unit)
+	for _, u := range registry {
+		for _, s := range append([]string{u.Symbol, u.Name}, u.Aliases...) {
+			k := key(s)
+			if prev, ok := m[k]; ok && prev.Symbol != u.Symbol {
+				panic(fmt.Sprintf("units: %q is registered for both %s and %s", s, prev.Symbol, u.Symbol))
+			}
+			m[k] = u
+		}
+	}
+	return m
+}()
+
+// key folds case, whitespace, and sub/superscript digits so "t CO₂e" matches "tCO2e".
+func key(s string) string {
+	s = strings.NewReplacer("₂", "2", "²", "2", "³", "3", " ", "", "\t", "").Replace(s)
+	return strings.ToLower(s)
+}
+
+// All returns every registered unit.
+func All() []Unit {
+	return append([]Unit(nil), registry...)
+}
+
+// Lookup finds a unit by symbol, name, or alias.
+func Lookup(s string) (Unit, bool) {
+	u, ok := index[key(strings.TrimSpace(s))]
+	return u, ok
+}
+
+// Normalize returns the canonical symbol for s, or s trimmed if it is not registered.
+func Normalize(s string) string {
+	if u, ok := Lookup(s); ok {
+		return u.Symbol
+	}
+	return strings.TrimSpace(s)
+}
+
+// Compatible reports whether values in unit a can be converted to unit b.
+func Compatible(a, b string) bool {
+	ua, okA := Lookup(a)
+	ub, okB := Lookup(b)
+	return okA && okB && ua.Dimension == ub.Dimension
+}
+
+// Convert converts value from one unit to another.
+// It fails if either unit is unknown or they measure different dimensions.
+func Convert(value float64, from, to string) (float64, error) {
+	uf, ok := Lookup(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	ut, ok := Lookup(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if uf.Dimension != ut.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", uf.Symbol, uf.Dimension, ut.Symbol, ut.Dimension)
+	}
+	return value * uf.Factor / ut.Factor, nil
+}
+
+// ParseValue parses a numeric measurement value. Thousands separators
+// ("1,500") and surrounding whitespace are accepted.
+func ParseValue(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	s = strings.ReplaceAll(s, "_", "")
+	if s == "" {
+		return 0, fmt.Errorf("value is required")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("value %q is not a number", s)
+	}
+	return v, nil
+}
+
+// FormatValue formats a value without trailing zeros or float noise from conversion.
+func FormatValue(v float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,99 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"tonnes CO2", "tCO2e"},
+		{"tCO2e", "tCO2e"},
+		{"t CO2", "tCO2e"},
+		{"t CO₂e", "tCO2e"},
+		{"TCO2E", "tCO2e"},
+		{"Hectares", "ha"},
+		{"km²", "km2"},
+		{"person hours", "person-hours"},
+		{"tree", "trees"},
+		{"  kg ", "kg"},
+		{"widgets", "widgets"},
+		{"  widgets ", "widgets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		from    string
+		to      string
+		want    string
+		wantErr bool
+	}{
+		{"kg_to_tonnes_co2e", 1500, "kgCO2e", "tCO2e", "1.5", false},
+		{"ha_to_m2", 2.5, "hectares", "m2", "25000", false},
+		{"acres_to_ha", 1, "acre", "ha", "0.40468564224", false},
+		{"person_days_to_hours", 3, "person-days", "person hours", "24", false},
+		{"same_unit", 42, "trees", "tree", "42", false},
+		{"incompatible", 1, "kg", "ha", "", true},
+		{"unknown_from", 1, "widgets", "kg", "", true},
+		{"unknown_to", 1, "kg", "widgets", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.value, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && FormatValue(got) != tt.want {
+				t.Errorf("Convert() = %s, want %s", FormatValue(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"1500", 1500, false},
+		{"1,500.5", 1500.5, false},
+		{" -3.2 ", -3.2, false},
+		{"1e3", 1000, false},
+		{"", 0, true},
+		{"about 10", 0, true},
+		{"NaN", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseValue(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseValue(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseValue(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	if got := FormatValue(0.1 + 0.2); got != "0.3" {
+		t.Errorf("FormatValue(0.1+0.2) = %q, want 0.3", got)
+	}
+	if got := FormatValue(1500); got != "1500" {
+		t.Errorf("FormatValue(1500) = %q, want 1500", got)
+	}
+}